@@ -18,8 +18,13 @@ import (
 type K8STestSetup struct {
 	WaitForPods         []string
 	PortForwardServices map[string]int
+	// WaitForIngresses and WaitForLoadBalancers are names of Ingresses and Services type=LoadBalancer
+	// to wait for until an external address is assigned
+	WaitForIngresses     []string
+	WaitForLoadBalancers []string
 
-	forwards map[string]int
+	forwards  map[string]int
+	endpoints map[string][]string
 
 	cmd *exec.Cmd
 
@@ -41,6 +46,7 @@ var setupCMD = flag.String("setup", "", "the path to the it setup command")
 // to teardown the test namespace
 func (s *K8STestSetup) TestMain(m *testing.M) {
 	s.forwards = make(map[string]int)
+	s.endpoints = make(map[string][]string)
 	wg := new(sync.WaitGroup)
 	wg.Add(2) // there will be 2 goroutines, one reading stdout and one reading stdin
 	os.Exit(func() int {
@@ -71,6 +77,16 @@ func (s *K8STestSetup) GetServiceLocalPort(serviceName string) int {
 	return s.forwards[serviceName]
 }
 
+// GetIngressEndpoints returns host:port endpoints of the Ingress
+func (s *K8STestSetup) GetIngressEndpoints(name string) []string {
+	return s.endpoints["ingress/"+name]
+}
+
+// GetLoadBalancerEndpoints returns host:port endpoints of the Service type=LoadBalancer
+func (s *K8STestSetup) GetLoadBalancerEndpoints(name string) []string {
+	return s.endpoints["service/"+name]
+}
+
 func (s *K8STestSetup) before(wg *sync.WaitGroup) {
 	log.Printf("setup command: %s\n", *setupCMD)
 
@@ -81,6 +97,12 @@ func (s *K8STestSetup) before(wg *sync.WaitGroup) {
 	for service, port := range s.PortForwardServices {
 		args = append(args, fmt.Sprintf("-portforward=%s:%d", service, port))
 	}
+	for _, ingress := range s.WaitForIngresses {
+		args = append(args, fmt.Sprintf("-wait_for_ingress=%s", ingress))
+	}
+	for _, service := range s.WaitForLoadBalancers {
+		args = append(args, fmt.Sprintf("-wait_for_loadbalancer=%s", service))
+	}
 
 	s.cmd = exec.Command(*setupCMD, args...)
 
@@ -136,6 +158,12 @@ waitForReady:
 			localPort, _ := strconv.Atoi(parts[2])
 			s.forwards[parts[0]] = localPort
 		}
+		if strings.HasPrefix(str, "ENDPOINT ") {
+			// format: ENDPOINT kind/name host:port
+			if fields := strings.Fields(str); len(fields) == 3 {
+				s.endpoints[fields[1]] = append(s.endpoints[fields[1]], fields[2])
+			}
+		}
 		if "READY\n" == str {
 			break waitForReady
 		}
@@ -11,14 +11,30 @@ governing permissions and limitations under the License.
 */
 package bazel
 
-import "strings"
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+)
 
 // TargetToExecutable converts bazel target name to respective executable name in bazel-bin
 func TargetToExecutable(target string) string {
+	return targetToExecutable(target, runtime.GOOS)
+}
+
+func targetToExecutable(target, goos string) string {
 	if !strings.HasPrefix(target, "//") {
 		return target
 	}
 	target = "bazel-bin/" + target[2:]
 	target = strings.Replace(target, ":", "/", 1)
-	return target
+	if goos == "windows" {
+		// bazel creates .exe launchers for all executable rules on Windows
+		target = strings.ReplaceAll(target, "/", `\`)
+		if !strings.HasSuffix(target, ".exe") {
+			target += ".exe"
+		}
+		return target
+	}
+	return filepath.FromSlash(target)
 }
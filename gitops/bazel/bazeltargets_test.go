@@ -11,7 +11,10 @@ governing permissions and limitations under the License.
 */
 package bazel
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestTargetToExecutableHappypath(t *testing.T) {
 	s := TargetToExecutable("//rtb/bidder:rtb-uat-k8s01-iad-1b-bidder-first-uat.gitops")
@@ -19,3 +22,28 @@ func TestTargetToExecutableHappypath(t *testing.T) {
 		t.Error("unexpected result", s)
 	}
 }
+
+func TestCommandArgs(t *testing.T) {
+	c := Command{
+		Bin:            "bazel",
+		OutputBase:     "/tmp/ob",
+		StartupOptions: []string{"--host_jvm_args=-Xmx2g"},
+		Flags:          []string{"--config=ci"},
+	}
+	args := c.Args("cquery", "//...", "--output=proto")
+	expected := []string{"--output_base=/tmp/ob", "--host_jvm_args=-Xmx2g", "cquery", "--config=ci", "//...", "--output=proto"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Error("unexpected result", args)
+	}
+}
+
+func TestTargetToExecutableWindows(t *testing.T) {
+	s := targetToExecutable("//rtb/bidder:bidder.gitops", "windows")
+	if s != `bazel-bin\rtb\bidder\bidder.gitops.exe` {
+		t.Error("unexpected result", s)
+	}
+	s = targetToExecutable(`C:\out\bidder.gitops.exe`, "windows")
+	if s != `C:\out\bidder.gitops.exe` {
+		t.Error("unexpected result", s)
+	}
+}
@@ -0,0 +1,51 @@
+package bazel
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+)
+
+// Command describes how to invoke bazel so that every phase of a run talks to the same bazel server.
+// Bazel keeps one server per output base and restarts it (dropping the analysis cache) whenever startup options
+// change, and discards the analysis cache when build options change between invocations. Using the same
+// Command for all invocations avoids both.
+type Command struct {
+	// Bin is the bazel binary to execute
+	Bin string
+	// OutputBase pins the server output base (--output_base startup option) if not empty
+	OutputBase string
+	// StartupOptions are passed before the bazel command
+	StartupOptions []string
+	// Flags are passed after the bazel command to every invocation
+	Flags []string
+}
+
+// Args returns the full argument list for bazel command with args
+func (c *Command) Args(command string, args ...string) []string {
+	var v []string
+	if c.OutputBase != "" {
+		v = append(v, "--output_base="+c.OutputBase)
+	}
+	v = append(v, c.StartupOptions...)
+	v = append(v, command)
+	v = append(v, c.Flags...)
+	v = append(v, args...)
+	return v
+}
+
+// Cmd returns exec.Cmd running bazel command with args
+func (c *Command) Cmd(command string, args ...string) *exec.Cmd {
+	return exec.Command(c.Bin, c.Args(command, args...)...)
+}
+
+// CmdContext is Cmd killing bazel when ctx is done
+func (c *Command) CmdContext(ctx context.Context, command string, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, c.Bin, c.Args(command, args...)...)
+}
+
+// Version returns the output of bazel --version, like "bazel 7.1.0"
+func (c *Command) Version() (string, error) {
+	out, err := exec.Command(c.Bin, "--version").Output()
+	return strings.TrimSpace(string(out)), err
+}
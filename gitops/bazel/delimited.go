@@ -0,0 +1,39 @@
+package bazel
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ReadDelimited reads a stream of varint length-delimited messages (the format of --output=streamed_proto)
+// from r and calls fn for every message. The buffer passed to fn is reused for the next message,
+// so fn must not retain it. Messages larger than maxSize are rejected.
+func ReadDelimited(r io.Reader, maxSize int, fn func([]byte) error) error {
+	br := bufio.NewReader(r)
+	var buf []byte
+	for {
+		size, err := binary.ReadUvarint(br)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read message size: %w", err)
+		}
+		if size > uint64(maxSize) {
+			return fmt.Errorf("message size %d exceeds limit %d", size, maxSize)
+		}
+		if uint64(cap(buf)) < size {
+			buf = make([]byte, size)
+		}
+		buf = buf[:size]
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return fmt.Errorf("unable to read message of size %d: %w", size, err)
+		}
+		if err := fn(buf); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package bazel
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func delimited(msgs ...string) []byte {
+	var b []byte
+	for _, m := range msgs {
+		b = binary.AppendUvarint(b, uint64(len(m)))
+		b = append(b, m...)
+	}
+	return b
+}
+
+func TestReadDelimited(t *testing.T) {
+	long := string(bytes.Repeat([]byte("x"), 300))
+	var got []string
+	err := ReadDelimited(bytes.NewReader(delimited("first", "", long)), 1024, func(b []byte) error {
+		got = append(got, string(b))
+		return nil
+	})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if !reflect.DeepEqual(got, []string{"first", "", long}) {
+		t.Error("unexpected messages", got)
+	}
+}
+
+func TestReadDelimitedLimits(t *testing.T) {
+	fn := func([]byte) error { return nil }
+	if err := ReadDelimited(bytes.NewReader(delimited("toolong")), 3, fn); err == nil {
+		t.Error("expected size limit error")
+	}
+	truncated := delimited("message")
+	if err := ReadDelimited(bytes.NewReader(truncated[:4]), 1024, fn); err == nil {
+		t.Error("expected truncated message error")
+	}
+}
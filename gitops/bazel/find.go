@@ -0,0 +1,53 @@
+package bazel
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Find returns the bazel binary to use in the workspace dir and why it was chosen:
+//   - bazelisk on PATH, it honors .bazelversion and runs tools/bazel wrappers with BAZEL_REAL set
+//   - tools/bazel of the workspace if BAZEL_REAL is set, like when the tool itself runs under bazelisk
+//   - BAZEL_REAL
+//   - tools/bazel of the workspace
+//   - bazel on PATH
+//
+// getenv and lookPath are os.Getenv and exec.LookPath outside of tests.
+func Find(dir string, getenv func(string) string, lookPath func(string) (string, error)) (bin, reason string) {
+	wrapper := filepath.Join(dir, "tools", "bazel")
+	fi, err := os.Stat(wrapper)
+	hasWrapper := err == nil && fi.Mode().IsRegular() && fi.Mode()&0111 != 0
+	real := getenv("BAZEL_REAL")
+	if p, err := lookPath("bazelisk"); err == nil {
+		return p, "bazelisk found on PATH"
+	}
+	if hasWrapper && real != "" {
+		return "tools/bazel", "workspace wrapper tools/bazel with BAZEL_REAL=" + real
+	}
+	if real != "" {
+		return real, "BAZEL_REAL environment variable"
+	}
+	if hasWrapper {
+		return "tools/bazel", "workspace wrapper tools/bazel"
+	}
+	if p, err := lookPath("bazel"); err == nil {
+		return p, "bazel found on PATH"
+	}
+	return "bazel", "no bazel found on PATH"
+}
+
+// FindDefault is Find using the process environment
+func FindDefault(dir string) (bin, reason string) {
+	return Find(dir, os.Getenv, exec.LookPath)
+}
+
+// PinnedVersion returns the bazel version pinned by .bazelversion of the workspace dir or empty string
+func PinnedVersion(dir string) string {
+	b, err := os.ReadFile(filepath.Join(dir, ".bazelversion"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
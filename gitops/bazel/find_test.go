@@ -0,0 +1,69 @@
+package bazel
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	plain := t.TempDir()
+	withWrapper := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(withWrapper, "tools"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(withWrapper, "tools", "bazel"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := func(bins ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, b := range bins {
+				if b == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+	}
+	env := func(real string) func(string) string {
+		return func(k string) string {
+			if k == "BAZEL_REAL" {
+				return real
+			}
+			return ""
+		}
+	}
+	tests := []struct {
+		name     string
+		dir      string
+		real     string
+		lookPath func(string) (string, error)
+		expected string
+	}{
+		{"bazelisk first", withWrapper, "/opt/bazel", path("bazelisk", "bazel"), "/usr/bin/bazelisk"},
+		{"wrapper under bazelisk", withWrapper, "/opt/bazel", path("bazel"), "tools/bazel"},
+		{"bazel real", plain, "/opt/bazel", path("bazel"), "/opt/bazel"},
+		{"wrapper", withWrapper, "", path("bazel"), "tools/bazel"},
+		{"bazel on path", plain, "", path("bazel"), "/usr/bin/bazel"},
+		{"nothing", plain, "", path(), "bazel"},
+	}
+	for _, tt := range tests {
+		if bin, reason := Find(tt.dir, env(tt.real), tt.lookPath); bin != tt.expected {
+			t.Errorf("%s: Find() = %s (%s), expected %s", tt.name, bin, reason, tt.expected)
+		}
+	}
+}
+
+func TestPinnedVersion(t *testing.T) {
+	dir := t.TempDir()
+	if v := PinnedVersion(dir); v != "" {
+		t.Errorf("unexpected version %q", v)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".bazelversion"), []byte("7.1.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if v := PinnedVersion(dir); v != "7.1.0" {
+		t.Errorf("unexpected version %q", v)
+	}
+}
@@ -0,0 +1,41 @@
+package bazel
+
+import "strings"
+
+// DefaultTargets is the query expression of targets scanned when no target patterns are given
+const DefaultTargets = "//... except //experimental/..."
+
+// TargetPatterns combines target patterns into a query expression the way the bazel command line does:
+// patterns are applied in order, a pattern is added to the union of the previous ones and a pattern starting
+// with - is subtracted from it. Excluding patterns without a preceding pattern apply to //...
+// Patterns may also be query expressions, like //... except //experimental/...
+// DefaultTargets is returned if there are no patterns.
+func TargetPatterns(patterns []string) string {
+	if len(patterns) == 0 {
+		return DefaultTargets
+	}
+	var expr string
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		switch {
+		case strings.HasPrefix(p, "-"):
+			if expr == "" {
+				expr = "//..."
+			}
+			expr = operand(expr) + " except " + operand(p[1:])
+		case expr == "":
+			expr = p
+		default:
+			expr = operand(expr) + " union " + operand(p)
+		}
+	}
+	return expr
+}
+
+// operand parenthesizes query expressions used as an operand
+func operand(p string) string {
+	if strings.ContainsAny(p, " \t") {
+		return "(" + p + ")"
+	}
+	return p
+}
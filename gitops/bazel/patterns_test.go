@@ -0,0 +1,24 @@
+package bazel
+
+import "testing"
+
+func TestTargetPatterns(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		expected string
+	}{
+		{nil, DefaultTargets},
+		{[]string{"//services/..."}, "//services/..."},
+		{[]string{"//... except //experimental/..."}, "//... except //experimental/..."},
+		{[]string{"//services/...", "//infra/..."}, "//services/... union //infra/..."},
+		{[]string{"//services/...", "//infra/...", "-//experimental/..."}, "(//services/... union //infra/...) except //experimental/..."},
+		{[]string{"-//experimental/..."}, "//... except //experimental/..."},
+		{[]string{"//a/...", "-//a/b/...", "//a/b/c:t"}, "(//a/... except //a/b/...) union //a/b/c:t"},
+		{[]string{"//a/... except //a/x/...", "//b/..."}, "(//a/... except //a/x/...) union //b/..."},
+	}
+	for _, tt := range tests {
+		if expr := TargetPatterns(tt.patterns); expr != tt.expected {
+			t.Errorf("TargetPatterns(%q) = %q, want %q", tt.patterns, expr, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package bazel
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// RunfilesDigest returns a sha256 digest of an executable and the content of all its runfiles.
+// Runfiles are read from the <executable>.runfiles_manifest file produced by bazel.
+// The digest changes whenever anything the executable can read at runtime changes.
+func RunfilesDigest(executable string) (string, error) {
+	h := sha256.New()
+	if err := hashFile(h, executable); err != nil {
+		return "", err
+	}
+	f, err := os.Open(executable + ".runfiles_manifest")
+	if err != nil {
+		return "", fmt.Errorf("unable to read runfiles manifest: %w", err)
+	}
+	defer f.Close()
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("unable to read runfiles manifest: %w", err)
+	}
+	sort.Strings(lines)
+	for _, line := range lines {
+		// format: <runfiles path> <absolute path>, absolute path is empty for empty files
+		rel, abs, _ := strings.Cut(line, " ")
+		fmt.Fprintf(h, "%s\n", rel)
+		if abs == "" {
+			continue
+		}
+		err := filepath.WalkDir(abs, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			fmt.Fprintf(h, "%s\n", strings.TrimPrefix(path, abs))
+			return hashFile(h, path)
+		})
+		if err != nil {
+			return "", fmt.Errorf("unable to hash runfile %s: %w", rel, err)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
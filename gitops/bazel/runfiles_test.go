@@ -0,0 +1,52 @@
+package bazel
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunfilesDigest(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "app.gitops")
+	data := filepath.Join(dir, "deployment.yaml")
+	mustWrite := func(path, content string) {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mustWrite(exe, "#!/bin/sh\n")
+	mustWrite(data, "kind: Deployment\n")
+	mustWrite(exe+".runfiles_manifest", fmt.Sprintf("main/deployment.yaml %s\nmain/__init__.py \n", data))
+
+	d1, err := RunfilesDigest(exe)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	d2, err := RunfilesDigest(exe)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if d1 != d2 {
+		t.Error("digest is not stable", d1, d2)
+	}
+	mustWrite(data, "kind: StatefulSet\n")
+	d3, err := RunfilesDigest(exe)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if d1 == d3 {
+		t.Error("digest did not change after runfile change")
+	}
+}
+
+func TestRunfilesDigestNoManifest(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "app.gitops")
+	if err := os.WriteFile(exe, []byte("#!/bin/sh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RunfilesDigest(exe); err == nil {
+		t.Error("expected error for missing runfiles manifest")
+	}
+}
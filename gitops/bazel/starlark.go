@@ -0,0 +1,43 @@
+package bazel
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// NormalizeLabel converts a label printed by Starlark str(Label) to the form used by query output.
+// Main repository labels like @//pkg:name and @@//pkg:name become //pkg:name.
+func NormalizeLabel(label string) string {
+	if strings.HasPrefix(label, "@@//") {
+		return label[2:]
+	}
+	if strings.HasPrefix(label, "@//") {
+		return label[1:]
+	}
+	return label
+}
+
+// ReadTabSeparated reads lines of tab separated fields produced by cquery --output=starlark.
+// Empty lines are skipped. fn is called with fields of every line; the first field is a normalized label.
+// It is an error if a line has fewer than minFields fields.
+func ReadTabSeparated(r io.Reader, minFields int, fn func(fields []string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16<<20)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) < minFields {
+			return fmt.Errorf("line %d: expected at least %d tab separated fields, got %q", line, minFields, text)
+		}
+		fields[0] = NormalizeLabel(fields[0])
+		if err := fn(fields); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,45 @@
+package bazel
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeLabel(t *testing.T) {
+	tests := map[string]string{
+		"//app:prod":          "//app:prod",
+		"@//app:prod":         "//app:prod",
+		"@@//app:prod":        "//app:prod",
+		"@other//app:prod":    "@other//app:prod",
+		"@@other~1.0//x:prod": "@@other~1.0//x:prod",
+	}
+	for in, expected := range tests {
+		if got := NormalizeLabel(in); got != expected {
+			t.Errorf("NormalizeLabel(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+func TestReadTabSeparated(t *testing.T) {
+	in := "@//app:prod.gitops\tprod\tmaster\n\n//app:dev.gitops\tdev\tmaster\r\n"
+	var got [][]string
+	err := ReadTabSeparated(strings.NewReader(in), 2, func(fields []string) error {
+		got = append(got, fields)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]string{
+		{"//app:prod.gitops", "prod", "master"},
+		{"//app:dev.gitops", "dev", "master"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected fields %q", got)
+	}
+	err = ReadTabSeparated(strings.NewReader("//app:prod.gitops\n"), 2, func([]string) error { return nil })
+	if err == nil {
+		t.Error("expected error for a line without deployment branch")
+	}
+}
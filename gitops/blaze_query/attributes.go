@@ -0,0 +1,75 @@
+package blaze_query
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrConfigurable is returned for attributes set with select() whose value depends on the configuration
+// and can't be determined from the rule alone
+var ErrConfigurable = errors.New("attribute value depends on the configuration")
+
+// Attr returns the attribute of the rule with the name or nil if the rule has no such attribute
+func (x *Rule) Attr(name string) *Attribute {
+	for _, a := range x.GetAttribute() {
+		if a.GetName() == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// StringAttr returns the value of a STRING, LABEL or OUTPUT attribute.
+// An error is returned if the attribute is missing or has another type.
+// Attributes set with select() are resolved if every branch of every select has the same value,
+// otherwise an error wrapping ErrConfigurable is returned.
+func (x *Rule) StringAttr(name string) (string, error) {
+	a := x.Attr(name)
+	if a == nil {
+		return "", fmt.Errorf("rule %s has no attribute %s", x.GetName(), name)
+	}
+	switch a.GetType() {
+	case Attribute_STRING, Attribute_LABEL, Attribute_OUTPUT:
+		if a.GetSelectorList() == nil || a.StringValue != nil {
+			return a.GetStringValue(), nil
+		}
+		v, ok := a.GetSelectorList().uniformString()
+		if !ok {
+			return "", fmt.Errorf("attribute %s of rule %s is set with select(): %w", name, x.GetName(), ErrConfigurable)
+		}
+		return v, nil
+	}
+	return "", fmt.Errorf("attribute %s of rule %s has type %s, expected a string", name, x.GetName(), a.GetType())
+}
+
+// StringListAttr returns the value of a STRING_LIST, LABEL_LIST or OUTPUT_LIST attribute.
+// An error is returned if the attribute is missing or has another type.
+func (x *Rule) StringListAttr(name string) ([]string, error) {
+	a := x.Attr(name)
+	if a == nil {
+		return nil, fmt.Errorf("rule %s has no attribute %s", x.GetName(), name)
+	}
+	switch a.GetType() {
+	case Attribute_STRING_LIST, Attribute_LABEL_LIST, Attribute_OUTPUT_LIST:
+		return a.GetStringListValue(), nil
+	}
+	return nil, fmt.Errorf("attribute %s of rule %s has type %s, expected a list of strings", name, x.GetName(), a.GetType())
+}
+
+// uniformString returns the concatenated value of string selects if all branches of each select have the same value
+func (x *Attribute_SelectorList) uniformString() (string, bool) {
+	var v string
+	for _, sel := range x.GetElements() {
+		entries := sel.GetEntries()
+		if len(entries) == 0 {
+			return "", false
+		}
+		for _, e := range entries[1:] {
+			if e.GetStringValue() != entries[0].GetStringValue() {
+				return "", false
+			}
+		}
+		v += entries[0].GetStringValue()
+	}
+	return v, true
+}
@@ -0,0 +1,82 @@
+package blaze_query
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func testRule(t *testing.T) *Rule {
+	r := &Rule{
+		Name:      proto.String("//app:prod.gitops"),
+		RuleClass: proto.String("gitops"),
+		Attribute: []*Attribute{
+			{Name: proto.String("deployment_branch"), Type: Attribute_STRING.Enum(), StringValue: proto.String("prod")},
+			{Name: proto.String("srcs"), Type: Attribute_LABEL_LIST.Enum(), StringListValue: []string{"//app:a.yaml"}},
+			{Name: proto.String("replicas"), Type: Attribute_INTEGER.Enum(), IntValue: proto.Int32(2)},
+		},
+	}
+	// round trip through the wire format the way bazel output is consumed
+	b, err := proto.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed := &Rule{}
+	if err := proto.Unmarshal(b, parsed); err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestStringAttr(t *testing.T) {
+	r := testRule(t)
+	if v, err := r.StringAttr("deployment_branch"); err != nil || v != "prod" {
+		t.Errorf("unexpected deployment_branch %q %v", v, err)
+	}
+	if _, err := r.StringAttr("replicas"); err == nil {
+		t.Error("expected type error")
+	}
+	if _, err := r.StringAttr("missing"); err == nil {
+		t.Error("expected missing attribute error")
+	}
+}
+
+func TestStringAttrSelect(t *testing.T) {
+	sel := func(values ...string) *Attribute_Selector {
+		s := &Attribute_Selector{}
+		for i, v := range values {
+			s.Entries = append(s.Entries, &Attribute_SelectorEntry{Label: proto.String(fmt.Sprintf("//conditions:%d", i)), StringValue: proto.String(v)})
+		}
+		return s
+	}
+	r := &Rule{
+		Name: proto.String("//app:prod.gitops"),
+		Attribute: []*Attribute{
+			{Name: proto.String("uniform"), Type: Attribute_STRING.Enum(), SelectorList: &Attribute_SelectorList{Elements: []*Attribute_Selector{sel("prod", "prod"), sel("-eu")}}},
+			{Name: proto.String("varying"), Type: Attribute_STRING.Enum(), SelectorList: &Attribute_SelectorList{Elements: []*Attribute_Selector{sel("prod", "prod-arm")}}},
+			{Name: proto.String("resolved"), Type: Attribute_STRING.Enum(), StringValue: proto.String("prod-arm"), SelectorList: &Attribute_SelectorList{Elements: []*Attribute_Selector{sel("prod", "prod-arm")}}},
+		},
+	}
+	if v, err := r.StringAttr("uniform"); err != nil || v != "prod-eu" {
+		t.Errorf("unexpected uniform %q %v", v, err)
+	}
+	if _, err := r.StringAttr("varying"); !errors.Is(err, ErrConfigurable) {
+		t.Errorf("expected ErrConfigurable, got %v", err)
+	}
+	if v, err := r.StringAttr("resolved"); err != nil || v != "prod-arm" {
+		t.Errorf("unexpected resolved %q %v", v, err)
+	}
+}
+
+func TestStringListAttr(t *testing.T) {
+	r := testRule(t)
+	if v, err := r.StringListAttr("srcs"); err != nil || !reflect.DeepEqual(v, []string{"//app:a.yaml"}) {
+		t.Errorf("unexpected srcs %v %v", v, err)
+	}
+	if _, err := r.StringListAttr("deployment_branch"); err == nil {
+		t.Error("expected type error")
+	}
+}
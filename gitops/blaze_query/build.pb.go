@@ -1,24 +1,24 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
 // source: gitops/blaze_query/build.proto
 
 package blaze_query
 
 import (
-	fmt "fmt"
-	proto "github.com/golang/protobuf/proto"
-	math "math"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
 )
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
 type FilesetEntry_SymlinkBehavior int32
 
@@ -27,15 +27,17 @@ const (
 	FilesetEntry_DEREFERENCE FilesetEntry_SymlinkBehavior = 2
 )
 
-var FilesetEntry_SymlinkBehavior_name = map[int32]string{
-	1: "COPY",
-	2: "DEREFERENCE",
-}
-
-var FilesetEntry_SymlinkBehavior_value = map[string]int32{
-	"COPY":        1,
-	"DEREFERENCE": 2,
-}
+// Enum value maps for FilesetEntry_SymlinkBehavior.
+var (
+	FilesetEntry_SymlinkBehavior_name = map[int32]string{
+		1: "COPY",
+		2: "DEREFERENCE",
+	}
+	FilesetEntry_SymlinkBehavior_value = map[string]int32{
+		"COPY":        1,
+		"DEREFERENCE": 2,
+	}
+)
 
 func (x FilesetEntry_SymlinkBehavior) Enum() *FilesetEntry_SymlinkBehavior {
 	p := new(FilesetEntry_SymlinkBehavior)
@@ -44,20 +46,34 @@ func (x FilesetEntry_SymlinkBehavior) Enum() *FilesetEntry_SymlinkBehavior {
 }
 
 func (x FilesetEntry_SymlinkBehavior) String() string {
-	return proto.EnumName(FilesetEntry_SymlinkBehavior_name, int32(x))
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FilesetEntry_SymlinkBehavior) Descriptor() protoreflect.EnumDescriptor {
+	return file_gitops_blaze_query_build_proto_enumTypes[0].Descriptor()
+}
+
+func (FilesetEntry_SymlinkBehavior) Type() protoreflect.EnumType {
+	return &file_gitops_blaze_query_build_proto_enumTypes[0]
+}
+
+func (x FilesetEntry_SymlinkBehavior) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *FilesetEntry_SymlinkBehavior) UnmarshalJSON(data []byte) error {
-	value, err := proto.UnmarshalJSONEnum(FilesetEntry_SymlinkBehavior_value, data, "FilesetEntry_SymlinkBehavior")
+// Deprecated: Do not use.
+func (x *FilesetEntry_SymlinkBehavior) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
 	if err != nil {
 		return err
 	}
-	*x = FilesetEntry_SymlinkBehavior(value)
+	*x = FilesetEntry_SymlinkBehavior(num)
 	return nil
 }
 
+// Deprecated: Use FilesetEntry_SymlinkBehavior.Descriptor instead.
 func (FilesetEntry_SymlinkBehavior) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{6, 0}
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{6, 0}
 }
 
 type Attribute_Discriminator int32
@@ -86,53 +102,55 @@ const (
 	Attribute_DEPRECATED_STRING_DICT_UNARY Attribute_Discriminator = 17
 )
 
-var Attribute_Discriminator_name = map[int32]string{
-	1:  "INTEGER",
-	2:  "STRING",
-	3:  "LABEL",
-	4:  "OUTPUT",
-	5:  "STRING_LIST",
-	6:  "LABEL_LIST",
-	7:  "OUTPUT_LIST",
-	8:  "DISTRIBUTION_SET",
-	9:  "LICENSE",
-	10: "STRING_DICT",
-	11: "FILESET_ENTRY_LIST",
-	12: "LABEL_LIST_DICT",
-	13: "STRING_LIST_DICT",
-	14: "BOOLEAN",
-	15: "TRISTATE",
-	16: "INTEGER_LIST",
-	18: "UNKNOWN",
-	19: "LABEL_DICT_UNARY",
-	20: "SELECTOR_LIST",
-	21: "LABEL_KEYED_STRING_DICT",
-	17: "DEPRECATED_STRING_DICT_UNARY",
-}
-
-var Attribute_Discriminator_value = map[string]int32{
-	"INTEGER":                      1,
-	"STRING":                       2,
-	"LABEL":                        3,
-	"OUTPUT":                       4,
-	"STRING_LIST":                  5,
-	"LABEL_LIST":                   6,
-	"OUTPUT_LIST":                  7,
-	"DISTRIBUTION_SET":             8,
-	"LICENSE":                      9,
-	"STRING_DICT":                  10,
-	"FILESET_ENTRY_LIST":           11,
-	"LABEL_LIST_DICT":              12,
-	"STRING_LIST_DICT":             13,
-	"BOOLEAN":                      14,
-	"TRISTATE":                     15,
-	"INTEGER_LIST":                 16,
-	"UNKNOWN":                      18,
-	"LABEL_DICT_UNARY":             19,
-	"SELECTOR_LIST":                20,
-	"LABEL_KEYED_STRING_DICT":      21,
-	"DEPRECATED_STRING_DICT_UNARY": 17,
-}
+// Enum value maps for Attribute_Discriminator.
+var (
+	Attribute_Discriminator_name = map[int32]string{
+		1:  "INTEGER",
+		2:  "STRING",
+		3:  "LABEL",
+		4:  "OUTPUT",
+		5:  "STRING_LIST",
+		6:  "LABEL_LIST",
+		7:  "OUTPUT_LIST",
+		8:  "DISTRIBUTION_SET",
+		9:  "LICENSE",
+		10: "STRING_DICT",
+		11: "FILESET_ENTRY_LIST",
+		12: "LABEL_LIST_DICT",
+		13: "STRING_LIST_DICT",
+		14: "BOOLEAN",
+		15: "TRISTATE",
+		16: "INTEGER_LIST",
+		18: "UNKNOWN",
+		19: "LABEL_DICT_UNARY",
+		20: "SELECTOR_LIST",
+		21: "LABEL_KEYED_STRING_DICT",
+		17: "DEPRECATED_STRING_DICT_UNARY",
+	}
+	Attribute_Discriminator_value = map[string]int32{
+		"INTEGER":                      1,
+		"STRING":                       2,
+		"LABEL":                        3,
+		"OUTPUT":                       4,
+		"STRING_LIST":                  5,
+		"LABEL_LIST":                   6,
+		"OUTPUT_LIST":                  7,
+		"DISTRIBUTION_SET":             8,
+		"LICENSE":                      9,
+		"STRING_DICT":                  10,
+		"FILESET_ENTRY_LIST":           11,
+		"LABEL_LIST_DICT":              12,
+		"STRING_LIST_DICT":             13,
+		"BOOLEAN":                      14,
+		"TRISTATE":                     15,
+		"INTEGER_LIST":                 16,
+		"UNKNOWN":                      18,
+		"LABEL_DICT_UNARY":             19,
+		"SELECTOR_LIST":                20,
+		"LABEL_KEYED_STRING_DICT":      21,
+		"DEPRECATED_STRING_DICT_UNARY": 17,
+	}
+)
 
 func (x Attribute_Discriminator) Enum() *Attribute_Discriminator {
 	p := new(Attribute_Discriminator)
@@ -141,20 +159,34 @@ func (x Attribute_Discriminator) Enum() *Attribute_Discriminator {
 }
 
 func (x Attribute_Discriminator) String() string {
-	return proto.EnumName(Attribute_Discriminator_name, int32(x))
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Attribute_Discriminator) Descriptor() protoreflect.EnumDescriptor {
+	return file_gitops_blaze_query_build_proto_enumTypes[1].Descriptor()
+}
+
+func (Attribute_Discriminator) Type() protoreflect.EnumType {
+	return &file_gitops_blaze_query_build_proto_enumTypes[1]
+}
+
+func (x Attribute_Discriminator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *Attribute_Discriminator) UnmarshalJSON(data []byte) error {
-	value, err := proto.UnmarshalJSONEnum(Attribute_Discriminator_value, data, "Attribute_Discriminator")
+// Deprecated: Do not use.
+func (x *Attribute_Discriminator) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
 	if err != nil {
 		return err
 	}
-	*x = Attribute_Discriminator(value)
+	*x = Attribute_Discriminator(num)
 	return nil
 }
 
+// Deprecated: Use Attribute_Discriminator.Descriptor instead.
 func (Attribute_Discriminator) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{7, 0}
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{7, 0}
 }
 
 type Attribute_Tristate int32
@@ -165,17 +197,19 @@ const (
 	Attribute_AUTO Attribute_Tristate = 2
 )
 
-var Attribute_Tristate_name = map[int32]string{
-	0: "NO",
-	1: "YES",
-	2: "AUTO",
-}
-
-var Attribute_Tristate_value = map[string]int32{
-	"NO":   0,
-	"YES":  1,
-	"AUTO": 2,
-}
+// Enum value maps for Attribute_Tristate.
+var (
+	Attribute_Tristate_name = map[int32]string{
+		0: "NO",
+		1: "YES",
+		2: "AUTO",
+	}
+	Attribute_Tristate_value = map[string]int32{
+		"NO":   0,
+		"YES":  1,
+		"AUTO": 2,
+	}
+)
 
 func (x Attribute_Tristate) Enum() *Attribute_Tristate {
 	p := new(Attribute_Tristate)
@@ -184,20 +218,34 @@ func (x Attribute_Tristate) Enum() *Attribute_Tristate {
 }
 
 func (x Attribute_Tristate) String() string {
-	return proto.EnumName(Attribute_Tristate_name, int32(x))
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Attribute_Tristate) Descriptor() protoreflect.EnumDescriptor {
+	return file_gitops_blaze_query_build_proto_enumTypes[2].Descriptor()
 }
 
-func (x *Attribute_Tristate) UnmarshalJSON(data []byte) error {
-	value, err := proto.UnmarshalJSONEnum(Attribute_Tristate_value, data, "Attribute_Tristate")
+func (Attribute_Tristate) Type() protoreflect.EnumType {
+	return &file_gitops_blaze_query_build_proto_enumTypes[2]
+}
+
+func (x Attribute_Tristate) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *Attribute_Tristate) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
 	if err != nil {
 		return err
 	}
-	*x = Attribute_Tristate(value)
+	*x = Attribute_Tristate(num)
 	return nil
 }
 
+// Deprecated: Use Attribute_Tristate.Descriptor instead.
 func (Attribute_Tristate) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{7, 1}
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{7, 1}
 }
 
 type Target_Discriminator int32
@@ -210,21 +258,23 @@ const (
 	Target_ENVIRONMENT_GROUP Target_Discriminator = 5
 )
 
-var Target_Discriminator_name = map[int32]string{
-	1: "RULE",
-	2: "SOURCE_FILE",
-	3: "GENERATED_FILE",
-	4: "PACKAGE_GROUP",
-	5: "ENVIRONMENT_GROUP",
-}
-
-var Target_Discriminator_value = map[string]int32{
-	"RULE":              1,
-	"SOURCE_FILE":       2,
-	"GENERATED_FILE":    3,
-	"PACKAGE_GROUP":     4,
-	"ENVIRONMENT_GROUP": 5,
-}
+// Enum value maps for Target_Discriminator.
+var (
+	Target_Discriminator_name = map[int32]string{
+		1: "RULE",
+		2: "SOURCE_FILE",
+		3: "GENERATED_FILE",
+		4: "PACKAGE_GROUP",
+		5: "ENVIRONMENT_GROUP",
+	}
+	Target_Discriminator_value = map[string]int32{
+		"RULE":              1,
+		"SOURCE_FILE":       2,
+		"GENERATED_FILE":    3,
+		"PACKAGE_GROUP":     4,
+		"ENVIRONMENT_GROUP": 5,
+	}
+)
 
 func (x Target_Discriminator) Enum() *Target_Discriminator {
 	p := new(Target_Discriminator)
@@ -233,20 +283,34 @@ func (x Target_Discriminator) Enum() *Target_Discriminator {
 }
 
 func (x Target_Discriminator) String() string {
-	return proto.EnumName(Target_Discriminator_name, int32(x))
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Target_Discriminator) Descriptor() protoreflect.EnumDescriptor {
+	return file_gitops_blaze_query_build_proto_enumTypes[3].Descriptor()
+}
+
+func (Target_Discriminator) Type() protoreflect.EnumType {
+	return &file_gitops_blaze_query_build_proto_enumTypes[3]
+}
+
+func (x Target_Discriminator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *Target_Discriminator) UnmarshalJSON(data []byte) error {
-	value, err := proto.UnmarshalJSONEnum(Target_Discriminator_value, data, "Target_Discriminator")
+// Deprecated: Do not use.
+func (x *Target_Discriminator) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
 	if err != nil {
 		return err
 	}
-	*x = Target_Discriminator(value)
+	*x = Target_Discriminator(num)
 	return nil
 }
 
+// Deprecated: Use Target_Discriminator.Descriptor instead.
 func (Target_Discriminator) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{14, 0}
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{14, 0}
 }
 
 type AllowedRuleClassInfo_AllowedRuleClasses int32
@@ -256,15 +320,17 @@ const (
 	AllowedRuleClassInfo_SPECIFIED AllowedRuleClassInfo_AllowedRuleClasses = 2
 )
 
-var AllowedRuleClassInfo_AllowedRuleClasses_name = map[int32]string{
-	1: "ANY",
-	2: "SPECIFIED",
-}
-
-var AllowedRuleClassInfo_AllowedRuleClasses_value = map[string]int32{
-	"ANY":       1,
-	"SPECIFIED": 2,
-}
+// Enum value maps for AllowedRuleClassInfo_AllowedRuleClasses.
+var (
+	AllowedRuleClassInfo_AllowedRuleClasses_name = map[int32]string{
+		1: "ANY",
+		2: "SPECIFIED",
+	}
+	AllowedRuleClassInfo_AllowedRuleClasses_value = map[string]int32{
+		"ANY":       1,
+		"SPECIFIED": 2,
+	}
+)
 
 func (x AllowedRuleClassInfo_AllowedRuleClasses) Enum() *AllowedRuleClassInfo_AllowedRuleClasses {
 	p := new(AllowedRuleClassInfo_AllowedRuleClasses)
@@ -273,305 +339,371 @@ func (x AllowedRuleClassInfo_AllowedRuleClasses) Enum() *AllowedRuleClassInfo_Al
 }
 
 func (x AllowedRuleClassInfo_AllowedRuleClasses) String() string {
-	return proto.EnumName(AllowedRuleClassInfo_AllowedRuleClasses_name, int32(x))
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AllowedRuleClassInfo_AllowedRuleClasses) Descriptor() protoreflect.EnumDescriptor {
+	return file_gitops_blaze_query_build_proto_enumTypes[4].Descriptor()
+}
+
+func (AllowedRuleClassInfo_AllowedRuleClasses) Type() protoreflect.EnumType {
+	return &file_gitops_blaze_query_build_proto_enumTypes[4]
+}
+
+func (x AllowedRuleClassInfo_AllowedRuleClasses) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *AllowedRuleClassInfo_AllowedRuleClasses) UnmarshalJSON(data []byte) error {
-	value, err := proto.UnmarshalJSONEnum(AllowedRuleClassInfo_AllowedRuleClasses_value, data, "AllowedRuleClassInfo_AllowedRuleClasses")
+// Deprecated: Do not use.
+func (x *AllowedRuleClassInfo_AllowedRuleClasses) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
 	if err != nil {
 		return err
 	}
-	*x = AllowedRuleClassInfo_AllowedRuleClasses(value)
+	*x = AllowedRuleClassInfo_AllowedRuleClasses(num)
 	return nil
 }
 
+// Deprecated: Use AllowedRuleClassInfo_AllowedRuleClasses.Descriptor instead.
 func (AllowedRuleClassInfo_AllowedRuleClasses) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{16, 0}
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{16, 0}
 }
 
 type License struct {
-	LicenseType          []string `protobuf:"bytes,1,rep,name=license_type,json=licenseType" json:"license_type,omitempty"`
-	Exception            []string `protobuf:"bytes,2,rep,name=exception" json:"exception,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *License) Reset()         { *m = License{} }
-func (m *License) String() string { return proto.CompactTextString(m) }
-func (*License) ProtoMessage()    {}
-func (*License) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{0}
+	LicenseType []string `protobuf:"bytes,1,rep,name=license_type,json=licenseType" json:"license_type,omitempty"`
+	Exception   []string `protobuf:"bytes,2,rep,name=exception" json:"exception,omitempty"`
 }
 
-func (m *License) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_License.Unmarshal(m, b)
-}
-func (m *License) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_License.Marshal(b, m, deterministic)
-}
-func (m *License) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_License.Merge(m, src)
+func (x *License) Reset() {
+	*x = License{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *License) XXX_Size() int {
-	return xxx_messageInfo_License.Size(m)
+
+func (x *License) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *License) XXX_DiscardUnknown() {
-	xxx_messageInfo_License.DiscardUnknown(m)
+
+func (*License) ProtoMessage() {}
+
+func (x *License) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_License proto.InternalMessageInfo
+// Deprecated: Use License.ProtoReflect.Descriptor instead.
+func (*License) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{0}
+}
 
-func (m *License) GetLicenseType() []string {
-	if m != nil {
-		return m.LicenseType
+func (x *License) GetLicenseType() []string {
+	if x != nil {
+		return x.LicenseType
 	}
 	return nil
 }
 
-func (m *License) GetException() []string {
-	if m != nil {
-		return m.Exception
+func (x *License) GetException() []string {
+	if x != nil {
+		return x.Exception
 	}
 	return nil
 }
 
 type StringDictEntry struct {
-	Key                  *string  `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
-	Value                *string  `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *StringDictEntry) Reset()         { *m = StringDictEntry{} }
-func (m *StringDictEntry) String() string { return proto.CompactTextString(m) }
-func (*StringDictEntry) ProtoMessage()    {}
-func (*StringDictEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{1}
+	Key   *string `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
+	Value *string `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
 }
 
-func (m *StringDictEntry) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StringDictEntry.Unmarshal(m, b)
-}
-func (m *StringDictEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StringDictEntry.Marshal(b, m, deterministic)
-}
-func (m *StringDictEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StringDictEntry.Merge(m, src)
+func (x *StringDictEntry) Reset() {
+	*x = StringDictEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *StringDictEntry) XXX_Size() int {
-	return xxx_messageInfo_StringDictEntry.Size(m)
+
+func (x *StringDictEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *StringDictEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_StringDictEntry.DiscardUnknown(m)
+
+func (*StringDictEntry) ProtoMessage() {}
+
+func (x *StringDictEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_StringDictEntry proto.InternalMessageInfo
+// Deprecated: Use StringDictEntry.ProtoReflect.Descriptor instead.
+func (*StringDictEntry) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{1}
+}
 
-func (m *StringDictEntry) GetKey() string {
-	if m != nil && m.Key != nil {
-		return *m.Key
+func (x *StringDictEntry) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
 	}
 	return ""
 }
 
-func (m *StringDictEntry) GetValue() string {
-	if m != nil && m.Value != nil {
-		return *m.Value
+func (x *StringDictEntry) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
 	}
 	return ""
 }
 
 type LabelDictUnaryEntry struct {
-	Key                  *string  `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
-	Value                *string  `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *LabelDictUnaryEntry) Reset()         { *m = LabelDictUnaryEntry{} }
-func (m *LabelDictUnaryEntry) String() string { return proto.CompactTextString(m) }
-func (*LabelDictUnaryEntry) ProtoMessage()    {}
-func (*LabelDictUnaryEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{2}
+	Key   *string `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
+	Value *string `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
 }
 
-func (m *LabelDictUnaryEntry) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_LabelDictUnaryEntry.Unmarshal(m, b)
-}
-func (m *LabelDictUnaryEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_LabelDictUnaryEntry.Marshal(b, m, deterministic)
-}
-func (m *LabelDictUnaryEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelDictUnaryEntry.Merge(m, src)
+func (x *LabelDictUnaryEntry) Reset() {
+	*x = LabelDictUnaryEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *LabelDictUnaryEntry) XXX_Size() int {
-	return xxx_messageInfo_LabelDictUnaryEntry.Size(m)
+
+func (x *LabelDictUnaryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *LabelDictUnaryEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelDictUnaryEntry.DiscardUnknown(m)
+
+func (*LabelDictUnaryEntry) ProtoMessage() {}
+
+func (x *LabelDictUnaryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_LabelDictUnaryEntry proto.InternalMessageInfo
+// Deprecated: Use LabelDictUnaryEntry.ProtoReflect.Descriptor instead.
+func (*LabelDictUnaryEntry) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{2}
+}
 
-func (m *LabelDictUnaryEntry) GetKey() string {
-	if m != nil && m.Key != nil {
-		return *m.Key
+func (x *LabelDictUnaryEntry) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
 	}
 	return ""
 }
 
-func (m *LabelDictUnaryEntry) GetValue() string {
-	if m != nil && m.Value != nil {
-		return *m.Value
+func (x *LabelDictUnaryEntry) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
 	}
 	return ""
 }
 
 type LabelListDictEntry struct {
-	Key                  *string  `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
-	Value                []string `protobuf:"bytes,2,rep,name=value" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *LabelListDictEntry) Reset()         { *m = LabelListDictEntry{} }
-func (m *LabelListDictEntry) String() string { return proto.CompactTextString(m) }
-func (*LabelListDictEntry) ProtoMessage()    {}
-func (*LabelListDictEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{3}
+	Key   *string  `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
+	Value []string `protobuf:"bytes,2,rep,name=value" json:"value,omitempty"`
 }
 
-func (m *LabelListDictEntry) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_LabelListDictEntry.Unmarshal(m, b)
-}
-func (m *LabelListDictEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_LabelListDictEntry.Marshal(b, m, deterministic)
-}
-func (m *LabelListDictEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelListDictEntry.Merge(m, src)
+func (x *LabelListDictEntry) Reset() {
+	*x = LabelListDictEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *LabelListDictEntry) XXX_Size() int {
-	return xxx_messageInfo_LabelListDictEntry.Size(m)
+
+func (x *LabelListDictEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *LabelListDictEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelListDictEntry.DiscardUnknown(m)
+
+func (*LabelListDictEntry) ProtoMessage() {}
+
+func (x *LabelListDictEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_LabelListDictEntry proto.InternalMessageInfo
+// Deprecated: Use LabelListDictEntry.ProtoReflect.Descriptor instead.
+func (*LabelListDictEntry) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{3}
+}
 
-func (m *LabelListDictEntry) GetKey() string {
-	if m != nil && m.Key != nil {
-		return *m.Key
+func (x *LabelListDictEntry) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
 	}
 	return ""
 }
 
-func (m *LabelListDictEntry) GetValue() []string {
-	if m != nil {
-		return m.Value
+func (x *LabelListDictEntry) GetValue() []string {
+	if x != nil {
+		return x.Value
 	}
 	return nil
 }
 
 type LabelKeyedStringDictEntry struct {
-	Key                  *string  `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
-	Value                *string  `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *LabelKeyedStringDictEntry) Reset()         { *m = LabelKeyedStringDictEntry{} }
-func (m *LabelKeyedStringDictEntry) String() string { return proto.CompactTextString(m) }
-func (*LabelKeyedStringDictEntry) ProtoMessage()    {}
-func (*LabelKeyedStringDictEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{4}
+	Key   *string `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
+	Value *string `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
 }
 
-func (m *LabelKeyedStringDictEntry) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_LabelKeyedStringDictEntry.Unmarshal(m, b)
-}
-func (m *LabelKeyedStringDictEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_LabelKeyedStringDictEntry.Marshal(b, m, deterministic)
-}
-func (m *LabelKeyedStringDictEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelKeyedStringDictEntry.Merge(m, src)
+func (x *LabelKeyedStringDictEntry) Reset() {
+	*x = LabelKeyedStringDictEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *LabelKeyedStringDictEntry) XXX_Size() int {
-	return xxx_messageInfo_LabelKeyedStringDictEntry.Size(m)
+
+func (x *LabelKeyedStringDictEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *LabelKeyedStringDictEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelKeyedStringDictEntry.DiscardUnknown(m)
+
+func (*LabelKeyedStringDictEntry) ProtoMessage() {}
+
+func (x *LabelKeyedStringDictEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_LabelKeyedStringDictEntry proto.InternalMessageInfo
+// Deprecated: Use LabelKeyedStringDictEntry.ProtoReflect.Descriptor instead.
+func (*LabelKeyedStringDictEntry) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{4}
+}
 
-func (m *LabelKeyedStringDictEntry) GetKey() string {
-	if m != nil && m.Key != nil {
-		return *m.Key
+func (x *LabelKeyedStringDictEntry) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
 	}
 	return ""
 }
 
-func (m *LabelKeyedStringDictEntry) GetValue() string {
-	if m != nil && m.Value != nil {
-		return *m.Value
+func (x *LabelKeyedStringDictEntry) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
 	}
 	return ""
 }
 
 type StringListDictEntry struct {
-	Key                  *string  `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
-	Value                []string `protobuf:"bytes,2,rep,name=value" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *StringListDictEntry) Reset()         { *m = StringListDictEntry{} }
-func (m *StringListDictEntry) String() string { return proto.CompactTextString(m) }
-func (*StringListDictEntry) ProtoMessage()    {}
-func (*StringListDictEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{5}
+	Key   *string  `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
+	Value []string `protobuf:"bytes,2,rep,name=value" json:"value,omitempty"`
 }
 
-func (m *StringListDictEntry) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StringListDictEntry.Unmarshal(m, b)
-}
-func (m *StringListDictEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StringListDictEntry.Marshal(b, m, deterministic)
-}
-func (m *StringListDictEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StringListDictEntry.Merge(m, src)
+func (x *StringListDictEntry) Reset() {
+	*x = StringListDictEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *StringListDictEntry) XXX_Size() int {
-	return xxx_messageInfo_StringListDictEntry.Size(m)
+
+func (x *StringListDictEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *StringListDictEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_StringListDictEntry.DiscardUnknown(m)
+
+func (*StringListDictEntry) ProtoMessage() {}
+
+func (x *StringListDictEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_StringListDictEntry proto.InternalMessageInfo
+// Deprecated: Use StringListDictEntry.ProtoReflect.Descriptor instead.
+func (*StringListDictEntry) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{5}
+}
 
-func (m *StringListDictEntry) GetKey() string {
-	if m != nil && m.Key != nil {
-		return *m.Key
+func (x *StringListDictEntry) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
 	}
 	return ""
 }
 
-func (m *StringListDictEntry) GetValue() []string {
-	if m != nil {
-		return m.Value
+func (x *StringListDictEntry) GetValue() []string {
+	if x != nil {
+		return x.Value
 	}
 	return nil
 }
 
 type FilesetEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
 	Source               *string                       `protobuf:"bytes,1,req,name=source" json:"source,omitempty"`
 	DestinationDirectory *string                       `protobuf:"bytes,2,req,name=destination_directory,json=destinationDirectory" json:"destination_directory,omitempty"`
 	FilesPresent         *bool                         `protobuf:"varint,7,opt,name=files_present,json=filesPresent" json:"files_present,omitempty"`
@@ -579,88 +711,99 @@ type FilesetEntry struct {
 	Exclude              []string                      `protobuf:"bytes,4,rep,name=exclude" json:"exclude,omitempty"`
 	SymlinkBehavior      *FilesetEntry_SymlinkBehavior `protobuf:"varint,5,opt,name=symlink_behavior,json=symlinkBehavior,enum=blaze_query.FilesetEntry_SymlinkBehavior,def=1" json:"symlink_behavior,omitempty"`
 	StripPrefix          *string                       `protobuf:"bytes,6,opt,name=strip_prefix,json=stripPrefix" json:"strip_prefix,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
-	XXX_unrecognized     []byte                        `json:"-"`
-	XXX_sizecache        int32                         `json:"-"`
 }
 
-func (m *FilesetEntry) Reset()         { *m = FilesetEntry{} }
-func (m *FilesetEntry) String() string { return proto.CompactTextString(m) }
-func (*FilesetEntry) ProtoMessage()    {}
-func (*FilesetEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{6}
-}
+// Default values for FilesetEntry fields.
+const (
+	Default_FilesetEntry_SymlinkBehavior = FilesetEntry_COPY
+)
 
-func (m *FilesetEntry) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FilesetEntry.Unmarshal(m, b)
-}
-func (m *FilesetEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FilesetEntry.Marshal(b, m, deterministic)
-}
-func (m *FilesetEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FilesetEntry.Merge(m, src)
-}
-func (m *FilesetEntry) XXX_Size() int {
-	return xxx_messageInfo_FilesetEntry.Size(m)
+func (x *FilesetEntry) Reset() {
+	*x = FilesetEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *FilesetEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_FilesetEntry.DiscardUnknown(m)
+
+func (x *FilesetEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_FilesetEntry proto.InternalMessageInfo
+func (*FilesetEntry) ProtoMessage() {}
+
+func (x *FilesetEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-const Default_FilesetEntry_SymlinkBehavior FilesetEntry_SymlinkBehavior = FilesetEntry_COPY
+// Deprecated: Use FilesetEntry.ProtoReflect.Descriptor instead.
+func (*FilesetEntry) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{6}
+}
 
-func (m *FilesetEntry) GetSource() string {
-	if m != nil && m.Source != nil {
-		return *m.Source
+func (x *FilesetEntry) GetSource() string {
+	if x != nil && x.Source != nil {
+		return *x.Source
 	}
 	return ""
 }
 
-func (m *FilesetEntry) GetDestinationDirectory() string {
-	if m != nil && m.DestinationDirectory != nil {
-		return *m.DestinationDirectory
+func (x *FilesetEntry) GetDestinationDirectory() string {
+	if x != nil && x.DestinationDirectory != nil {
+		return *x.DestinationDirectory
 	}
 	return ""
 }
 
-func (m *FilesetEntry) GetFilesPresent() bool {
-	if m != nil && m.FilesPresent != nil {
-		return *m.FilesPresent
+func (x *FilesetEntry) GetFilesPresent() bool {
+	if x != nil && x.FilesPresent != nil {
+		return *x.FilesPresent
 	}
 	return false
 }
 
-func (m *FilesetEntry) GetFile() []string {
-	if m != nil {
-		return m.File
+func (x *FilesetEntry) GetFile() []string {
+	if x != nil {
+		return x.File
 	}
 	return nil
 }
 
-func (m *FilesetEntry) GetExclude() []string {
-	if m != nil {
-		return m.Exclude
+func (x *FilesetEntry) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
 	}
 	return nil
 }
 
-func (m *FilesetEntry) GetSymlinkBehavior() FilesetEntry_SymlinkBehavior {
-	if m != nil && m.SymlinkBehavior != nil {
-		return *m.SymlinkBehavior
+func (x *FilesetEntry) GetSymlinkBehavior() FilesetEntry_SymlinkBehavior {
+	if x != nil && x.SymlinkBehavior != nil {
+		return *x.SymlinkBehavior
 	}
 	return Default_FilesetEntry_SymlinkBehavior
 }
 
-func (m *FilesetEntry) GetStripPrefix() string {
-	if m != nil && m.StripPrefix != nil {
-		return *m.StripPrefix
+func (x *FilesetEntry) GetStripPrefix() string {
+	if x != nil && x.StripPrefix != nil {
+		return *x.StripPrefix
 	}
 	return ""
 }
 
 type Attribute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
 	Name                           *string                      `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
 	ExplicitlySpecified            *bool                        `protobuf:"varint,13,opt,name=explicitly_specified,json=explicitlySpecified" json:"explicitly_specified,omitempty"`
 	Nodep                          *bool                        `protobuf:"varint,20,opt,name=nodep" json:"nodep,omitempty"`
@@ -680,1550 +823,2351 @@ type Attribute struct {
 	LabelKeyedStringDictValue      []*LabelKeyedStringDictEntry `protobuf:"bytes,22,rep,name=label_keyed_string_dict_value,json=labelKeyedStringDictValue" json:"label_keyed_string_dict_value,omitempty"`
 	SelectorList                   *Attribute_SelectorList      `protobuf:"bytes,21,opt,name=selector_list,json=selectorList" json:"selector_list,omitempty"`
 	DEPRECATEDStringDictUnaryValue [][]byte                     `protobuf:"bytes,18,rep,name=DEPRECATED_string_dict_unary_value,json=DEPRECATEDStringDictUnaryValue" json:"DEPRECATED_string_dict_unary_value,omitempty"`
-	XXX_NoUnkeyedLiteral           struct{}                     `json:"-"`
-	XXX_unrecognized               []byte                       `json:"-"`
-	XXX_sizecache                  int32                        `json:"-"`
 }
 
-func (m *Attribute) Reset()         { *m = Attribute{} }
-func (m *Attribute) String() string { return proto.CompactTextString(m) }
-func (*Attribute) ProtoMessage()    {}
-func (*Attribute) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{7}
+func (x *Attribute) Reset() {
+	*x = Attribute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (m *Attribute) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Attribute.Unmarshal(m, b)
-}
-func (m *Attribute) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Attribute.Marshal(b, m, deterministic)
+func (x *Attribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *Attribute) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Attribute.Merge(m, src)
-}
-func (m *Attribute) XXX_Size() int {
-	return xxx_messageInfo_Attribute.Size(m)
-}
-func (m *Attribute) XXX_DiscardUnknown() {
-	xxx_messageInfo_Attribute.DiscardUnknown(m)
+
+func (*Attribute) ProtoMessage() {}
+
+func (x *Attribute) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_Attribute proto.InternalMessageInfo
+// Deprecated: Use Attribute.ProtoReflect.Descriptor instead.
+func (*Attribute) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{7}
+}
 
-func (m *Attribute) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (x *Attribute) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
 	return ""
 }
 
-func (m *Attribute) GetExplicitlySpecified() bool {
-	if m != nil && m.ExplicitlySpecified != nil {
-		return *m.ExplicitlySpecified
+func (x *Attribute) GetExplicitlySpecified() bool {
+	if x != nil && x.ExplicitlySpecified != nil {
+		return *x.ExplicitlySpecified
 	}
 	return false
 }
 
-func (m *Attribute) GetNodep() bool {
-	if m != nil && m.Nodep != nil {
-		return *m.Nodep
+func (x *Attribute) GetNodep() bool {
+	if x != nil && x.Nodep != nil {
+		return *x.Nodep
 	}
 	return false
 }
 
-func (m *Attribute) GetType() Attribute_Discriminator {
-	if m != nil && m.Type != nil {
-		return *m.Type
+func (x *Attribute) GetType() Attribute_Discriminator {
+	if x != nil && x.Type != nil {
+		return *x.Type
 	}
 	return Attribute_INTEGER
 }
 
-func (m *Attribute) GetIntValue() int32 {
-	if m != nil && m.IntValue != nil {
-		return *m.IntValue
+func (x *Attribute) GetIntValue() int32 {
+	if x != nil && x.IntValue != nil {
+		return *x.IntValue
 	}
 	return 0
 }
 
-func (m *Attribute) GetStringValue() string {
-	if m != nil && m.StringValue != nil {
-		return *m.StringValue
+func (x *Attribute) GetStringValue() string {
+	if x != nil && x.StringValue != nil {
+		return *x.StringValue
 	}
 	return ""
 }
 
-func (m *Attribute) GetBooleanValue() bool {
-	if m != nil && m.BooleanValue != nil {
-		return *m.BooleanValue
+func (x *Attribute) GetBooleanValue() bool {
+	if x != nil && x.BooleanValue != nil {
+		return *x.BooleanValue
 	}
 	return false
 }
 
-func (m *Attribute) GetTristateValue() Attribute_Tristate {
-	if m != nil && m.TristateValue != nil {
-		return *m.TristateValue
+func (x *Attribute) GetTristateValue() Attribute_Tristate {
+	if x != nil && x.TristateValue != nil {
+		return *x.TristateValue
 	}
 	return Attribute_NO
 }
 
-func (m *Attribute) GetStringListValue() []string {
-	if m != nil {
-		return m.StringListValue
+func (x *Attribute) GetStringListValue() []string {
+	if x != nil {
+		return x.StringListValue
 	}
 	return nil
 }
 
-func (m *Attribute) GetLicense() *License {
-	if m != nil {
-		return m.License
+func (x *Attribute) GetLicense() *License {
+	if x != nil {
+		return x.License
 	}
 	return nil
 }
 
-func (m *Attribute) GetStringDictValue() []*StringDictEntry {
-	if m != nil {
-		return m.StringDictValue
+func (x *Attribute) GetStringDictValue() []*StringDictEntry {
+	if x != nil {
+		return x.StringDictValue
 	}
 	return nil
 }
 
-func (m *Attribute) GetFilesetListValue() []*FilesetEntry {
-	if m != nil {
-		return m.FilesetListValue
+func (x *Attribute) GetFilesetListValue() []*FilesetEntry {
+	if x != nil {
+		return x.FilesetListValue
 	}
 	return nil
 }
 
-func (m *Attribute) GetLabelListDictValue() []*LabelListDictEntry {
-	if m != nil {
-		return m.LabelListDictValue
+func (x *Attribute) GetLabelListDictValue() []*LabelListDictEntry {
+	if x != nil {
+		return x.LabelListDictValue
 	}
 	return nil
 }
 
-func (m *Attribute) GetStringListDictValue() []*StringListDictEntry {
-	if m != nil {
-		return m.StringListDictValue
+func (x *Attribute) GetStringListDictValue() []*StringListDictEntry {
+	if x != nil {
+		return x.StringListDictValue
 	}
 	return nil
 }
 
-func (m *Attribute) GetIntListValue() []int32 {
-	if m != nil {
-		return m.IntListValue
+func (x *Attribute) GetIntListValue() []int32 {
+	if x != nil {
+		return x.IntListValue
 	}
 	return nil
 }
 
-func (m *Attribute) GetLabelDictUnaryValue() []*LabelDictUnaryEntry {
-	if m != nil {
-		return m.LabelDictUnaryValue
+func (x *Attribute) GetLabelDictUnaryValue() []*LabelDictUnaryEntry {
+	if x != nil {
+		return x.LabelDictUnaryValue
 	}
 	return nil
 }
 
-func (m *Attribute) GetLabelKeyedStringDictValue() []*LabelKeyedStringDictEntry {
-	if m != nil {
-		return m.LabelKeyedStringDictValue
+func (x *Attribute) GetLabelKeyedStringDictValue() []*LabelKeyedStringDictEntry {
+	if x != nil {
+		return x.LabelKeyedStringDictValue
 	}
 	return nil
 }
 
-func (m *Attribute) GetSelectorList() *Attribute_SelectorList {
-	if m != nil {
-		return m.SelectorList
+func (x *Attribute) GetSelectorList() *Attribute_SelectorList {
+	if x != nil {
+		return x.SelectorList
 	}
 	return nil
 }
 
-func (m *Attribute) GetDEPRECATEDStringDictUnaryValue() [][]byte {
-	if m != nil {
-		return m.DEPRECATEDStringDictUnaryValue
+func (x *Attribute) GetDEPRECATEDStringDictUnaryValue() [][]byte {
+	if x != nil {
+		return x.DEPRECATEDStringDictUnaryValue
 	}
 	return nil
 }
 
-type Attribute_SelectorEntry struct {
-	Label                          *string                      `protobuf:"bytes,1,opt,name=label" json:"label,omitempty"`
-	IsDefaultValue                 *bool                        `protobuf:"varint,16,opt,name=is_default_value,json=isDefaultValue" json:"is_default_value,omitempty"`
-	IntValue                       *int32                       `protobuf:"varint,2,opt,name=int_value,json=intValue" json:"int_value,omitempty"`
-	StringValue                    *string                      `protobuf:"bytes,3,opt,name=string_value,json=stringValue" json:"string_value,omitempty"`
-	BooleanValue                   *bool                        `protobuf:"varint,4,opt,name=boolean_value,json=booleanValue" json:"boolean_value,omitempty"`
-	TristateValue                  *Attribute_Tristate          `protobuf:"varint,5,opt,name=tristate_value,json=tristateValue,enum=blaze_query.Attribute_Tristate" json:"tristate_value,omitempty"`
-	StringListValue                []string                     `protobuf:"bytes,6,rep,name=string_list_value,json=stringListValue" json:"string_list_value,omitempty"`
-	License                        *License                     `protobuf:"bytes,7,opt,name=license" json:"license,omitempty"`
-	StringDictValue                []*StringDictEntry           `protobuf:"bytes,8,rep,name=string_dict_value,json=stringDictValue" json:"string_dict_value,omitempty"`
-	FilesetListValue               []*FilesetEntry              `protobuf:"bytes,9,rep,name=fileset_list_value,json=filesetListValue" json:"fileset_list_value,omitempty"`
-	LabelListDictValue             []*LabelListDictEntry        `protobuf:"bytes,10,rep,name=label_list_dict_value,json=labelListDictValue" json:"label_list_dict_value,omitempty"`
-	StringListDictValue            []*StringListDictEntry       `protobuf:"bytes,11,rep,name=string_list_dict_value,json=stringListDictValue" json:"string_list_dict_value,omitempty"`
-	IntListValue                   []int32                      `protobuf:"varint,13,rep,name=int_list_value,json=intListValue" json:"int_list_value,omitempty"`
-	LabelDictUnaryValue            []*LabelDictUnaryEntry       `protobuf:"bytes,15,rep,name=label_dict_unary_value,json=labelDictUnaryValue" json:"label_dict_unary_value,omitempty"`
-	LabelKeyedStringDictValue      []*LabelKeyedStringDictEntry `protobuf:"bytes,17,rep,name=label_keyed_string_dict_value,json=labelKeyedStringDictValue" json:"label_keyed_string_dict_value,omitempty"`
-	DEPRECATEDStringDictUnaryValue [][]byte                     `protobuf:"bytes,14,rep,name=DEPRECATED_string_dict_unary_value,json=DEPRECATEDStringDictUnaryValue" json:"DEPRECATED_string_dict_unary_value,omitempty"`
-	XXX_NoUnkeyedLiteral           struct{}                     `json:"-"`
-	XXX_unrecognized               []byte                       `json:"-"`
-	XXX_sizecache                  int32                        `json:"-"`
-}
+type Rule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *Attribute_SelectorEntry) Reset()         { *m = Attribute_SelectorEntry{} }
-func (m *Attribute_SelectorEntry) String() string { return proto.CompactTextString(m) }
-func (*Attribute_SelectorEntry) ProtoMessage()    {}
-func (*Attribute_SelectorEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{7, 0}
+	Name                       *string      `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	RuleClass                  *string      `protobuf:"bytes,2,req,name=rule_class,json=ruleClass" json:"rule_class,omitempty"`
+	Location                   *string      `protobuf:"bytes,3,opt,name=location" json:"location,omitempty"`
+	Attribute                  []*Attribute `protobuf:"bytes,4,rep,name=attribute" json:"attribute,omitempty"`
+	RuleInput                  []string     `protobuf:"bytes,5,rep,name=rule_input,json=ruleInput" json:"rule_input,omitempty"`
+	RuleOutput                 []string     `protobuf:"bytes,6,rep,name=rule_output,json=ruleOutput" json:"rule_output,omitempty"`
+	DefaultSetting             []string     `protobuf:"bytes,7,rep,name=default_setting,json=defaultSetting" json:"default_setting,omitempty"`
+	PublicByDefault            *bool        `protobuf:"varint,9,opt,name=public_by_default,json=publicByDefault" json:"public_by_default,omitempty"`
+	IsSkylark                  *bool        `protobuf:"varint,10,opt,name=is_skylark,json=isSkylark" json:"is_skylark,omitempty"`
+	SkylarkEnvironmentHashCode *string      `protobuf:"bytes,12,opt,name=skylark_environment_hash_code,json=skylarkEnvironmentHashCode" json:"skylark_environment_hash_code,omitempty"`
 }
 
-func (m *Attribute_SelectorEntry) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Attribute_SelectorEntry.Unmarshal(m, b)
-}
-func (m *Attribute_SelectorEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Attribute_SelectorEntry.Marshal(b, m, deterministic)
-}
-func (m *Attribute_SelectorEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Attribute_SelectorEntry.Merge(m, src)
-}
-func (m *Attribute_SelectorEntry) XXX_Size() int {
-	return xxx_messageInfo_Attribute_SelectorEntry.Size(m)
+func (x *Rule) Reset() {
+	*x = Rule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *Attribute_SelectorEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_Attribute_SelectorEntry.DiscardUnknown(m)
+
+func (x *Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_Attribute_SelectorEntry proto.InternalMessageInfo
+func (*Rule) ProtoMessage() {}
 
-func (m *Attribute_SelectorEntry) GetLabel() string {
-	if m != nil && m.Label != nil {
-		return *m.Label
+func (x *Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (m *Attribute_SelectorEntry) GetIsDefaultValue() bool {
-	if m != nil && m.IsDefaultValue != nil {
-		return *m.IsDefaultValue
-	}
-	return false
+// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
+func (*Rule) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{8}
 }
 
-func (m *Attribute_SelectorEntry) GetIntValue() int32 {
-	if m != nil && m.IntValue != nil {
-		return *m.IntValue
+func (x *Rule) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
-	return 0
+	return ""
 }
 
-func (m *Attribute_SelectorEntry) GetStringValue() string {
-	if m != nil && m.StringValue != nil {
-		return *m.StringValue
+func (x *Rule) GetRuleClass() string {
+	if x != nil && x.RuleClass != nil {
+		return *x.RuleClass
 	}
 	return ""
 }
 
-func (m *Attribute_SelectorEntry) GetBooleanValue() bool {
-	if m != nil && m.BooleanValue != nil {
-		return *m.BooleanValue
+func (x *Rule) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
 	}
-	return false
+	return ""
 }
 
-func (m *Attribute_SelectorEntry) GetTristateValue() Attribute_Tristate {
-	if m != nil && m.TristateValue != nil {
-		return *m.TristateValue
+func (x *Rule) GetAttribute() []*Attribute {
+	if x != nil {
+		return x.Attribute
 	}
-	return Attribute_NO
+	return nil
 }
 
-func (m *Attribute_SelectorEntry) GetStringListValue() []string {
-	if m != nil {
-		return m.StringListValue
+func (x *Rule) GetRuleInput() []string {
+	if x != nil {
+		return x.RuleInput
 	}
 	return nil
 }
 
-func (m *Attribute_SelectorEntry) GetLicense() *License {
-	if m != nil {
-		return m.License
+func (x *Rule) GetRuleOutput() []string {
+	if x != nil {
+		return x.RuleOutput
 	}
 	return nil
 }
 
-func (m *Attribute_SelectorEntry) GetStringDictValue() []*StringDictEntry {
-	if m != nil {
-		return m.StringDictValue
+func (x *Rule) GetDefaultSetting() []string {
+	if x != nil {
+		return x.DefaultSetting
 	}
 	return nil
 }
 
-func (m *Attribute_SelectorEntry) GetFilesetListValue() []*FilesetEntry {
-	if m != nil {
-		return m.FilesetListValue
+func (x *Rule) GetPublicByDefault() bool {
+	if x != nil && x.PublicByDefault != nil {
+		return *x.PublicByDefault
 	}
-	return nil
+	return false
 }
 
-func (m *Attribute_SelectorEntry) GetLabelListDictValue() []*LabelListDictEntry {
-	if m != nil {
-		return m.LabelListDictValue
+func (x *Rule) GetIsSkylark() bool {
+	if x != nil && x.IsSkylark != nil {
+		return *x.IsSkylark
 	}
-	return nil
+	return false
 }
 
-func (m *Attribute_SelectorEntry) GetStringListDictValue() []*StringListDictEntry {
-	if m != nil {
-		return m.StringListDictValue
+func (x *Rule) GetSkylarkEnvironmentHashCode() string {
+	if x != nil && x.SkylarkEnvironmentHashCode != nil {
+		return *x.SkylarkEnvironmentHashCode
 	}
-	return nil
+	return ""
+}
+
+type RuleSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rule       *Rule   `protobuf:"bytes,1,req,name=rule" json:"rule,omitempty"`
+	Dependency []*Rule `protobuf:"bytes,2,rep,name=dependency" json:"dependency,omitempty"`
+	Location   *string `protobuf:"bytes,3,opt,name=location" json:"location,omitempty"`
 }
 
-func (m *Attribute_SelectorEntry) GetIntListValue() []int32 {
-	if m != nil {
-		return m.IntListValue
+func (x *RuleSummary) Reset() {
+	*x = RuleSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (m *Attribute_SelectorEntry) GetLabelDictUnaryValue() []*LabelDictUnaryEntry {
-	if m != nil {
-		return m.LabelDictUnaryValue
+func (x *RuleSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleSummary) ProtoMessage() {}
+
+func (x *RuleSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleSummary.ProtoReflect.Descriptor instead.
+func (*RuleSummary) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{9}
 }
 
-func (m *Attribute_SelectorEntry) GetLabelKeyedStringDictValue() []*LabelKeyedStringDictEntry {
-	if m != nil {
-		return m.LabelKeyedStringDictValue
+func (x *RuleSummary) GetRule() *Rule {
+	if x != nil {
+		return x.Rule
 	}
 	return nil
 }
 
-func (m *Attribute_SelectorEntry) GetDEPRECATEDStringDictUnaryValue() [][]byte {
-	if m != nil {
-		return m.DEPRECATEDStringDictUnaryValue
+func (x *RuleSummary) GetDependency() []*Rule {
+	if x != nil {
+		return x.Dependency
 	}
 	return nil
 }
 
-type Attribute_Selector struct {
-	Entries              []*Attribute_SelectorEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
-	HasDefaultValue      *bool                      `protobuf:"varint,2,opt,name=has_default_value,json=hasDefaultValue" json:"has_default_value,omitempty"`
-	NoMatchError         *string                    `protobuf:"bytes,3,opt,name=no_match_error,json=noMatchError" json:"no_match_error,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+func (x *RuleSummary) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
 }
 
-func (m *Attribute_Selector) Reset()         { *m = Attribute_Selector{} }
-func (m *Attribute_Selector) String() string { return proto.CompactTextString(m) }
-func (*Attribute_Selector) ProtoMessage()    {}
-func (*Attribute_Selector) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{7, 1}
-}
+type PackageGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *Attribute_Selector) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Attribute_Selector.Unmarshal(m, b)
-}
-func (m *Attribute_Selector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Attribute_Selector.Marshal(b, m, deterministic)
-}
-func (m *Attribute_Selector) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Attribute_Selector.Merge(m, src)
+	Name                 *string  `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	ContainedPackage     []string `protobuf:"bytes,2,rep,name=contained_package,json=containedPackage" json:"contained_package,omitempty"`
+	IncludedPackageGroup []string `protobuf:"bytes,3,rep,name=included_package_group,json=includedPackageGroup" json:"included_package_group,omitempty"`
 }
-func (m *Attribute_Selector) XXX_Size() int {
-	return xxx_messageInfo_Attribute_Selector.Size(m)
+
+func (x *PackageGroup) Reset() {
+	*x = PackageGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *Attribute_Selector) XXX_DiscardUnknown() {
-	xxx_messageInfo_Attribute_Selector.DiscardUnknown(m)
+
+func (x *PackageGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_Attribute_Selector proto.InternalMessageInfo
+func (*PackageGroup) ProtoMessage() {}
 
-func (m *Attribute_Selector) GetEntries() []*Attribute_SelectorEntry {
-	if m != nil {
-		return m.Entries
+func (x *PackageGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (m *Attribute_Selector) GetHasDefaultValue() bool {
-	if m != nil && m.HasDefaultValue != nil {
-		return *m.HasDefaultValue
-	}
-	return false
+// Deprecated: Use PackageGroup.ProtoReflect.Descriptor instead.
+func (*PackageGroup) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{10}
 }
 
-func (m *Attribute_Selector) GetNoMatchError() string {
-	if m != nil && m.NoMatchError != nil {
-		return *m.NoMatchError
+func (x *PackageGroup) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
 	return ""
 }
 
-type Attribute_SelectorList struct {
-	Type                 *Attribute_Discriminator `protobuf:"varint,1,opt,name=type,enum=blaze_query.Attribute_Discriminator" json:"type,omitempty"`
-	Elements             []*Attribute_Selector    `protobuf:"bytes,2,rep,name=elements" json:"elements,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
+func (x *PackageGroup) GetContainedPackage() []string {
+	if x != nil {
+		return x.ContainedPackage
+	}
+	return nil
 }
 
-func (m *Attribute_SelectorList) Reset()         { *m = Attribute_SelectorList{} }
-func (m *Attribute_SelectorList) String() string { return proto.CompactTextString(m) }
-func (*Attribute_SelectorList) ProtoMessage()    {}
-func (*Attribute_SelectorList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{7, 2}
+func (x *PackageGroup) GetIncludedPackageGroup() []string {
+	if x != nil {
+		return x.IncludedPackageGroup
+	}
+	return nil
 }
 
-func (m *Attribute_SelectorList) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Attribute_SelectorList.Unmarshal(m, b)
+type EnvironmentGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        *string  `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	Environment []string `protobuf:"bytes,2,rep,name=environment" json:"environment,omitempty"`
+	Default     []string `protobuf:"bytes,3,rep,name=default" json:"default,omitempty"`
 }
-func (m *Attribute_SelectorList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Attribute_SelectorList.Marshal(b, m, deterministic)
+
+func (x *EnvironmentGroup) Reset() {
+	*x = EnvironmentGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *Attribute_SelectorList) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Attribute_SelectorList.Merge(m, src)
+
+func (x *EnvironmentGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *Attribute_SelectorList) XXX_Size() int {
-	return xxx_messageInfo_Attribute_SelectorList.Size(m)
+
+func (*EnvironmentGroup) ProtoMessage() {}
+
+func (x *EnvironmentGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (m *Attribute_SelectorList) XXX_DiscardUnknown() {
-	xxx_messageInfo_Attribute_SelectorList.DiscardUnknown(m)
+
+// Deprecated: Use EnvironmentGroup.ProtoReflect.Descriptor instead.
+func (*EnvironmentGroup) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{11}
 }
 
-var xxx_messageInfo_Attribute_SelectorList proto.InternalMessageInfo
+func (x *EnvironmentGroup) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
 
-func (m *Attribute_SelectorList) GetType() Attribute_Discriminator {
-	if m != nil && m.Type != nil {
-		return *m.Type
+func (x *EnvironmentGroup) GetEnvironment() []string {
+	if x != nil {
+		return x.Environment
 	}
-	return Attribute_INTEGER
+	return nil
 }
 
-func (m *Attribute_SelectorList) GetElements() []*Attribute_Selector {
-	if m != nil {
-		return m.Elements
+func (x *EnvironmentGroup) GetDefault() []string {
+	if x != nil {
+		return x.Default
 	}
 	return nil
 }
 
-type Rule struct {
-	Name                       *string      `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
-	RuleClass                  *string      `protobuf:"bytes,2,req,name=rule_class,json=ruleClass" json:"rule_class,omitempty"`
-	Location                   *string      `protobuf:"bytes,3,opt,name=location" json:"location,omitempty"`
-	Attribute                  []*Attribute `protobuf:"bytes,4,rep,name=attribute" json:"attribute,omitempty"`
-	RuleInput                  []string     `protobuf:"bytes,5,rep,name=rule_input,json=ruleInput" json:"rule_input,omitempty"`
-	RuleOutput                 []string     `protobuf:"bytes,6,rep,name=rule_output,json=ruleOutput" json:"rule_output,omitempty"`
-	DefaultSetting             []string     `protobuf:"bytes,7,rep,name=default_setting,json=defaultSetting" json:"default_setting,omitempty"`
-	PublicByDefault            *bool        `protobuf:"varint,9,opt,name=public_by_default,json=publicByDefault" json:"public_by_default,omitempty"`
-	IsSkylark                  *bool        `protobuf:"varint,10,opt,name=is_skylark,json=isSkylark" json:"is_skylark,omitempty"`
-	SkylarkEnvironmentHashCode *string      `protobuf:"bytes,12,opt,name=skylark_environment_hash_code,json=skylarkEnvironmentHashCode" json:"skylark_environment_hash_code,omitempty"`
-	XXX_NoUnkeyedLiteral       struct{}     `json:"-"`
-	XXX_unrecognized           []byte       `json:"-"`
-	XXX_sizecache              int32        `json:"-"`
-}
+type SourceFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *Rule) Reset()         { *m = Rule{} }
-func (m *Rule) String() string { return proto.CompactTextString(m) }
-func (*Rule) ProtoMessage()    {}
-func (*Rule) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{8}
+	Name                  *string  `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	Location              *string  `protobuf:"bytes,2,opt,name=location" json:"location,omitempty"`
+	Subinclude            []string `protobuf:"bytes,3,rep,name=subinclude" json:"subinclude,omitempty"`
+	PackageGroup          []string `protobuf:"bytes,4,rep,name=package_group,json=packageGroup" json:"package_group,omitempty"`
+	VisibilityLabel       []string `protobuf:"bytes,5,rep,name=visibility_label,json=visibilityLabel" json:"visibility_label,omitempty"`
+	Feature               []string `protobuf:"bytes,6,rep,name=feature" json:"feature,omitempty"`
+	License               *License `protobuf:"bytes,8,opt,name=license" json:"license,omitempty"`
+	PackageContainsErrors *bool    `protobuf:"varint,9,opt,name=package_contains_errors,json=packageContainsErrors" json:"package_contains_errors,omitempty"`
 }
 
-func (m *Rule) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Rule.Unmarshal(m, b)
-}
-func (m *Rule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Rule.Marshal(b, m, deterministic)
-}
-func (m *Rule) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Rule.Merge(m, src)
-}
-func (m *Rule) XXX_Size() int {
-	return xxx_messageInfo_Rule.Size(m)
+func (x *SourceFile) Reset() {
+	*x = SourceFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *Rule) XXX_DiscardUnknown() {
-	xxx_messageInfo_Rule.DiscardUnknown(m)
+
+func (x *SourceFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_Rule proto.InternalMessageInfo
+func (*SourceFile) ProtoMessage() {}
 
-func (m *Rule) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (x *SourceFile) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (m *Rule) GetRuleClass() string {
-	if m != nil && m.RuleClass != nil {
-		return *m.RuleClass
+// Deprecated: Use SourceFile.ProtoReflect.Descriptor instead.
+func (*SourceFile) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SourceFile) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
 	return ""
 }
 
-func (m *Rule) GetLocation() string {
-	if m != nil && m.Location != nil {
-		return *m.Location
+func (x *SourceFile) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
 	}
 	return ""
 }
 
-func (m *Rule) GetAttribute() []*Attribute {
-	if m != nil {
-		return m.Attribute
+func (x *SourceFile) GetSubinclude() []string {
+	if x != nil {
+		return x.Subinclude
 	}
 	return nil
 }
 
-func (m *Rule) GetRuleInput() []string {
-	if m != nil {
-		return m.RuleInput
+func (x *SourceFile) GetPackageGroup() []string {
+	if x != nil {
+		return x.PackageGroup
 	}
 	return nil
 }
 
-func (m *Rule) GetRuleOutput() []string {
-	if m != nil {
-		return m.RuleOutput
+func (x *SourceFile) GetVisibilityLabel() []string {
+	if x != nil {
+		return x.VisibilityLabel
 	}
 	return nil
 }
 
-func (m *Rule) GetDefaultSetting() []string {
-	if m != nil {
-		return m.DefaultSetting
+func (x *SourceFile) GetFeature() []string {
+	if x != nil {
+		return x.Feature
 	}
 	return nil
 }
 
-func (m *Rule) GetPublicByDefault() bool {
-	if m != nil && m.PublicByDefault != nil {
-		return *m.PublicByDefault
+func (x *SourceFile) GetLicense() *License {
+	if x != nil {
+		return x.License
 	}
-	return false
+	return nil
 }
 
-func (m *Rule) GetIsSkylark() bool {
-	if m != nil && m.IsSkylark != nil {
-		return *m.IsSkylark
+func (x *SourceFile) GetPackageContainsErrors() bool {
+	if x != nil && x.PackageContainsErrors != nil {
+		return *x.PackageContainsErrors
 	}
 	return false
 }
 
-func (m *Rule) GetSkylarkEnvironmentHashCode() string {
-	if m != nil && m.SkylarkEnvironmentHashCode != nil {
-		return *m.SkylarkEnvironmentHashCode
-	}
-	return ""
-}
+type GeneratedFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-type RuleSummary struct {
-	Rule                 *Rule    `protobuf:"bytes,1,req,name=rule" json:"rule,omitempty"`
-	Dependency           []*Rule  `protobuf:"bytes,2,rep,name=dependency" json:"dependency,omitempty"`
-	Location             *string  `protobuf:"bytes,3,opt,name=location" json:"location,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name           *string `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	GeneratingRule *string `protobuf:"bytes,2,req,name=generating_rule,json=generatingRule" json:"generating_rule,omitempty"`
+	Location       *string `protobuf:"bytes,3,opt,name=location" json:"location,omitempty"`
 }
 
-func (m *RuleSummary) Reset()         { *m = RuleSummary{} }
-func (m *RuleSummary) String() string { return proto.CompactTextString(m) }
-func (*RuleSummary) ProtoMessage()    {}
-func (*RuleSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{9}
+func (x *GeneratedFile) Reset() {
+	*x = GeneratedFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (m *RuleSummary) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RuleSummary.Unmarshal(m, b)
-}
-func (m *RuleSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RuleSummary.Marshal(b, m, deterministic)
-}
-func (m *RuleSummary) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RuleSummary.Merge(m, src)
-}
-func (m *RuleSummary) XXX_Size() int {
-	return xxx_messageInfo_RuleSummary.Size(m)
-}
-func (m *RuleSummary) XXX_DiscardUnknown() {
-	xxx_messageInfo_RuleSummary.DiscardUnknown(m)
+func (x *GeneratedFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_RuleSummary proto.InternalMessageInfo
+func (*GeneratedFile) ProtoMessage() {}
 
-func (m *RuleSummary) GetRule() *Rule {
-	if m != nil {
-		return m.Rule
+func (x *GeneratedFile) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (m *RuleSummary) GetDependency() []*Rule {
-	if m != nil {
-		return m.Dependency
-	}
-	return nil
+// Deprecated: Use GeneratedFile.ProtoReflect.Descriptor instead.
+func (*GeneratedFile) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{13}
 }
 
-func (m *RuleSummary) GetLocation() string {
-	if m != nil && m.Location != nil {
-		return *m.Location
+func (x *GeneratedFile) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
 	return ""
 }
 
-type PackageGroup struct {
-	Name                 *string  `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
-	ContainedPackage     []string `protobuf:"bytes,2,rep,name=contained_package,json=containedPackage" json:"contained_package,omitempty"`
-	IncludedPackageGroup []string `protobuf:"bytes,3,rep,name=included_package_group,json=includedPackageGroup" json:"included_package_group,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *GeneratedFile) GetGeneratingRule() string {
+	if x != nil && x.GeneratingRule != nil {
+		return *x.GeneratingRule
+	}
+	return ""
 }
 
-func (m *PackageGroup) Reset()         { *m = PackageGroup{} }
-func (m *PackageGroup) String() string { return proto.CompactTextString(m) }
-func (*PackageGroup) ProtoMessage()    {}
-func (*PackageGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{10}
+func (x *GeneratedFile) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
 }
 
-func (m *PackageGroup) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PackageGroup.Unmarshal(m, b)
-}
-func (m *PackageGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PackageGroup.Marshal(b, m, deterministic)
+type Target struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type             *Target_Discriminator `protobuf:"varint,1,req,name=type,enum=blaze_query.Target_Discriminator" json:"type,omitempty"`
+	Rule             *Rule                 `protobuf:"bytes,2,opt,name=rule" json:"rule,omitempty"`
+	SourceFile       *SourceFile           `protobuf:"bytes,3,opt,name=source_file,json=sourceFile" json:"source_file,omitempty"`
+	GeneratedFile    *GeneratedFile        `protobuf:"bytes,4,opt,name=generated_file,json=generatedFile" json:"generated_file,omitempty"`
+	PackageGroup     *PackageGroup         `protobuf:"bytes,5,opt,name=package_group,json=packageGroup" json:"package_group,omitempty"`
+	EnvironmentGroup *EnvironmentGroup     `protobuf:"bytes,6,opt,name=environment_group,json=environmentGroup" json:"environment_group,omitempty"`
 }
-func (m *PackageGroup) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PackageGroup.Merge(m, src)
+
+func (x *Target) Reset() {
+	*x = Target{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *PackageGroup) XXX_Size() int {
-	return xxx_messageInfo_PackageGroup.Size(m)
+
+func (x *Target) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *PackageGroup) XXX_DiscardUnknown() {
-	xxx_messageInfo_PackageGroup.DiscardUnknown(m)
+
+func (*Target) ProtoMessage() {}
+
+func (x *Target) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_PackageGroup proto.InternalMessageInfo
+// Deprecated: Use Target.ProtoReflect.Descriptor instead.
+func (*Target) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{14}
+}
 
-func (m *PackageGroup) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (x *Target) GetType() Target_Discriminator {
+	if x != nil && x.Type != nil {
+		return *x.Type
 	}
-	return ""
+	return Target_RULE
 }
 
-func (m *PackageGroup) GetContainedPackage() []string {
-	if m != nil {
-		return m.ContainedPackage
+func (x *Target) GetRule() *Rule {
+	if x != nil {
+		return x.Rule
 	}
 	return nil
 }
 
-func (m *PackageGroup) GetIncludedPackageGroup() []string {
-	if m != nil {
-		return m.IncludedPackageGroup
+func (x *Target) GetSourceFile() *SourceFile {
+	if x != nil {
+		return x.SourceFile
 	}
 	return nil
 }
 
-type EnvironmentGroup struct {
-	Name                 *string  `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
-	Environment          []string `protobuf:"bytes,2,rep,name=environment" json:"environment,omitempty"`
-	Default              []string `protobuf:"bytes,3,rep,name=default" json:"default,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *Target) GetGeneratedFile() *GeneratedFile {
+	if x != nil {
+		return x.GeneratedFile
+	}
+	return nil
 }
 
-func (m *EnvironmentGroup) Reset()         { *m = EnvironmentGroup{} }
-func (m *EnvironmentGroup) String() string { return proto.CompactTextString(m) }
-func (*EnvironmentGroup) ProtoMessage()    {}
-func (*EnvironmentGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{11}
+func (x *Target) GetPackageGroup() *PackageGroup {
+	if x != nil {
+		return x.PackageGroup
+	}
+	return nil
 }
 
-func (m *EnvironmentGroup) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EnvironmentGroup.Unmarshal(m, b)
-}
-func (m *EnvironmentGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EnvironmentGroup.Marshal(b, m, deterministic)
+func (x *Target) GetEnvironmentGroup() *EnvironmentGroup {
+	if x != nil {
+		return x.EnvironmentGroup
+	}
+	return nil
 }
-func (m *EnvironmentGroup) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EnvironmentGroup.Merge(m, src)
+
+type QueryResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target []*Target `protobuf:"bytes,1,rep,name=target" json:"target,omitempty"`
 }
-func (m *EnvironmentGroup) XXX_Size() int {
-	return xxx_messageInfo_EnvironmentGroup.Size(m)
+
+func (x *QueryResult) Reset() {
+	*x = QueryResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *EnvironmentGroup) XXX_DiscardUnknown() {
-	xxx_messageInfo_EnvironmentGroup.DiscardUnknown(m)
+
+func (x *QueryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_EnvironmentGroup proto.InternalMessageInfo
+func (*QueryResult) ProtoMessage() {}
 
-func (m *EnvironmentGroup) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (x *QueryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (m *EnvironmentGroup) GetEnvironment() []string {
-	if m != nil {
-		return m.Environment
-	}
-	return nil
+// Deprecated: Use QueryResult.ProtoReflect.Descriptor instead.
+func (*QueryResult) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{15}
 }
 
-func (m *EnvironmentGroup) GetDefault() []string {
-	if m != nil {
-		return m.Default
+func (x *QueryResult) GetTarget() []*Target {
+	if x != nil {
+		return x.Target
 	}
 	return nil
 }
 
-type SourceFile struct {
-	Name                  *string  `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
-	Location              *string  `protobuf:"bytes,2,opt,name=location" json:"location,omitempty"`
-	Subinclude            []string `protobuf:"bytes,3,rep,name=subinclude" json:"subinclude,omitempty"`
-	PackageGroup          []string `protobuf:"bytes,4,rep,name=package_group,json=packageGroup" json:"package_group,omitempty"`
-	VisibilityLabel       []string `protobuf:"bytes,5,rep,name=visibility_label,json=visibilityLabel" json:"visibility_label,omitempty"`
-	Feature               []string `protobuf:"bytes,6,rep,name=feature" json:"feature,omitempty"`
-	License               *License `protobuf:"bytes,8,opt,name=license" json:"license,omitempty"`
-	PackageContainsErrors *bool    `protobuf:"varint,9,opt,name=package_contains_errors,json=packageContainsErrors" json:"package_contains_errors,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
-	XXX_unrecognized      []byte   `json:"-"`
-	XXX_sizecache         int32    `json:"-"`
+type AllowedRuleClassInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policy           *AllowedRuleClassInfo_AllowedRuleClasses `protobuf:"varint,1,req,name=policy,enum=blaze_query.AllowedRuleClassInfo_AllowedRuleClasses" json:"policy,omitempty"`
+	AllowedRuleClass []string                                 `protobuf:"bytes,2,rep,name=allowed_rule_class,json=allowedRuleClass" json:"allowed_rule_class,omitempty"`
 }
 
-func (m *SourceFile) Reset()         { *m = SourceFile{} }
-func (m *SourceFile) String() string { return proto.CompactTextString(m) }
-func (*SourceFile) ProtoMessage()    {}
-func (*SourceFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{12}
+func (x *AllowedRuleClassInfo) Reset() {
+	*x = AllowedRuleClassInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (m *SourceFile) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SourceFile.Unmarshal(m, b)
+func (x *AllowedRuleClassInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *SourceFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SourceFile.Marshal(b, m, deterministic)
+
+func (*AllowedRuleClassInfo) ProtoMessage() {}
+
+func (x *AllowedRuleClassInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (m *SourceFile) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SourceFile.Merge(m, src)
+
+// Deprecated: Use AllowedRuleClassInfo.ProtoReflect.Descriptor instead.
+func (*AllowedRuleClassInfo) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{16}
 }
-func (m *SourceFile) XXX_Size() int {
-	return xxx_messageInfo_SourceFile.Size(m)
+
+func (x *AllowedRuleClassInfo) GetPolicy() AllowedRuleClassInfo_AllowedRuleClasses {
+	if x != nil && x.Policy != nil {
+		return *x.Policy
+	}
+	return AllowedRuleClassInfo_ANY
 }
-func (m *SourceFile) XXX_DiscardUnknown() {
-	xxx_messageInfo_SourceFile.DiscardUnknown(m)
+
+func (x *AllowedRuleClassInfo) GetAllowedRuleClass() []string {
+	if x != nil {
+		return x.AllowedRuleClass
+	}
+	return nil
 }
 
-var xxx_messageInfo_SourceFile proto.InternalMessageInfo
+type AttributeDefinition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *SourceFile) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+	Name               *string                  `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	Type               *Attribute_Discriminator `protobuf:"varint,2,req,name=type,enum=blaze_query.Attribute_Discriminator" json:"type,omitempty"`
+	Mandatory          *bool                    `protobuf:"varint,3,opt,name=mandatory" json:"mandatory,omitempty"`
+	AllowedRuleClasses *AllowedRuleClassInfo    `protobuf:"bytes,4,opt,name=allowed_rule_classes,json=allowedRuleClasses" json:"allowed_rule_classes,omitempty"`
+	Documentation      *string                  `protobuf:"bytes,5,opt,name=documentation" json:"documentation,omitempty"`
+	AllowEmpty         *bool                    `protobuf:"varint,6,opt,name=allow_empty,json=allowEmpty" json:"allow_empty,omitempty"`
+	AllowSingleFile    *bool                    `protobuf:"varint,7,opt,name=allow_single_file,json=allowSingleFile" json:"allow_single_file,omitempty"`
+	Default            *AttributeValue          `protobuf:"bytes,9,opt,name=default" json:"default,omitempty"`
+	Executable         *bool                    `protobuf:"varint,10,opt,name=executable" json:"executable,omitempty"`
+	Configurable       *bool                    `protobuf:"varint,11,opt,name=configurable" json:"configurable,omitempty"`
+	Nodep              *bool                    `protobuf:"varint,12,opt,name=nodep" json:"nodep,omitempty"`
+	CfgIsHost          *bool                    `protobuf:"varint,13,opt,name=cfg_is_host,json=cfgIsHost" json:"cfg_is_host,omitempty"`
+}
+
+func (x *AttributeDefinition) Reset() {
+	*x = AttributeDefinition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (m *SourceFile) GetLocation() string {
-	if m != nil && m.Location != nil {
-		return *m.Location
+func (x *AttributeDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeDefinition) ProtoMessage() {}
+
+func (x *AttributeDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeDefinition.ProtoReflect.Descriptor instead.
+func (*AttributeDefinition) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{17}
 }
 
-func (m *SourceFile) GetSubinclude() []string {
-	if m != nil {
-		return m.Subinclude
+func (x *AttributeDefinition) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
-	return nil
+	return ""
 }
 
-func (m *SourceFile) GetPackageGroup() []string {
-	if m != nil {
-		return m.PackageGroup
+func (x *AttributeDefinition) GetType() Attribute_Discriminator {
+	if x != nil && x.Type != nil {
+		return *x.Type
 	}
-	return nil
+	return Attribute_INTEGER
 }
 
-func (m *SourceFile) GetVisibilityLabel() []string {
-	if m != nil {
-		return m.VisibilityLabel
+func (x *AttributeDefinition) GetMandatory() bool {
+	if x != nil && x.Mandatory != nil {
+		return *x.Mandatory
 	}
-	return nil
+	return false
 }
 
-func (m *SourceFile) GetFeature() []string {
-	if m != nil {
-		return m.Feature
+func (x *AttributeDefinition) GetAllowedRuleClasses() *AllowedRuleClassInfo {
+	if x != nil {
+		return x.AllowedRuleClasses
 	}
 	return nil
 }
 
-func (m *SourceFile) GetLicense() *License {
-	if m != nil {
-		return m.License
+func (x *AttributeDefinition) GetDocumentation() string {
+	if x != nil && x.Documentation != nil {
+		return *x.Documentation
 	}
-	return nil
+	return ""
 }
 
-func (m *SourceFile) GetPackageContainsErrors() bool {
-	if m != nil && m.PackageContainsErrors != nil {
-		return *m.PackageContainsErrors
+func (x *AttributeDefinition) GetAllowEmpty() bool {
+	if x != nil && x.AllowEmpty != nil {
+		return *x.AllowEmpty
 	}
 	return false
 }
 
-type GeneratedFile struct {
-	Name                 *string  `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
-	GeneratingRule       *string  `protobuf:"bytes,2,req,name=generating_rule,json=generatingRule" json:"generating_rule,omitempty"`
-	Location             *string  `protobuf:"bytes,3,opt,name=location" json:"location,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *AttributeDefinition) GetAllowSingleFile() bool {
+	if x != nil && x.AllowSingleFile != nil {
+		return *x.AllowSingleFile
+	}
+	return false
 }
 
-func (m *GeneratedFile) Reset()         { *m = GeneratedFile{} }
-func (m *GeneratedFile) String() string { return proto.CompactTextString(m) }
-func (*GeneratedFile) ProtoMessage()    {}
-func (*GeneratedFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{13}
+func (x *AttributeDefinition) GetDefault() *AttributeValue {
+	if x != nil {
+		return x.Default
+	}
+	return nil
 }
 
-func (m *GeneratedFile) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GeneratedFile.Unmarshal(m, b)
-}
-func (m *GeneratedFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GeneratedFile.Marshal(b, m, deterministic)
-}
-func (m *GeneratedFile) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GeneratedFile.Merge(m, src)
-}
-func (m *GeneratedFile) XXX_Size() int {
-	return xxx_messageInfo_GeneratedFile.Size(m)
-}
-func (m *GeneratedFile) XXX_DiscardUnknown() {
-	xxx_messageInfo_GeneratedFile.DiscardUnknown(m)
+func (x *AttributeDefinition) GetExecutable() bool {
+	if x != nil && x.Executable != nil {
+		return *x.Executable
+	}
+	return false
 }
 
-var xxx_messageInfo_GeneratedFile proto.InternalMessageInfo
-
-func (m *GeneratedFile) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (x *AttributeDefinition) GetConfigurable() bool {
+	if x != nil && x.Configurable != nil {
+		return *x.Configurable
 	}
-	return ""
+	return false
 }
 
-func (m *GeneratedFile) GetGeneratingRule() string {
-	if m != nil && m.GeneratingRule != nil {
-		return *m.GeneratingRule
+func (x *AttributeDefinition) GetNodep() bool {
+	if x != nil && x.Nodep != nil {
+		return *x.Nodep
 	}
-	return ""
+	return false
 }
 
-func (m *GeneratedFile) GetLocation() string {
-	if m != nil && m.Location != nil {
-		return *m.Location
+func (x *AttributeDefinition) GetCfgIsHost() bool {
+	if x != nil && x.CfgIsHost != nil {
+		return *x.CfgIsHost
 	}
-	return ""
+	return false
 }
 
-type Target struct {
-	Type                 *Target_Discriminator `protobuf:"varint,1,req,name=type,enum=blaze_query.Target_Discriminator" json:"type,omitempty"`
-	Rule                 *Rule                 `protobuf:"bytes,2,opt,name=rule" json:"rule,omitempty"`
-	SourceFile           *SourceFile           `protobuf:"bytes,3,opt,name=source_file,json=sourceFile" json:"source_file,omitempty"`
-	GeneratedFile        *GeneratedFile        `protobuf:"bytes,4,opt,name=generated_file,json=generatedFile" json:"generated_file,omitempty"`
-	PackageGroup         *PackageGroup         `protobuf:"bytes,5,opt,name=package_group,json=packageGroup" json:"package_group,omitempty"`
-	EnvironmentGroup     *EnvironmentGroup     `protobuf:"bytes,6,opt,name=environment_group,json=environmentGroup" json:"environment_group,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
-}
-
-func (m *Target) Reset()         { *m = Target{} }
-func (m *Target) String() string { return proto.CompactTextString(m) }
-func (*Target) ProtoMessage()    {}
-func (*Target) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{14}
-}
+type AttributeValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *Target) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Target.Unmarshal(m, b)
-}
-func (m *Target) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Target.Marshal(b, m, deterministic)
-}
-func (m *Target) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Target.Merge(m, src)
+	Int     *int32                      `protobuf:"varint,1,opt,name=int" json:"int,omitempty"`
+	String_ *string                     `protobuf:"bytes,2,opt,name=string" json:"string,omitempty"`
+	Bool    *bool                       `protobuf:"varint,3,opt,name=bool" json:"bool,omitempty"`
+	List    []*AttributeValue           `protobuf:"bytes,4,rep,name=list" json:"list,omitempty"`
+	Dict    []*AttributeValue_DictEntry `protobuf:"bytes,5,rep,name=dict" json:"dict,omitempty"`
 }
-func (m *Target) XXX_Size() int {
-	return xxx_messageInfo_Target.Size(m)
+
+func (x *AttributeValue) Reset() {
+	*x = AttributeValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *Target) XXX_DiscardUnknown() {
-	xxx_messageInfo_Target.DiscardUnknown(m)
+
+func (x *AttributeValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_Target proto.InternalMessageInfo
+func (*AttributeValue) ProtoMessage() {}
 
-func (m *Target) GetType() Target_Discriminator {
-	if m != nil && m.Type != nil {
-		return *m.Type
+func (x *AttributeValue) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return Target_RULE
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeValue.ProtoReflect.Descriptor instead.
+func (*AttributeValue) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{18}
 }
 
-func (m *Target) GetRule() *Rule {
-	if m != nil {
-		return m.Rule
+func (x *AttributeValue) GetInt() int32 {
+	if x != nil && x.Int != nil {
+		return *x.Int
 	}
-	return nil
+	return 0
 }
 
-func (m *Target) GetSourceFile() *SourceFile {
-	if m != nil {
-		return m.SourceFile
+func (x *AttributeValue) GetString_() string {
+	if x != nil && x.String_ != nil {
+		return *x.String_
 	}
-	return nil
+	return ""
 }
 
-func (m *Target) GetGeneratedFile() *GeneratedFile {
-	if m != nil {
-		return m.GeneratedFile
+func (x *AttributeValue) GetBool() bool {
+	if x != nil && x.Bool != nil {
+		return *x.Bool
 	}
-	return nil
+	return false
 }
 
-func (m *Target) GetPackageGroup() *PackageGroup {
-	if m != nil {
-		return m.PackageGroup
+func (x *AttributeValue) GetList() []*AttributeValue {
+	if x != nil {
+		return x.List
 	}
 	return nil
 }
 
-func (m *Target) GetEnvironmentGroup() *EnvironmentGroup {
-	if m != nil {
-		return m.EnvironmentGroup
+func (x *AttributeValue) GetDict() []*AttributeValue_DictEntry {
+	if x != nil {
+		return x.Dict
 	}
 	return nil
 }
 
-type QueryResult struct {
-	Target               []*Target `protobuf:"bytes,1,rep,name=target" json:"target,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
-}
+type RuleDefinition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *QueryResult) Reset()         { *m = QueryResult{} }
-func (m *QueryResult) String() string { return proto.CompactTextString(m) }
-func (*QueryResult) ProtoMessage()    {}
-func (*QueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{15}
+	Name          *string                `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	Attribute     []*AttributeDefinition `protobuf:"bytes,2,rep,name=attribute" json:"attribute,omitempty"`
+	Documentation *string                `protobuf:"bytes,3,opt,name=documentation" json:"documentation,omitempty"`
+	Label         *string                `protobuf:"bytes,4,opt,name=label" json:"label,omitempty"`
 }
 
-func (m *QueryResult) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_QueryResult.Unmarshal(m, b)
-}
-func (m *QueryResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_QueryResult.Marshal(b, m, deterministic)
+func (x *RuleDefinition) Reset() {
+	*x = RuleDefinition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *QueryResult) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryResult.Merge(m, src)
+
+func (x *RuleDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *QueryResult) XXX_Size() int {
-	return xxx_messageInfo_QueryResult.Size(m)
+
+func (*RuleDefinition) ProtoMessage() {}
+
+func (x *RuleDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (m *QueryResult) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryResult.DiscardUnknown(m)
+
+// Deprecated: Use RuleDefinition.ProtoReflect.Descriptor instead.
+func (*RuleDefinition) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{19}
 }
 
-var xxx_messageInfo_QueryResult proto.InternalMessageInfo
+func (x *RuleDefinition) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
 
-func (m *QueryResult) GetTarget() []*Target {
-	if m != nil {
-		return m.Target
+func (x *RuleDefinition) GetAttribute() []*AttributeDefinition {
+	if x != nil {
+		return x.Attribute
 	}
 	return nil
 }
 
-type AllowedRuleClassInfo struct {
-	Policy               *AllowedRuleClassInfo_AllowedRuleClasses `protobuf:"varint,1,req,name=policy,enum=blaze_query.AllowedRuleClassInfo_AllowedRuleClasses" json:"policy,omitempty"`
-	AllowedRuleClass     []string                                 `protobuf:"bytes,2,rep,name=allowed_rule_class,json=allowedRuleClass" json:"allowed_rule_class,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
-	XXX_unrecognized     []byte                                   `json:"-"`
-	XXX_sizecache        int32                                    `json:"-"`
+func (x *RuleDefinition) GetDocumentation() string {
+	if x != nil && x.Documentation != nil {
+		return *x.Documentation
+	}
+	return ""
 }
 
-func (m *AllowedRuleClassInfo) Reset()         { *m = AllowedRuleClassInfo{} }
-func (m *AllowedRuleClassInfo) String() string { return proto.CompactTextString(m) }
-func (*AllowedRuleClassInfo) ProtoMessage()    {}
-func (*AllowedRuleClassInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{16}
+func (x *RuleDefinition) GetLabel() string {
+	if x != nil && x.Label != nil {
+		return *x.Label
+	}
+	return ""
 }
 
-func (m *AllowedRuleClassInfo) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AllowedRuleClassInfo.Unmarshal(m, b)
-}
-func (m *AllowedRuleClassInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AllowedRuleClassInfo.Marshal(b, m, deterministic)
-}
-func (m *AllowedRuleClassInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AllowedRuleClassInfo.Merge(m, src)
+type BuildLanguage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rule []*RuleDefinition `protobuf:"bytes,1,rep,name=rule" json:"rule,omitempty"`
 }
-func (m *AllowedRuleClassInfo) XXX_Size() int {
-	return xxx_messageInfo_AllowedRuleClassInfo.Size(m)
+
+func (x *BuildLanguage) Reset() {
+	*x = BuildLanguage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *AllowedRuleClassInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_AllowedRuleClassInfo.DiscardUnknown(m)
+
+func (x *BuildLanguage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_AllowedRuleClassInfo proto.InternalMessageInfo
+func (*BuildLanguage) ProtoMessage() {}
 
-func (m *AllowedRuleClassInfo) GetPolicy() AllowedRuleClassInfo_AllowedRuleClasses {
-	if m != nil && m.Policy != nil {
-		return *m.Policy
+func (x *BuildLanguage) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return AllowedRuleClassInfo_ANY
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildLanguage.ProtoReflect.Descriptor instead.
+func (*BuildLanguage) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{20}
 }
 
-func (m *AllowedRuleClassInfo) GetAllowedRuleClass() []string {
-	if m != nil {
-		return m.AllowedRuleClass
+func (x *BuildLanguage) GetRule() []*RuleDefinition {
+	if x != nil {
+		return x.Rule
 	}
 	return nil
 }
 
-type AttributeDefinition struct {
-	Name                 *string                  `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
-	Type                 *Attribute_Discriminator `protobuf:"varint,2,req,name=type,enum=blaze_query.Attribute_Discriminator" json:"type,omitempty"`
-	Mandatory            *bool                    `protobuf:"varint,3,opt,name=mandatory" json:"mandatory,omitempty"`
-	AllowedRuleClasses   *AllowedRuleClassInfo    `protobuf:"bytes,4,opt,name=allowed_rule_classes,json=allowedRuleClasses" json:"allowed_rule_classes,omitempty"`
-	Documentation        *string                  `protobuf:"bytes,5,opt,name=documentation" json:"documentation,omitempty"`
-	AllowEmpty           *bool                    `protobuf:"varint,6,opt,name=allow_empty,json=allowEmpty" json:"allow_empty,omitempty"`
-	AllowSingleFile      *bool                    `protobuf:"varint,7,opt,name=allow_single_file,json=allowSingleFile" json:"allow_single_file,omitempty"`
-	Default              *AttributeValue          `protobuf:"bytes,9,opt,name=default" json:"default,omitempty"`
-	Executable           *bool                    `protobuf:"varint,10,opt,name=executable" json:"executable,omitempty"`
-	Configurable         *bool                    `protobuf:"varint,11,opt,name=configurable" json:"configurable,omitempty"`
-	Nodep                *bool                    `protobuf:"varint,12,opt,name=nodep" json:"nodep,omitempty"`
-	CfgIsHost            *bool                    `protobuf:"varint,13,opt,name=cfg_is_host,json=cfgIsHost" json:"cfg_is_host,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
-}
-
-func (m *AttributeDefinition) Reset()         { *m = AttributeDefinition{} }
-func (m *AttributeDefinition) String() string { return proto.CompactTextString(m) }
-func (*AttributeDefinition) ProtoMessage()    {}
-func (*AttributeDefinition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{17}
-}
+type Attribute_SelectorEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *AttributeDefinition) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AttributeDefinition.Unmarshal(m, b)
-}
-func (m *AttributeDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AttributeDefinition.Marshal(b, m, deterministic)
-}
-func (m *AttributeDefinition) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AttributeDefinition.Merge(m, src)
+	Label                          *string                      `protobuf:"bytes,1,opt,name=label" json:"label,omitempty"`
+	IsDefaultValue                 *bool                        `protobuf:"varint,16,opt,name=is_default_value,json=isDefaultValue" json:"is_default_value,omitempty"`
+	IntValue                       *int32                       `protobuf:"varint,2,opt,name=int_value,json=intValue" json:"int_value,omitempty"`
+	StringValue                    *string                      `protobuf:"bytes,3,opt,name=string_value,json=stringValue" json:"string_value,omitempty"`
+	BooleanValue                   *bool                        `protobuf:"varint,4,opt,name=boolean_value,json=booleanValue" json:"boolean_value,omitempty"`
+	TristateValue                  *Attribute_Tristate          `protobuf:"varint,5,opt,name=tristate_value,json=tristateValue,enum=blaze_query.Attribute_Tristate" json:"tristate_value,omitempty"`
+	StringListValue                []string                     `protobuf:"bytes,6,rep,name=string_list_value,json=stringListValue" json:"string_list_value,omitempty"`
+	License                        *License                     `protobuf:"bytes,7,opt,name=license" json:"license,omitempty"`
+	StringDictValue                []*StringDictEntry           `protobuf:"bytes,8,rep,name=string_dict_value,json=stringDictValue" json:"string_dict_value,omitempty"`
+	FilesetListValue               []*FilesetEntry              `protobuf:"bytes,9,rep,name=fileset_list_value,json=filesetListValue" json:"fileset_list_value,omitempty"`
+	LabelListDictValue             []*LabelListDictEntry        `protobuf:"bytes,10,rep,name=label_list_dict_value,json=labelListDictValue" json:"label_list_dict_value,omitempty"`
+	StringListDictValue            []*StringListDictEntry       `protobuf:"bytes,11,rep,name=string_list_dict_value,json=stringListDictValue" json:"string_list_dict_value,omitempty"`
+	IntListValue                   []int32                      `protobuf:"varint,13,rep,name=int_list_value,json=intListValue" json:"int_list_value,omitempty"`
+	LabelDictUnaryValue            []*LabelDictUnaryEntry       `protobuf:"bytes,15,rep,name=label_dict_unary_value,json=labelDictUnaryValue" json:"label_dict_unary_value,omitempty"`
+	LabelKeyedStringDictValue      []*LabelKeyedStringDictEntry `protobuf:"bytes,17,rep,name=label_keyed_string_dict_value,json=labelKeyedStringDictValue" json:"label_keyed_string_dict_value,omitempty"`
+	DEPRECATEDStringDictUnaryValue [][]byte                     `protobuf:"bytes,14,rep,name=DEPRECATED_string_dict_unary_value,json=DEPRECATEDStringDictUnaryValue" json:"DEPRECATED_string_dict_unary_value,omitempty"`
 }
-func (m *AttributeDefinition) XXX_Size() int {
-	return xxx_messageInfo_AttributeDefinition.Size(m)
+
+func (x *Attribute_SelectorEntry) Reset() {
+	*x = Attribute_SelectorEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *AttributeDefinition) XXX_DiscardUnknown() {
-	xxx_messageInfo_AttributeDefinition.DiscardUnknown(m)
+
+func (x *Attribute_SelectorEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_AttributeDefinition proto.InternalMessageInfo
+func (*Attribute_SelectorEntry) ProtoMessage() {}
 
-func (m *AttributeDefinition) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (x *Attribute_SelectorEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attribute_SelectorEntry.ProtoReflect.Descriptor instead.
+func (*Attribute_SelectorEntry) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{7, 0}
 }
 
-func (m *AttributeDefinition) GetType() Attribute_Discriminator {
-	if m != nil && m.Type != nil {
-		return *m.Type
+func (x *Attribute_SelectorEntry) GetLabel() string {
+	if x != nil && x.Label != nil {
+		return *x.Label
 	}
-	return Attribute_INTEGER
+	return ""
 }
 
-func (m *AttributeDefinition) GetMandatory() bool {
-	if m != nil && m.Mandatory != nil {
-		return *m.Mandatory
+func (x *Attribute_SelectorEntry) GetIsDefaultValue() bool {
+	if x != nil && x.IsDefaultValue != nil {
+		return *x.IsDefaultValue
 	}
 	return false
 }
 
-func (m *AttributeDefinition) GetAllowedRuleClasses() *AllowedRuleClassInfo {
-	if m != nil {
-		return m.AllowedRuleClasses
+func (x *Attribute_SelectorEntry) GetIntValue() int32 {
+	if x != nil && x.IntValue != nil {
+		return *x.IntValue
 	}
-	return nil
+	return 0
 }
 
-func (m *AttributeDefinition) GetDocumentation() string {
-	if m != nil && m.Documentation != nil {
-		return *m.Documentation
+func (x *Attribute_SelectorEntry) GetStringValue() string {
+	if x != nil && x.StringValue != nil {
+		return *x.StringValue
 	}
 	return ""
 }
 
-func (m *AttributeDefinition) GetAllowEmpty() bool {
-	if m != nil && m.AllowEmpty != nil {
-		return *m.AllowEmpty
+func (x *Attribute_SelectorEntry) GetBooleanValue() bool {
+	if x != nil && x.BooleanValue != nil {
+		return *x.BooleanValue
 	}
 	return false
 }
 
-func (m *AttributeDefinition) GetAllowSingleFile() bool {
-	if m != nil && m.AllowSingleFile != nil {
-		return *m.AllowSingleFile
+func (x *Attribute_SelectorEntry) GetTristateValue() Attribute_Tristate {
+	if x != nil && x.TristateValue != nil {
+		return *x.TristateValue
 	}
-	return false
+	return Attribute_NO
 }
 
-func (m *AttributeDefinition) GetDefault() *AttributeValue {
-	if m != nil {
-		return m.Default
+func (x *Attribute_SelectorEntry) GetStringListValue() []string {
+	if x != nil {
+		return x.StringListValue
 	}
 	return nil
 }
 
-func (m *AttributeDefinition) GetExecutable() bool {
-	if m != nil && m.Executable != nil {
-		return *m.Executable
+func (x *Attribute_SelectorEntry) GetLicense() *License {
+	if x != nil {
+		return x.License
 	}
-	return false
+	return nil
 }
 
-func (m *AttributeDefinition) GetConfigurable() bool {
-	if m != nil && m.Configurable != nil {
-		return *m.Configurable
+func (x *Attribute_SelectorEntry) GetStringDictValue() []*StringDictEntry {
+	if x != nil {
+		return x.StringDictValue
 	}
-	return false
+	return nil
 }
 
-func (m *AttributeDefinition) GetNodep() bool {
-	if m != nil && m.Nodep != nil {
-		return *m.Nodep
+func (x *Attribute_SelectorEntry) GetFilesetListValue() []*FilesetEntry {
+	if x != nil {
+		return x.FilesetListValue
 	}
-	return false
+	return nil
 }
 
-func (m *AttributeDefinition) GetCfgIsHost() bool {
-	if m != nil && m.CfgIsHost != nil {
-		return *m.CfgIsHost
+func (x *Attribute_SelectorEntry) GetLabelListDictValue() []*LabelListDictEntry {
+	if x != nil {
+		return x.LabelListDictValue
 	}
-	return false
-}
-
-type AttributeValue struct {
-	Int                  *int32                      `protobuf:"varint,1,opt,name=int" json:"int,omitempty"`
-	String_              *string                     `protobuf:"bytes,2,opt,name=string" json:"string,omitempty"`
-	Bool                 *bool                       `protobuf:"varint,3,opt,name=bool" json:"bool,omitempty"`
-	List                 []*AttributeValue           `protobuf:"bytes,4,rep,name=list" json:"list,omitempty"`
-	Dict                 []*AttributeValue_DictEntry `protobuf:"bytes,5,rep,name=dict" json:"dict,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
-}
-
-func (m *AttributeValue) Reset()         { *m = AttributeValue{} }
-func (m *AttributeValue) String() string { return proto.CompactTextString(m) }
-func (*AttributeValue) ProtoMessage()    {}
-func (*AttributeValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{18}
-}
-
-func (m *AttributeValue) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AttributeValue.Unmarshal(m, b)
-}
-func (m *AttributeValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AttributeValue.Marshal(b, m, deterministic)
-}
-func (m *AttributeValue) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AttributeValue.Merge(m, src)
-}
-func (m *AttributeValue) XXX_Size() int {
-	return xxx_messageInfo_AttributeValue.Size(m)
-}
-func (m *AttributeValue) XXX_DiscardUnknown() {
-	xxx_messageInfo_AttributeValue.DiscardUnknown(m)
+	return nil
 }
 
-var xxx_messageInfo_AttributeValue proto.InternalMessageInfo
-
-func (m *AttributeValue) GetInt() int32 {
-	if m != nil && m.Int != nil {
-		return *m.Int
+func (x *Attribute_SelectorEntry) GetStringListDictValue() []*StringListDictEntry {
+	if x != nil {
+		return x.StringListDictValue
 	}
-	return 0
+	return nil
 }
 
-func (m *AttributeValue) GetString_() string {
-	if m != nil && m.String_ != nil {
-		return *m.String_
+func (x *Attribute_SelectorEntry) GetIntListValue() []int32 {
+	if x != nil {
+		return x.IntListValue
 	}
-	return ""
+	return nil
 }
 
-func (m *AttributeValue) GetBool() bool {
-	if m != nil && m.Bool != nil {
-		return *m.Bool
+func (x *Attribute_SelectorEntry) GetLabelDictUnaryValue() []*LabelDictUnaryEntry {
+	if x != nil {
+		return x.LabelDictUnaryValue
 	}
-	return false
+	return nil
 }
 
-func (m *AttributeValue) GetList() []*AttributeValue {
-	if m != nil {
-		return m.List
+func (x *Attribute_SelectorEntry) GetLabelKeyedStringDictValue() []*LabelKeyedStringDictEntry {
+	if x != nil {
+		return x.LabelKeyedStringDictValue
 	}
 	return nil
 }
 
-func (m *AttributeValue) GetDict() []*AttributeValue_DictEntry {
-	if m != nil {
-		return m.Dict
+func (x *Attribute_SelectorEntry) GetDEPRECATEDStringDictUnaryValue() [][]byte {
+	if x != nil {
+		return x.DEPRECATEDStringDictUnaryValue
 	}
 	return nil
 }
 
-type AttributeValue_DictEntry struct {
-	Key                  *string         `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
-	Value                *AttributeValue `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
-}
+type Attribute_Selector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *AttributeValue_DictEntry) Reset()         { *m = AttributeValue_DictEntry{} }
-func (m *AttributeValue_DictEntry) String() string { return proto.CompactTextString(m) }
-func (*AttributeValue_DictEntry) ProtoMessage()    {}
-func (*AttributeValue_DictEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{18, 0}
+	Entries         []*Attribute_SelectorEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	HasDefaultValue *bool                      `protobuf:"varint,2,opt,name=has_default_value,json=hasDefaultValue" json:"has_default_value,omitempty"`
+	NoMatchError    *string                    `protobuf:"bytes,3,opt,name=no_match_error,json=noMatchError" json:"no_match_error,omitempty"`
 }
 
-func (m *AttributeValue_DictEntry) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AttributeValue_DictEntry.Unmarshal(m, b)
-}
-func (m *AttributeValue_DictEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AttributeValue_DictEntry.Marshal(b, m, deterministic)
-}
-func (m *AttributeValue_DictEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AttributeValue_DictEntry.Merge(m, src)
-}
-func (m *AttributeValue_DictEntry) XXX_Size() int {
-	return xxx_messageInfo_AttributeValue_DictEntry.Size(m)
+func (x *Attribute_Selector) Reset() {
+	*x = Attribute_Selector{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *AttributeValue_DictEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_AttributeValue_DictEntry.DiscardUnknown(m)
+
+func (x *Attribute_Selector) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_AttributeValue_DictEntry proto.InternalMessageInfo
+func (*Attribute_Selector) ProtoMessage() {}
 
-func (m *AttributeValue_DictEntry) GetKey() string {
-	if m != nil && m.Key != nil {
-		return *m.Key
+func (x *Attribute_Selector) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attribute_Selector.ProtoReflect.Descriptor instead.
+func (*Attribute_Selector) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{7, 1}
 }
 
-func (m *AttributeValue_DictEntry) GetValue() *AttributeValue {
-	if m != nil {
-		return m.Value
+func (x *Attribute_Selector) GetEntries() []*Attribute_SelectorEntry {
+	if x != nil {
+		return x.Entries
 	}
 	return nil
 }
 
-type RuleDefinition struct {
-	Name                 *string                `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
-	Attribute            []*AttributeDefinition `protobuf:"bytes,2,rep,name=attribute" json:"attribute,omitempty"`
-	Documentation        *string                `protobuf:"bytes,3,opt,name=documentation" json:"documentation,omitempty"`
-	Label                *string                `protobuf:"bytes,4,opt,name=label" json:"label,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
-}
-
-func (m *RuleDefinition) Reset()         { *m = RuleDefinition{} }
-func (m *RuleDefinition) String() string { return proto.CompactTextString(m) }
-func (*RuleDefinition) ProtoMessage()    {}
-func (*RuleDefinition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{19}
+func (x *Attribute_Selector) GetHasDefaultValue() bool {
+	if x != nil && x.HasDefaultValue != nil {
+		return *x.HasDefaultValue
+	}
+	return false
 }
 
-func (m *RuleDefinition) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RuleDefinition.Unmarshal(m, b)
-}
-func (m *RuleDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RuleDefinition.Marshal(b, m, deterministic)
+func (x *Attribute_Selector) GetNoMatchError() string {
+	if x != nil && x.NoMatchError != nil {
+		return *x.NoMatchError
+	}
+	return ""
 }
-func (m *RuleDefinition) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RuleDefinition.Merge(m, src)
+
+type Attribute_SelectorList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type     *Attribute_Discriminator `protobuf:"varint,1,opt,name=type,enum=blaze_query.Attribute_Discriminator" json:"type,omitempty"`
+	Elements []*Attribute_Selector    `protobuf:"bytes,2,rep,name=elements" json:"elements,omitempty"`
 }
-func (m *RuleDefinition) XXX_Size() int {
-	return xxx_messageInfo_RuleDefinition.Size(m)
+
+func (x *Attribute_SelectorList) Reset() {
+	*x = Attribute_SelectorList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *RuleDefinition) XXX_DiscardUnknown() {
-	xxx_messageInfo_RuleDefinition.DiscardUnknown(m)
+
+func (x *Attribute_SelectorList) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_RuleDefinition proto.InternalMessageInfo
+func (*Attribute_SelectorList) ProtoMessage() {}
 
-func (m *RuleDefinition) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (x *Attribute_SelectorList) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (m *RuleDefinition) GetAttribute() []*AttributeDefinition {
-	if m != nil {
-		return m.Attribute
-	}
-	return nil
+// Deprecated: Use Attribute_SelectorList.ProtoReflect.Descriptor instead.
+func (*Attribute_SelectorList) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{7, 2}
 }
 
-func (m *RuleDefinition) GetDocumentation() string {
-	if m != nil && m.Documentation != nil {
-		return *m.Documentation
+func (x *Attribute_SelectorList) GetType() Attribute_Discriminator {
+	if x != nil && x.Type != nil {
+		return *x.Type
 	}
-	return ""
+	return Attribute_INTEGER
 }
 
-func (m *RuleDefinition) GetLabel() string {
-	if m != nil && m.Label != nil {
-		return *m.Label
+func (x *Attribute_SelectorList) GetElements() []*Attribute_Selector {
+	if x != nil {
+		return x.Elements
 	}
-	return ""
+	return nil
 }
 
-type BuildLanguage struct {
-	Rule                 []*RuleDefinition `protobuf:"bytes,1,rep,name=rule" json:"rule,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
-}
+type AttributeValue_DictEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *BuildLanguage) Reset()         { *m = BuildLanguage{} }
-func (m *BuildLanguage) String() string { return proto.CompactTextString(m) }
-func (*BuildLanguage) ProtoMessage()    {}
-func (*BuildLanguage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f6a1462da7e80e5c, []int{20}
+	Key   *string         `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
+	Value *AttributeValue `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
 }
 
-func (m *BuildLanguage) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BuildLanguage.Unmarshal(m, b)
-}
-func (m *BuildLanguage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BuildLanguage.Marshal(b, m, deterministic)
+func (x *AttributeValue_DictEntry) Reset() {
+	*x = AttributeValue_DictEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gitops_blaze_query_build_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *BuildLanguage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BuildLanguage.Merge(m, src)
+
+func (x *AttributeValue_DictEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *BuildLanguage) XXX_Size() int {
-	return xxx_messageInfo_BuildLanguage.Size(m)
+
+func (*AttributeValue_DictEntry) ProtoMessage() {}
+
+func (x *AttributeValue_DictEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_gitops_blaze_query_build_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (m *BuildLanguage) XXX_DiscardUnknown() {
-	xxx_messageInfo_BuildLanguage.DiscardUnknown(m)
+
+// Deprecated: Use AttributeValue_DictEntry.ProtoReflect.Descriptor instead.
+func (*AttributeValue_DictEntry) Descriptor() ([]byte, []int) {
+	return file_gitops_blaze_query_build_proto_rawDescGZIP(), []int{18, 0}
 }
 
-var xxx_messageInfo_BuildLanguage proto.InternalMessageInfo
+func (x *AttributeValue_DictEntry) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
 
-func (m *BuildLanguage) GetRule() []*RuleDefinition {
-	if m != nil {
-		return m.Rule
+func (x *AttributeValue_DictEntry) GetValue() *AttributeValue {
+	if x != nil {
+		return x.Value
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("blaze_query.FilesetEntry_SymlinkBehavior", FilesetEntry_SymlinkBehavior_name, FilesetEntry_SymlinkBehavior_value)
-	proto.RegisterEnum("blaze_query.Attribute_Discriminator", Attribute_Discriminator_name, Attribute_Discriminator_value)
-	proto.RegisterEnum("blaze_query.Attribute_Tristate", Attribute_Tristate_name, Attribute_Tristate_value)
-	proto.RegisterEnum("blaze_query.Target_Discriminator", Target_Discriminator_name, Target_Discriminator_value)
-	proto.RegisterEnum("blaze_query.AllowedRuleClassInfo_AllowedRuleClasses", AllowedRuleClassInfo_AllowedRuleClasses_name, AllowedRuleClassInfo_AllowedRuleClasses_value)
-	proto.RegisterType((*License)(nil), "blaze_query.License")
-	proto.RegisterType((*StringDictEntry)(nil), "blaze_query.StringDictEntry")
-	proto.RegisterType((*LabelDictUnaryEntry)(nil), "blaze_query.LabelDictUnaryEntry")
-	proto.RegisterType((*LabelListDictEntry)(nil), "blaze_query.LabelListDictEntry")
-	proto.RegisterType((*LabelKeyedStringDictEntry)(nil), "blaze_query.LabelKeyedStringDictEntry")
-	proto.RegisterType((*StringListDictEntry)(nil), "blaze_query.StringListDictEntry")
-	proto.RegisterType((*FilesetEntry)(nil), "blaze_query.FilesetEntry")
-	proto.RegisterType((*Attribute)(nil), "blaze_query.Attribute")
-	proto.RegisterType((*Attribute_SelectorEntry)(nil), "blaze_query.Attribute.SelectorEntry")
-	proto.RegisterType((*Attribute_Selector)(nil), "blaze_query.Attribute.Selector")
-	proto.RegisterType((*Attribute_SelectorList)(nil), "blaze_query.Attribute.SelectorList")
-	proto.RegisterType((*Rule)(nil), "blaze_query.Rule")
-	proto.RegisterType((*RuleSummary)(nil), "blaze_query.RuleSummary")
-	proto.RegisterType((*PackageGroup)(nil), "blaze_query.PackageGroup")
-	proto.RegisterType((*EnvironmentGroup)(nil), "blaze_query.EnvironmentGroup")
-	proto.RegisterType((*SourceFile)(nil), "blaze_query.SourceFile")
-	proto.RegisterType((*GeneratedFile)(nil), "blaze_query.GeneratedFile")
-	proto.RegisterType((*Target)(nil), "blaze_query.Target")
-	proto.RegisterType((*QueryResult)(nil), "blaze_query.QueryResult")
-	proto.RegisterType((*AllowedRuleClassInfo)(nil), "blaze_query.AllowedRuleClassInfo")
-	proto.RegisterType((*AttributeDefinition)(nil), "blaze_query.AttributeDefinition")
-	proto.RegisterType((*AttributeValue)(nil), "blaze_query.AttributeValue")
-	proto.RegisterType((*AttributeValue_DictEntry)(nil), "blaze_query.AttributeValue.DictEntry")
-	proto.RegisterType((*RuleDefinition)(nil), "blaze_query.RuleDefinition")
-	proto.RegisterType((*BuildLanguage)(nil), "blaze_query.BuildLanguage")
-}
-
-func init() { proto.RegisterFile("gitops/blaze_query/build.proto", fileDescriptor_f6a1462da7e80e5c) }
-
-var fileDescriptor_f6a1462da7e80e5c = []byte{
-	// 2350 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0xdd, 0x72, 0x1b, 0x49,
-	0x15, 0x46, 0x7f, 0xb6, 0x74, 0xf4, 0x37, 0x6e, 0xcb, 0x8e, 0xe2, 0xfc, 0xac, 0xa2, 0x24, 0xac,
-	0x97, 0x4d, 0x29, 0x15, 0x93, 0xdd, 0xda, 0x5d, 0x20, 0x85, 0x2c, 0x4d, 0x1c, 0x39, 0x5a, 0xc9,
-	0xb4, 0xa4, 0xa5, 0xcc, 0xcd, 0xd4, 0x68, 0xd4, 0x92, 0xbb, 0x3c, 0x9e, 0x11, 0xd3, 0x33, 0x21,
-	0xe2, 0x96, 0xe2, 0x06, 0x28, 0x9e, 0x80, 0x2a, 0x78, 0x08, 0x1e, 0x80, 0x37, 0xe0, 0x9a, 0xe2,
-	0x4d, 0xb8, 0xa2, 0xfa, 0x67, 0xa4, 0x19, 0x5b, 0x4e, 0x9c, 0x05, 0x6e, 0xa8, 0xbd, 0x51, 0x4d,
-	0x7f, 0x7d, 0xce, 0xe9, 0x3e, 0xa7, 0xcf, 0x5f, 0xb7, 0xe0, 0xfe, 0x8c, 0xfa, 0xee, 0x9c, 0x3d,
-	0x1d, 0xdb, 0xe6, 0xaf, 0x89, 0xf1, 0xcb, 0x80, 0x78, 0x8b, 0xa7, 0xe3, 0x80, 0xda, 0x93, 0xc6,
-	0xdc, 0x73, 0x7d, 0x17, 0xe5, 0x23, 0x13, 0xf5, 0x63, 0xd8, 0xec, 0x52, 0x8b, 0x38, 0x8c, 0xa0,
-	0x07, 0x50, 0xb0, 0xe5, 0xa7, 0xe1, 0x2f, 0xe6, 0xa4, 0x9a, 0xa8, 0xa5, 0xf6, 0x73, 0x38, 0xaf,
-	0xb0, 0xe1, 0x62, 0x4e, 0xd0, 0x5d, 0xc8, 0x91, 0xb7, 0x16, 0x99, 0xfb, 0xd4, 0x75, 0xaa, 0x49,
-	0x31, 0xbf, 0x02, 0xea, 0x5f, 0x42, 0x79, 0xe0, 0x7b, 0xd4, 0x99, 0xb5, 0xa9, 0xe5, 0xeb, 0x8e,
-	0xef, 0x2d, 0x90, 0x06, 0xa9, 0x73, 0xb2, 0xa8, 0x26, 0x6a, 0xc9, 0xfd, 0x1c, 0xe6, 0x9f, 0xa8,
-	0x02, 0x99, 0x37, 0xa6, 0x1d, 0x90, 0x6a, 0x52, 0x60, 0x72, 0x50, 0xff, 0x09, 0x6c, 0x77, 0xcd,
-	0x31, 0xb1, 0x39, 0xe7, 0xc8, 0x31, 0xbd, 0xc5, 0x87, 0xb1, 0xff, 0x18, 0x90, 0x60, 0xef, 0x52,
-	0xe6, 0xdf, 0x70, 0xf1, 0xd4, 0x8a, 0xbb, 0x05, 0xb7, 0x05, 0xf7, 0x6b, 0xb2, 0x20, 0x93, 0xff,
-	0x40, 0x03, 0xc9, 0xfa, 0xed, 0xf6, 0xf0, 0xcf, 0x24, 0x14, 0x5e, 0x52, 0x9b, 0x30, 0xa2, 0x18,
-	0x77, 0x61, 0x83, 0xb9, 0x81, 0x67, 0x11, 0xc5, 0xab, 0x46, 0xe8, 0x87, 0xb0, 0x33, 0x21, 0xcc,
-	0xa7, 0x8e, 0xc9, 0x6d, 0x6e, 0x4c, 0xa8, 0x47, 0x2c, 0xdf, 0xf5, 0x16, 0x6a, 0x37, 0x95, 0xc8,
-	0x64, 0x3b, 0x9c, 0x43, 0x0f, 0xa1, 0x38, 0xe5, 0xc2, 0x8d, 0xb9, 0x47, 0x18, 0x71, 0xfc, 0xea,
-	0x66, 0x2d, 0xb1, 0x9f, 0xc5, 0x05, 0x01, 0x9e, 0x48, 0x0c, 0x21, 0x48, 0xf3, 0x71, 0x35, 0x25,
-	0xf6, 0x25, 0xbe, 0x51, 0x15, 0x36, 0xc9, 0x5b, 0xcb, 0x0e, 0x26, 0xa4, 0x9a, 0x16, 0x70, 0x38,
-	0x44, 0xbf, 0x00, 0x8d, 0x2d, 0x2e, 0x6c, 0xea, 0x9c, 0x1b, 0x63, 0x72, 0x66, 0xbe, 0xa1, 0xae,
-	0x57, 0xcd, 0xd4, 0x12, 0xfb, 0xa5, 0x83, 0x4f, 0x1a, 0x11, 0x07, 0x6b, 0x44, 0x95, 0x6a, 0x0c,
-	0x24, 0xc7, 0xa1, 0x62, 0xf8, 0x2a, 0xdd, 0xea, 0x9f, 0x9c, 0xe2, 0x32, 0x8b, 0xc3, 0xdc, 0x13,
-	0x99, 0xef, 0xd1, 0x39, 0xdf, 0xee, 0x94, 0xbe, 0xad, 0x6e, 0xd4, 0x12, 0xdc, 0x13, 0x05, 0x76,
-	0x22, 0xa0, 0xfa, 0x13, 0x28, 0x5f, 0x12, 0x86, 0xb2, 0x20, 0xc4, 0x69, 0x09, 0x54, 0x86, 0x7c,
-	0x5b, 0xc7, 0xfa, 0x4b, 0x1d, 0xeb, 0xbd, 0x96, 0xae, 0x25, 0xeb, 0x7f, 0xde, 0x85, 0x5c, 0xd3,
-	0xf7, 0x3d, 0x3a, 0x0e, 0x7c, 0xc2, 0x15, 0x75, 0xcc, 0x8b, 0xd0, 0xb0, 0xe2, 0x1b, 0x3d, 0x83,
-	0x0a, 0x79, 0x3b, 0xb7, 0xa9, 0x45, 0x7d, 0x7b, 0x61, 0xb0, 0x39, 0xb1, 0xe8, 0x94, 0x92, 0x49,
-	0xb5, 0x28, 0x0c, 0xb5, 0xbd, 0x9a, 0x1b, 0x84, 0x53, 0xfc, 0x20, 0x1d, 0x77, 0x42, 0xe6, 0xd5,
-	0x8a, 0xa0, 0x91, 0x03, 0xf4, 0x05, 0xa4, 0x45, 0xf4, 0xf0, 0xe3, 0x28, 0x1d, 0x3c, 0x8a, 0xd9,
-	0x62, 0xb9, 0x85, 0x46, 0x9b, 0x32, 0xcb, 0xa3, 0x17, 0xfc, 0xa0, 0x5c, 0x0f, 0x0b, 0x0e, 0x74,
-	0x07, 0x72, 0xd4, 0xf1, 0x0d, 0xe9, 0x1c, 0xa9, 0x5a, 0x62, 0x3f, 0x83, 0xb3, 0xd4, 0xf1, 0xbf,
-	0xe1, 0xe3, 0xd0, 0x24, 0xce, 0x4c, 0xcd, 0x67, 0x56, 0x26, 0x71, 0x66, 0x92, 0xe4, 0x21, 0x14,
-	0xc7, 0xae, 0x6b, 0x13, 0xd3, 0x51, 0x34, 0x25, 0x79, 0xc8, 0x0a, 0x94, 0x44, 0x2f, 0xa1, 0xe4,
-	0x7b, 0x94, 0xf9, 0xa6, 0x4f, 0x14, 0x55, 0x59, 0x1c, 0xda, 0x47, 0xd7, 0x6c, 0x74, 0xa8, 0x88,
-	0x71, 0x31, 0x64, 0x93, 0x72, 0x7e, 0x00, 0x5b, 0x6a, 0x3f, 0x36, 0x65, 0xe1, 0xa6, 0x37, 0x84,
-	0x8b, 0x94, 0xd9, 0x32, 0x0e, 0x24, 0x6d, 0x03, 0x36, 0x55, 0x12, 0x11, 0x7e, 0x97, 0x3f, 0xa8,
-	0xc4, 0x16, 0x53, 0xf9, 0x07, 0x87, 0x44, 0xe8, 0xd5, 0x52, 0xf6, 0x84, 0x5a, 0xa1, 0xec, 0x6c,
-	0x2d, 0xb5, 0x9f, 0x3f, 0xb8, 0x1b, 0xe3, 0xbc, 0x14, 0xab, 0xe1, 0xca, 0x1c, 0x90, 0x2b, 0x1f,
-	0x01, 0x9a, 0x4a, 0xff, 0x8b, 0x6e, 0x33, 0x27, 0x44, 0xdd, 0xbe, 0xd6, 0x4d, 0xb1, 0xa6, 0x98,
-	0x56, 0x2a, 0x60, 0xd8, 0xb1, 0x79, 0x8a, 0x90, 0x62, 0x22, 0xdb, 0x02, 0x21, 0x2b, 0x6e, 0xbd,
-	0xab, 0xa9, 0x08, 0x23, 0x3b, 0x8a, 0x49, 0x99, 0x23, 0xd8, 0x8d, 0x9a, 0x30, 0x22, 0x34, 0x2f,
-	0x84, 0xd6, 0xd6, 0xe8, 0x1a, 0x97, 0xba, 0xcd, 0x62, 0xa0, 0x14, 0xfb, 0x08, 0x4a, 0xdc, 0x8d,
-	0x22, 0xfa, 0x6e, 0xd5, 0x52, 0xfb, 0x19, 0x5c, 0xa0, 0x4e, 0x44, 0xa1, 0x11, 0xec, 0x4a, 0x85,
-	0xc4, 0xb2, 0x01, 0x4f, 0xb9, 0x8a, 0x7a, 0x7b, 0xcd, 0xe2, 0x6b, 0x72, 0x33, 0xde, 0xb6, 0x63,
-	0xa0, 0x14, 0x7b, 0x06, 0xf7, 0xa4, 0xd8, 0x73, 0x9e, 0x4b, 0x8d, 0xab, 0xc7, 0xb8, 0x2b, 0xa4,
-	0x7f, 0xff, 0xaa, 0xf4, 0x75, 0xc9, 0x17, 0xdf, 0xb6, 0xd7, 0x4c, 0xc9, 0x95, 0x5e, 0x41, 0x91,
-	0x11, 0x5b, 0xe4, 0x37, 0xa1, 0x6b, 0x75, 0x47, 0xb8, 0xd6, 0xc3, 0x6b, 0xfc, 0x78, 0xa0, 0x68,
-	0xb9, 0x05, 0x70, 0x81, 0x45, 0x46, 0xe8, 0x18, 0xea, 0x6d, 0xfd, 0x04, 0xeb, 0xad, 0xe6, 0x50,
-	0x6f, 0xc7, 0xb6, 0x1c, 0x35, 0x0b, 0xaa, 0xa5, 0xf6, 0x0b, 0xf8, 0xfe, 0x8a, 0x72, 0xb5, 0xa1,
-	0x95, 0xfe, 0x7b, 0x7f, 0xdf, 0x84, 0x62, 0xb8, 0x94, 0xcc, 0xe3, 0x15, 0xc8, 0x08, 0x25, 0xaa,
-	0x09, 0x11, 0xb1, 0x72, 0x80, 0xf6, 0x41, 0xa3, 0xcc, 0x98, 0x90, 0xa9, 0x19, 0xd8, 0xa1, 0x69,
-	0x34, 0x11, 0xae, 0x25, 0xca, 0xda, 0x12, 0x96, 0x7a, 0xc6, 0xb2, 0x42, 0xf2, 0x3d, 0x59, 0x21,
-	0x75, 0x83, 0xac, 0x90, 0xbe, 0x51, 0x56, 0xc8, 0x7c, 0x97, 0x15, 0xfe, 0xcf, 0xb3, 0x42, 0xf1,
-	0x83, 0xb2, 0x42, 0xf9, 0x7f, 0x9a, 0x15, 0xb6, 0xfe, 0x5b, 0x59, 0xe1, 0x66, 0xb1, 0x5c, 0xba,
-	0x49, 0x2c, 0x1f, 0xa7, 0xb3, 0x05, 0xad, 0xb8, 0xf7, 0xa7, 0x04, 0x64, 0xc3, 0x88, 0x46, 0x2f,
-	0x60, 0x93, 0x38, 0xbe, 0x47, 0x09, 0x13, 0xdd, 0x71, 0xfe, 0xda, 0xfa, 0x1e, 0xcb, 0x01, 0x38,
-	0x64, 0xe2, 0xf1, 0x71, 0x66, 0x5e, 0x8e, 0xfb, 0xa4, 0x08, 0xc8, 0xf2, 0x99, 0x19, 0x0f, 0xfc,
-	0x47, 0x50, 0x72, 0x5c, 0xe3, 0xc2, 0xf4, 0xad, 0x33, 0x83, 0x78, 0x9e, 0xeb, 0xa9, 0xe8, 0x2e,
-	0x38, 0xee, 0xd7, 0x1c, 0xd4, 0x39, 0xb6, 0xf7, 0xdb, 0x04, 0x14, 0xa2, 0xb9, 0x6d, 0xd9, 0x7f,
-	0x24, 0x44, 0x00, 0x7f, 0x48, 0xff, 0xf1, 0x23, 0xc8, 0x12, 0x9b, 0x5c, 0x10, 0xc7, 0x67, 0xa2,
-	0x37, 0xcd, 0x5f, 0x1b, 0xfe, 0xe1, 0x82, 0x78, 0xc9, 0x50, 0xff, 0x5d, 0x0a, 0x8a, 0x31, 0xa1,
-	0x28, 0x0f, 0x9b, 0x9d, 0xde, 0x50, 0x3f, 0xd2, 0xb1, 0x96, 0x40, 0x00, 0x1b, 0x83, 0x21, 0xee,
-	0xf4, 0x8e, 0xb4, 0x24, 0xca, 0x41, 0xa6, 0xdb, 0x3c, 0xd4, 0xbb, 0x5a, 0x8a, 0xc3, 0xfd, 0xd1,
-	0xf0, 0x64, 0x34, 0xd4, 0xd2, 0xbc, 0x69, 0x93, 0x24, 0x46, 0xb7, 0x33, 0x18, 0x6a, 0x19, 0x54,
-	0x02, 0x10, 0x74, 0x72, 0xbc, 0xc1, 0x09, 0x24, 0xb1, 0x04, 0x36, 0x51, 0x05, 0xb4, 0x76, 0x87,
-	0xf3, 0x1c, 0x8e, 0x86, 0x9d, 0x7e, 0xcf, 0x18, 0xe8, 0x43, 0x2d, 0xcb, 0xd7, 0xed, 0x76, 0x5a,
-	0x7a, 0x6f, 0xa0, 0x6b, 0xb9, 0x88, 0xd0, 0x76, 0xa7, 0x35, 0xd4, 0x00, 0xed, 0x02, 0x7a, 0xd9,
-	0xe9, 0xea, 0x03, 0x7d, 0x68, 0xe8, 0xbd, 0x21, 0x3e, 0x95, 0xb2, 0xf2, 0x68, 0x1b, 0xca, 0xab,
-	0xc5, 0x24, 0x71, 0x81, 0x2f, 0x10, 0xd9, 0x92, 0x44, 0x8b, 0x7c, 0x81, 0xc3, 0x7e, 0xbf, 0xab,
-	0x37, 0x7b, 0x5a, 0x09, 0x15, 0x20, 0x3b, 0xc4, 0x9d, 0xc1, 0xb0, 0x39, 0xd4, 0xb5, 0x32, 0xd2,
-	0xa0, 0xa0, 0x74, 0x96, 0x72, 0x35, 0x4e, 0x3c, 0xea, 0xbd, 0xee, 0xf5, 0x7f, 0xde, 0xd3, 0x10,
-	0x97, 0x27, 0x17, 0xe1, 0x92, 0x8c, 0x51, 0xaf, 0x89, 0x4f, 0xb5, 0x6d, 0xb4, 0x05, 0xc5, 0x81,
-	0xde, 0xd5, 0x5b, 0xc3, 0xbe, 0xe2, 0xaa, 0xa0, 0x3b, 0x70, 0x4b, 0x12, 0xbe, 0xd6, 0x4f, 0xf5,
-	0xb6, 0x11, 0x55, 0x61, 0x07, 0xd5, 0xe0, 0x6e, 0xc4, 0xc7, 0x23, 0x73, 0x4a, 0xe2, 0x56, 0xfd,
-	0x31, 0x64, 0xc3, 0x0c, 0x8d, 0x36, 0x20, 0xd9, 0xeb, 0x6b, 0xdf, 0x43, 0x9b, 0x90, 0x3a, 0xd5,
-	0x07, 0x5a, 0x82, 0xb7, 0xc9, 0xcd, 0xd1, 0xb0, 0xaf, 0x25, 0xa5, 0x83, 0x1f, 0xa7, 0xb3, 0x9a,
-	0xb6, 0x55, 0xff, 0x63, 0x0a, 0xd2, 0x38, 0xb0, 0xd7, 0x37, 0xc7, 0xf7, 0x00, 0xbc, 0xc0, 0x26,
-	0x86, 0x65, 0x9b, 0x8c, 0xa9, 0x8b, 0x46, 0x8e, 0x23, 0x2d, 0x0e, 0xa0, 0x3d, 0xc8, 0xda, 0xae,
-	0x25, 0xae, 0x1c, 0xca, 0x47, 0x97, 0x63, 0xf4, 0x1c, 0x72, 0x66, 0xe8, 0x37, 0xe2, 0x0a, 0x91,
-	0x3f, 0xd8, 0x5d, 0xef, 0x55, 0x78, 0x45, 0xb8, 0x5c, 0x90, 0x3a, 0xf3, 0xc0, 0xaf, 0x66, 0xe4,
-	0x45, 0x93, 0x23, 0x1d, 0x0e, 0xa0, 0x8f, 0x20, 0x2f, 0xa6, 0xdd, 0xc0, 0xe7, 0xf3, 0xb2, 0xc0,
-	0x08, 0x8e, 0xbe, 0x40, 0xd0, 0xc7, 0x50, 0x0e, 0x63, 0x8c, 0x11, 0xdf, 0xa7, 0xce, 0xac, 0xba,
-	0x29, 0x88, 0x4a, 0x0a, 0x1e, 0x48, 0x94, 0x07, 0xe4, 0x3c, 0x18, 0xdb, 0xd4, 0x32, 0xc6, 0x8b,
-	0x30, 0x2c, 0xab, 0x39, 0x19, 0x90, 0x72, 0xe2, 0x70, 0xa1, 0xa2, 0x92, 0x6f, 0x8a, 0x32, 0x83,
-	0x9d, 0x2f, 0x6c, 0xd3, 0x3b, 0xaf, 0x82, 0x20, 0xca, 0x51, 0x36, 0x90, 0x00, 0x6a, 0xc2, 0x3d,
-	0x35, 0x67, 0x10, 0xe7, 0x0d, 0xf5, 0x5c, 0x87, 0x47, 0x86, 0x71, 0x66, 0xb2, 0x33, 0xc3, 0x72,
-	0x27, 0xa4, 0x5a, 0x10, 0xa6, 0xd9, 0x53, 0x44, 0xfa, 0x8a, 0xe6, 0x95, 0xc9, 0xce, 0x5a, 0xee,
-	0x84, 0x67, 0x9c, 0xac, 0x96, 0x3b, 0x4e, 0x67, 0xf3, 0x5a, 0xa1, 0xfe, 0x9b, 0x04, 0xe4, 0xf9,
-	0x81, 0x0c, 0x82, 0x8b, 0x0b, 0xd3, 0x5b, 0xa0, 0xc7, 0x90, 0xe6, 0x0a, 0x8a, 0x73, 0xc9, 0x1f,
-	0x6c, 0xc5, 0x6c, 0xc8, 0xe9, 0xb0, 0x98, 0x46, 0xcf, 0x00, 0x26, 0x64, 0x4e, 0x9c, 0x09, 0x71,
-	0xac, 0x85, 0x0a, 0xe3, 0x35, 0xc4, 0x11, 0xa2, 0x77, 0x1d, 0x5f, 0xfd, 0xf7, 0x09, 0x28, 0x9c,
-	0x98, 0xd6, 0xb9, 0x39, 0x23, 0x47, 0x9e, 0x1b, 0xcc, 0xd7, 0xba, 0xc7, 0xa7, 0xb0, 0x65, 0xb9,
-	0x8e, 0x6f, 0x52, 0x87, 0x4c, 0x8c, 0xb9, 0xa4, 0x56, 0xb7, 0x5b, 0x6d, 0x39, 0xa1, 0xa4, 0xa0,
-	0xe7, 0xb0, 0x4b, 0x1d, 0x71, 0x85, 0x5c, 0xd2, 0x1a, 0x33, 0x2e, 0x5a, 0xdd, 0x3b, 0x2b, 0xe1,
-	0x6c, 0x74, 0xd9, 0xe3, 0x74, 0x36, 0xad, 0x65, 0xea, 0x63, 0xd0, 0x22, 0x66, 0xbb, 0x7e, 0x43,
-	0x35, 0xc8, 0x47, 0x8e, 0x40, 0x6d, 0x25, 0x0a, 0xf1, 0x7b, 0x6d, 0x78, 0xda, 0x72, 0xd9, 0x70,
-	0x58, 0xff, 0x6b, 0x12, 0x60, 0x20, 0xae, 0xda, 0xbc, 0xf8, 0xaf, 0x15, 0x1f, 0x35, 0x58, 0xf2,
-	0x92, 0xbf, 0xdf, 0x07, 0x60, 0xc1, 0x58, 0xe9, 0xa0, 0x64, 0x47, 0x10, 0xde, 0x8e, 0xc5, 0xb5,
-	0x96, 0xd7, 0xea, 0xc2, 0x3c, 0x6a, 0xe4, 0x4f, 0x40, 0x7b, 0x43, 0x19, 0x1d, 0x53, 0x9b, 0xfa,
-	0x0b, 0x43, 0xb6, 0x8f, 0x32, 0x08, 0xca, 0x2b, 0x5c, 0xd4, 0x47, 0xae, 0xc8, 0x94, 0x98, 0x7e,
-	0xe0, 0x85, 0x7d, 0x56, 0x38, 0x8c, 0xf6, 0x57, 0xd9, 0x9b, 0xf4, 0x57, 0x9f, 0xc3, 0xad, 0x70,
-	0x67, 0xea, 0xd0, 0x98, 0xac, 0x3b, 0x4c, 0x05, 0xc4, 0x8e, 0x9a, 0x6e, 0xa9, 0x59, 0x51, 0x80,
-	0xd8, 0x71, 0x3a, 0xbb, 0xa9, 0x65, 0xeb, 0x67, 0x50, 0x3c, 0x22, 0x0e, 0xf1, 0x4c, 0x9f, 0x4c,
-	0xae, 0x35, 0xdc, 0xc7, 0x50, 0x9e, 0x49, 0x22, 0x5e, 0x96, 0x85, 0x3b, 0xcb, 0x64, 0x52, 0x5a,
-	0xc1, 0x22, 0x09, 0xbd, 0xcb, 0x25, 0xff, 0x91, 0x82, 0x8d, 0xa1, 0xe9, 0xcd, 0x88, 0x8f, 0x3e,
-	0x5b, 0xd6, 0x3a, 0x7e, 0xd7, 0x7e, 0x10, 0xd3, 0x4f, 0x92, 0xac, 0x2d, 0x74, 0x61, 0x28, 0x25,
-	0x85, 0x59, 0xae, 0x0d, 0xa5, 0x2f, 0x20, 0x2f, 0xdf, 0x5c, 0x0c, 0xf5, 0x2c, 0xc2, 0xa9, 0x6f,
-	0xc5, 0xdb, 0xaf, 0xa5, 0xa3, 0x60, 0x60, 0x2b, 0xa7, 0x69, 0x42, 0xa8, 0x10, 0x99, 0x48, 0xe6,
-	0xb4, 0x60, 0xde, 0x8b, 0x31, 0xc7, 0xec, 0x85, 0x8b, 0xb3, 0x98, 0xf9, 0x5e, 0x5c, 0xf6, 0x93,
-	0x8c, 0x90, 0x10, 0x6f, 0x4f, 0xa3, 0x21, 0x72, 0xc9, 0x85, 0x8e, 0x61, 0x2b, 0x9a, 0x85, 0xa4,
-	0x8c, 0x0d, 0x21, 0xe3, 0x5e, 0x4c, 0xc6, 0xe5, 0x80, 0xc2, 0x1a, 0xb9, 0x84, 0xf0, 0xb3, 0x8d,
-	0x97, 0xf6, 0x2c, 0xa4, 0xf1, 0xa8, 0xab, 0xcb, 0x97, 0x96, 0x41, 0x7f, 0x84, 0x5b, 0xba, 0xc1,
-	0xab, 0xaa, 0x96, 0x44, 0x08, 0x4a, 0x47, 0x7a, 0x4f, 0xc7, 0xa2, 0x36, 0x09, 0x2c, 0xc5, 0x0b,
-	0xdc, 0x49, 0xb3, 0xf5, 0xba, 0x79, 0xa4, 0x1b, 0x47, 0xb8, 0x3f, 0x3a, 0xd1, 0xd2, 0x68, 0x07,
-	0xb6, 0xf4, 0xde, 0x37, 0x1d, 0xdc, 0xef, 0x7d, 0xad, 0xf7, 0x86, 0x0a, 0xce, 0xd4, 0xbf, 0x82,
-	0xfc, 0xcf, 0xf8, 0xae, 0x30, 0x61, 0x3c, 0xe3, 0x7e, 0x0a, 0x1b, 0xbe, 0x38, 0x46, 0xd5, 0x6d,
-	0x6d, 0xaf, 0x39, 0x61, 0xac, 0x48, 0xea, 0x7f, 0x4b, 0x40, 0xa5, 0x69, 0xdb, 0xee, 0xaf, 0xc8,
-	0x04, 0x87, 0xa5, 0xa9, 0xe3, 0x4c, 0x5d, 0xd4, 0x85, 0x8d, 0xb9, 0x6b, 0x53, 0x6b, 0xa1, 0xfc,
-	0xe4, 0x79, 0xbc, 0xfe, 0xac, 0x61, 0xb9, 0x02, 0x12, 0x86, 0x95, 0x0c, 0xf4, 0x04, 0x90, 0x29,
-	0x67, 0x8d, 0x58, 0x4d, 0x14, 0xd9, 0xce, 0xbc, 0xc4, 0x57, 0x7f, 0x02, 0xe8, 0xaa, 0x2c, 0x5e,
-	0x8b, 0x9b, 0xbd, 0x53, 0x2d, 0x81, 0x8a, 0x90, 0x1b, 0x9c, 0xe8, 0xad, 0xce, 0xcb, 0x8e, 0xde,
-	0xd6, 0x92, 0xf5, 0x7f, 0xa5, 0x60, 0x7b, 0x59, 0x0f, 0xdb, 0x64, 0x4a, 0x1d, 0x2a, 0x92, 0xca,
-	0xba, 0x58, 0xfa, 0xf6, 0xef, 0x4c, 0x77, 0x21, 0x77, 0x61, 0x3a, 0x13, 0x53, 0xbc, 0x1a, 0xa6,
-	0x64, 0x19, 0x5b, 0x02, 0x68, 0x00, 0x95, 0xab, 0xfa, 0x11, 0xa6, 0x3c, 0xf8, 0xc1, 0x7b, 0x6d,
-	0x87, 0x91, 0x79, 0x55, 0xe1, 0x47, 0x50, 0x9c, 0xb8, 0x56, 0xc0, 0x5d, 0x4a, 0x06, 0xb5, 0x7c,
-	0xbe, 0x8a, 0x83, 0xbc, 0xac, 0x0b, 0x5e, 0x83, 0x5c, 0xcc, 0xfd, 0x85, 0xf0, 0xd6, 0x2c, 0x06,
-	0x01, 0xe9, 0x1c, 0xe1, 0xd5, 0x5a, 0x12, 0x30, 0xea, 0xcc, 0x6c, 0x15, 0x97, 0xf2, 0x29, 0xb3,
-	0x2c, 0x26, 0x06, 0x02, 0x17, 0x01, 0xf4, 0xd9, 0x2a, 0xc3, 0xe7, 0xc4, 0xd6, 0xef, 0xac, 0x37,
-	0x91, 0x68, 0xb6, 0x97, 0xe9, 0x9f, 0xe7, 0x6f, 0xf2, 0x96, 0x58, 0x81, 0x6f, 0x8e, 0x6d, 0xa2,
-	0x8a, 0x7c, 0x04, 0x41, 0x75, 0x28, 0x58, 0xae, 0x33, 0xa5, 0xb3, 0xc0, 0x13, 0x14, 0x79, 0x79,
-	0x9b, 0x8e, 0x62, 0xab, 0x87, 0xc1, 0x42, 0xf4, 0x61, 0xf0, 0x3e, 0xe4, 0xad, 0xe9, 0xcc, 0xa0,
-	0xcc, 0x38, 0x73, 0x99, 0xaf, 0x1e, 0x16, 0x73, 0xd6, 0x74, 0xd6, 0x61, 0xaf, 0x5c, 0xe6, 0xd7,
-	0xff, 0x90, 0x84, 0x52, 0x7c, 0x57, 0x48, 0x83, 0x14, 0x75, 0x7c, 0xd1, 0xca, 0x67, 0x30, 0xff,
-	0x14, 0xaf, 0xc2, 0xe2, 0xae, 0xa2, 0x0a, 0x8f, 0x1a, 0x71, 0x0f, 0xe1, 0x17, 0x7a, 0x75, 0x9c,
-	0xe2, 0x1b, 0x3d, 0x85, 0xb4, 0x78, 0x18, 0x91, 0x5d, 0xd7, 0x3b, 0xd5, 0x17, 0x84, 0xe8, 0x4b,
-	0x48, 0xf3, 0xab, 0x92, 0x28, 0x35, 0xf9, 0x83, 0xc7, 0xef, 0x60, 0x68, 0xac, 0x2e, 0x63, 0x82,
-	0x65, 0xef, 0x04, 0x72, 0xef, 0x7a, 0xf3, 0x7e, 0x16, 0x7d, 0x32, 0x7f, 0xcf, 0x5e, 0xd4, 0x83,
-	0xf8, 0x5f, 0x12, 0x50, 0xe2, 0x2e, 0xf4, 0x9e, 0x30, 0x78, 0x11, 0xed, 0x2f, 0x93, 0x6b, 0x2e,
-	0xa9, 0x6b, 0xe2, 0x29, 0xda, 0x69, 0x5e, 0xf1, 0xcc, 0xd4, 0x3a, 0xcf, 0x5c, 0x3e, 0xe2, 0xa4,
-	0x23, 0x8f, 0x38, 0xf5, 0x9f, 0x42, 0xf1, 0x30, 0xa0, 0xf6, 0xa4, 0x6b, 0x3a, 0xb3, 0x80, 0xf7,
-	0x36, 0x4f, 0x97, 0x3d, 0xda, 0x55, 0x8b, 0xc7, 0x75, 0x91, 0x25, 0xe6, 0xf0, 0x73, 0x78, 0x6e,
-	0xb9, 0x17, 0x8d, 0x99, 0xeb, 0xce, 0x6c, 0xd2, 0x98, 0x90, 0x37, 0xbe, 0xeb, 0xda, 0xac, 0x21,
-	0xff, 0xad, 0xb1, 0xe9, 0xb8, 0x21, 0x78, 0x0f, 0xe4, 0x1f, 0x37, 0xf2, 0xf7, 0xc0, 0x9c, 0xd3,
-	0x7f, 0x07, 0x00, 0x00, 0xff, 0xff, 0x39, 0x7f, 0x05, 0x48, 0xe3, 0x19, 0x00, 0x00,
+var File_gitops_blaze_query_build_proto protoreflect.FileDescriptor
+
+var file_gitops_blaze_query_build_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x67, 0x69, 0x74, 0x6f, 0x70, 0x73, 0x2f, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x2f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0b, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x4a, 0x0a,
+	0x07, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x69, 0x63, 0x65,
+	0x6e, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b,
+	0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x65,
+	0x78, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x65, 0x78, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x39, 0x0a, 0x0f, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x02, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x3d, 0x0a, 0x13, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x44, 0x69, 0x63,
+	0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x02, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0x3c, 0x0a, 0x12, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x4c, 0x69, 0x73, 0x74,
+	0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0x43, 0x0a, 0x19, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79, 0x65, 0x64, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x02, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3d, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xdb, 0x02, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x65,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x33,
+	0x0a, 0x15, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x02, 0x28, 0x09, 0x52, 0x14, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x5f, 0x70, 0x72, 0x65,
+	0x73, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x5a, 0x0a, 0x10, 0x73, 0x79, 0x6d, 0x6c, 0x69, 0x6e,
+	0x6b, 0x5f, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x29, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x53, 0x79, 0x6d, 0x6c,
+	0x69, 0x6e, 0x6b, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x3a, 0x04, 0x43, 0x4f, 0x50,
+	0x59, 0x52, 0x0f, 0x73, 0x79, 0x6d, 0x6c, 0x69, 0x6e, 0x6b, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69,
+	0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x70, 0x5f, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x70, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x2c, 0x0a, 0x0f, 0x53, 0x79, 0x6d, 0x6c, 0x69, 0x6e, 0x6b,
+	0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x4f, 0x50, 0x59,
+	0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x45, 0x52, 0x45, 0x46, 0x45, 0x52, 0x45, 0x4e, 0x43,
+	0x45, 0x10, 0x02, 0x22, 0x9f, 0x16, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x14, 0x65, 0x78, 0x70, 0x6c, 0x69, 0x63, 0x69,
+	0x74, 0x6c, 0x79, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x13, 0x65, 0x78, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x6c, 0x79, 0x53,
+	0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65,
+	0x70, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x12, 0x38,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x02, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x62,
+	0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x6f, 0x6f, 0x6c,
+	0x65, 0x61, 0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x46, 0x0a,
+	0x0e, 0x74, 0x72, 0x69, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x2e, 0x54, 0x72,
+	0x69, 0x73, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0d, 0x74, 0x72, 0x69, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f,
+	0x6c, 0x69, 0x73, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x2e, 0x0a, 0x07, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x2e, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x07, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73,
+	0x65, 0x12, 0x48, 0x0a, 0x11, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x63, 0x74,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62,
+	0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x44, 0x69, 0x63, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x47, 0x0a, 0x12, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x65, 0x74, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x10, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x52, 0x0a, 0x15, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x6c, 0x69,
+	0x73, 0x74, 0x5f, 0x64, 0x69, 0x63, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0a, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x63, 0x74, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x12, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x69, 0x63, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x55, 0x0a, 0x16, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x64, 0x69, 0x63, 0x74, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65,
+	0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x63, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x24, 0x0a, 0x0e, 0x69, 0x6e, 0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x11, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x55, 0x0a, 0x16, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x64,
+	0x69, 0x63, 0x74, 0x5f, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x44, 0x69, 0x63, 0x74, 0x55, 0x6e, 0x61,
+	0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x44, 0x69,
+	0x63, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x68, 0x0a, 0x1d,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x6b, 0x65, 0x79, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x5f, 0x64, 0x69, 0x63, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x16, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79, 0x65, 0x64, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x19, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x4b, 0x65, 0x79, 0x65, 0x64, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x63,
+	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x48, 0x0a, 0x0d, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x0c, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x4a, 0x0a, 0x22, 0x44, 0x45, 0x50, 0x52, 0x45, 0x43, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x73,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x63, 0x74, 0x5f, 0x75, 0x6e, 0x61, 0x72, 0x79,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x1e, 0x44, 0x45,
+	0x50, 0x52, 0x45, 0x43, 0x41, 0x54, 0x45, 0x44, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x69,
+	0x63, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0xcf, 0x07, 0x0a,
+	0x0d, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x12, 0x28, 0x0a, 0x10, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x69, 0x73, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x46, 0x0a, 0x0e, 0x74, 0x72, 0x69, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x62, 0x6c,
+	0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x2e, 0x54, 0x72, 0x69, 0x73, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0d, 0x74, 0x72,
+	0x69, 0x73, 0x74, 0x61, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x73,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69,
+	0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x6c, 0x69, 0x63, 0x65, 0x6e,
+	0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65,
+	0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x07,
+	0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x11, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x5f, 0x64, 0x69, 0x63, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x63, 0x74, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x47, 0x0a, 0x12, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x69, 0x73,
+	0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x65,
+	0x74, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x52, 0x0a, 0x15, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x64, 0x69, 0x63, 0x74, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x62, 0x6c, 0x61, 0x7a,
+	0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x12, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x63, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x55,
+	0x0a, 0x16, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x64, 0x69,
+	0x63, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x13, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x63, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x69, 0x6e, 0x74, 0x5f, 0x6c, 0x69, 0x73,
+	0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0c, 0x69,
+	0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x55, 0x0a, 0x16, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x5f, 0x64, 0x69, 0x63, 0x74, 0x5f, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x6c,
+	0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x44,
+	0x69, 0x63, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x44, 0x69, 0x63, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x68, 0x0a, 0x1d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x6b, 0x65, 0x79, 0x65,
+	0x64, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x63, 0x74, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x62, 0x6c, 0x61, 0x7a,
+	0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79,
+	0x65, 0x64, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x19, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79, 0x65, 0x64, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x44, 0x69, 0x63, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x4a, 0x0a, 0x22,
+	0x44, 0x45, 0x50, 0x52, 0x45, 0x43, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x5f, 0x64, 0x69, 0x63, 0x74, 0x5f, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x1e, 0x44, 0x45, 0x50, 0x52, 0x45, 0x43,
+	0x41, 0x54, 0x45, 0x44, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x63, 0x74, 0x55, 0x6e,
+	0x61, 0x72, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4a, 0x04, 0x08, 0x0c, 0x10, 0x0d, 0x1a, 0x9c,
+	0x01, 0x0a, 0x08, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x3e, 0x0a, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x62,
+	0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x68,
+	0x61, 0x73, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x68, 0x61, 0x73, 0x44, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6e, 0x6f, 0x5f, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x6e, 0x6f, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x85, 0x01,
+	0x0a, 0x0c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x38,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x62,
+	0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x3b, 0x0a, 0x08, 0x65, 0x6c, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x62, 0x6c, 0x61,
+	0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x65, 0x6c, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x8a, 0x03, 0x0a, 0x0d, 0x44, 0x69, 0x73, 0x63, 0x72, 0x69,
+	0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x54, 0x45, 0x47,
+	0x45, 0x52, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x02,
+	0x12, 0x09, 0x0a, 0x05, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x4f,
+	0x55, 0x54, 0x50, 0x55, 0x54, 0x10, 0x04, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x54, 0x52, 0x49, 0x4e,
+	0x47, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x05, 0x12, 0x0e, 0x0a, 0x0a, 0x4c, 0x41, 0x42, 0x45,
+	0x4c, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x55, 0x54, 0x50,
+	0x55, 0x54, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x07, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x49, 0x53,
+	0x54, 0x52, 0x49, 0x42, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x45, 0x54, 0x10, 0x08, 0x12,
+	0x0b, 0x0a, 0x07, 0x4c, 0x49, 0x43, 0x45, 0x4e, 0x53, 0x45, 0x10, 0x09, 0x12, 0x0f, 0x0a, 0x0b,
+	0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x44, 0x49, 0x43, 0x54, 0x10, 0x0a, 0x12, 0x16, 0x0a,
+	0x12, 0x46, 0x49, 0x4c, 0x45, 0x53, 0x45, 0x54, 0x5f, 0x45, 0x4e, 0x54, 0x52, 0x59, 0x5f, 0x4c,
+	0x49, 0x53, 0x54, 0x10, 0x0b, 0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x5f, 0x4c,
+	0x49, 0x53, 0x54, 0x5f, 0x44, 0x49, 0x43, 0x54, 0x10, 0x0c, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x54,
+	0x52, 0x49, 0x4e, 0x47, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x44, 0x49, 0x43, 0x54, 0x10, 0x0d,
+	0x12, 0x0b, 0x0a, 0x07, 0x42, 0x4f, 0x4f, 0x4c, 0x45, 0x41, 0x4e, 0x10, 0x0e, 0x12, 0x0c, 0x0a,
+	0x08, 0x54, 0x52, 0x49, 0x53, 0x54, 0x41, 0x54, 0x45, 0x10, 0x0f, 0x12, 0x10, 0x0a, 0x0c, 0x49,
+	0x4e, 0x54, 0x45, 0x47, 0x45, 0x52, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x10, 0x12, 0x0b, 0x0a,
+	0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x12, 0x12, 0x14, 0x0a, 0x10, 0x4c, 0x41,
+	0x42, 0x45, 0x4c, 0x5f, 0x44, 0x49, 0x43, 0x54, 0x5f, 0x55, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x13,
+	0x12, 0x11, 0x0a, 0x0d, 0x53, 0x45, 0x4c, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x5f, 0x4c, 0x49, 0x53,
+	0x54, 0x10, 0x14, 0x12, 0x1b, 0x0a, 0x17, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x5f, 0x4b, 0x45, 0x59,
+	0x45, 0x44, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x44, 0x49, 0x43, 0x54, 0x10, 0x15,
+	0x12, 0x20, 0x0a, 0x1c, 0x44, 0x45, 0x50, 0x52, 0x45, 0x43, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x53,
+	0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x44, 0x49, 0x43, 0x54, 0x5f, 0x55, 0x4e, 0x41, 0x52, 0x59,
+	0x10, 0x11, 0x22, 0x25, 0x0a, 0x08, 0x54, 0x72, 0x69, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x06,
+	0x0a, 0x02, 0x4e, 0x4f, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x59, 0x45, 0x53, 0x10, 0x01, 0x12,
+	0x08, 0x0a, 0x04, 0x41, 0x55, 0x54, 0x4f, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x0c, 0x10, 0x0d, 0x4a,
+	0x04, 0x08, 0x10, 0x10, 0x11, 0x22, 0x8e, 0x03, 0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73,
+	0x18, 0x02, 0x20, 0x02, 0x28, 0x09, 0x52, 0x09, 0x72, 0x75, 0x6c, 0x65, 0x43, 0x6c, 0x61, 0x73,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a,
+	0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x52, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x72, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x75, 0x6c, 0x65, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x73,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x2a, 0x0a, 0x11,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x62, 0x79, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x42,
+	0x79, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x73,
+	0x6b, 0x79, 0x6c, 0x61, 0x72, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73,
+	0x53, 0x6b, 0x79, 0x6c, 0x61, 0x72, 0x6b, 0x12, 0x41, 0x0a, 0x1d, 0x73, 0x6b, 0x79, 0x6c, 0x61,
+	0x72, 0x6b, 0x5f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x1a,
+	0x73, 0x6b, 0x79, 0x6c, 0x61, 0x72, 0x6b, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65,
+	0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x43, 0x6f, 0x64, 0x65, 0x4a, 0x04, 0x08, 0x08, 0x10, 0x09,
+	0x4a, 0x04, 0x08, 0x0b, 0x10, 0x0c, 0x22, 0x83, 0x01, 0x0a, 0x0b, 0x52, 0x75, 0x6c, 0x65, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x02, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x31, 0x0a,
+	0x0a, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e,
+	0x52, 0x75, 0x6c, 0x65, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x8b, 0x01, 0x0a,
+	0x0c, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x64, 0x5f, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x34,
+	0x0a, 0x16, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22, 0x62, 0x0a, 0x10, 0x45, 0x6e,
+	0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x22, 0xb4,
+	0x02, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a,
+	0x0a, 0x73, 0x75, 0x62, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x76, 0x69,
+	0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x18, 0x0a,
+	0x07, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x6c, 0x69, 0x63, 0x65, 0x6e,
+	0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65,
+	0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x07,
+	0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x5f, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x4a,
+	0x04, 0x08, 0x07, 0x10, 0x08, 0x22, 0x68, 0x0a, 0x0d, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x02, 0x20,
+	0x02, 0x28, 0x09, 0x52, 0x0e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52,
+	0x75, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0xd9, 0x03, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x35, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65,
+	0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x2e, 0x44, 0x69,
+	0x73, 0x63, 0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x25, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x52, 0x75,
+	0x6c, 0x65, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x69,
+	0x6c, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x6c, 0x61,
+	0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x0d, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62,
+	0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x4a, 0x0a, 0x11, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x45,
+	0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52,
+	0x10, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x22, 0x68, 0x0a, 0x0d, 0x44, 0x69, 0x73, 0x63, 0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x55, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b,
+	0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x12, 0x0a,
+	0x0e, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x10,
+	0x03, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x5f, 0x47, 0x52, 0x4f,
+	0x55, 0x50, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x4e, 0x56, 0x49, 0x52, 0x4f, 0x4e, 0x4d,
+	0x45, 0x4e, 0x54, 0x5f, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x10, 0x05, 0x22, 0x3a, 0x0a, 0x0b, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6c, 0x61,
+	0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0xc0, 0x01, 0x0a, 0x14, 0x41, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x4c, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x01, 0x20, 0x02, 0x28, 0x0e,
+	0x32, 0x34, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41,
+	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x49,
+	0x6e, 0x66, 0x6f, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x2c,
+	0x0a, 0x12, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x63,
+	0x6c, 0x61, 0x73, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x22, 0x2c, 0x0a, 0x12,
+	0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73,
+	0x65, 0x73, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4e, 0x59, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x02, 0x22, 0xfa, 0x03, 0x0a, 0x13, 0x41,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x02, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x2e, 0x44, 0x69, 0x73,
+	0x63, 0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x6e, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x6d, 0x61, 0x6e, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x53,
+	0x0a, 0x14, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x63,
+	0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62,
+	0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x77,
+	0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x12, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x43, 0x6c, 0x61, 0x73,
+	0x73, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x5f, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x69, 0x6e, 0x67,
+	0x6c, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x22, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x62, 0x6c,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x12, 0x1e, 0x0a, 0x0b, 0x63, 0x66, 0x67, 0x5f, 0x69,
+	0x73, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x66,
+	0x67, 0x49, 0x73, 0x48, 0x6f, 0x73, 0x74, 0x22, 0x8c, 0x02, 0x0a, 0x0e, 0x41, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x62, 0x6f, 0x6f, 0x6c, 0x12, 0x2f, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x39, 0x0a, 0x04, 0x64, 0x69, 0x63,
+	0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x2e, 0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04,
+	0x64, 0x69, 0x63, 0x74, 0x1a, 0x50, 0x0a, 0x09, 0x44, 0x69, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x02,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xa0, 0x01, 0x0a, 0x0e, 0x52, 0x75, 0x6c, 0x65, 0x44,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3e, 0x0a,
+	0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x41,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x24, 0x0a,
+	0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0x40, 0x0a, 0x0d, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x72, 0x75,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x6c, 0x61, 0x7a, 0x65,
+	0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x42, 0x6b, 0x0a, 0x34, 0x63,
+	0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x64, 0x65, 0x76, 0x74, 0x6f, 0x6f,
+	0x6c, 0x73, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2e, 0x6c, 0x69, 0x62, 0x2e, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32,
+	0x61, 0x70, 0x69, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x66, 0x61, 0x73, 0x74, 0x65, 0x72, 0x63, 0x69, 0x2f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x5f, 0x67,
+	0x69, 0x74, 0x6f, 0x70, 0x73, 0x2f, 0x67, 0x69, 0x74, 0x6f, 0x70, 0x73, 0x2f, 0x62, 0x6c, 0x61,
+	0x7a, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79,
+}
+
+var (
+	file_gitops_blaze_query_build_proto_rawDescOnce sync.Once
+	file_gitops_blaze_query_build_proto_rawDescData = file_gitops_blaze_query_build_proto_rawDesc
+)
+
+func file_gitops_blaze_query_build_proto_rawDescGZIP() []byte {
+	file_gitops_blaze_query_build_proto_rawDescOnce.Do(func() {
+		file_gitops_blaze_query_build_proto_rawDescData = protoimpl.X.CompressGZIP(file_gitops_blaze_query_build_proto_rawDescData)
+	})
+	return file_gitops_blaze_query_build_proto_rawDescData
+}
+
+var file_gitops_blaze_query_build_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_gitops_blaze_query_build_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_gitops_blaze_query_build_proto_goTypes = []interface{}{
+	(FilesetEntry_SymlinkBehavior)(0),            // 0: blaze_query.FilesetEntry.SymlinkBehavior
+	(Attribute_Discriminator)(0),                 // 1: blaze_query.Attribute.Discriminator
+	(Attribute_Tristate)(0),                      // 2: blaze_query.Attribute.Tristate
+	(Target_Discriminator)(0),                    // 3: blaze_query.Target.Discriminator
+	(AllowedRuleClassInfo_AllowedRuleClasses)(0), // 4: blaze_query.AllowedRuleClassInfo.AllowedRuleClasses
+	(*License)(nil),                              // 5: blaze_query.License
+	(*StringDictEntry)(nil),                      // 6: blaze_query.StringDictEntry
+	(*LabelDictUnaryEntry)(nil),                  // 7: blaze_query.LabelDictUnaryEntry
+	(*LabelListDictEntry)(nil),                   // 8: blaze_query.LabelListDictEntry
+	(*LabelKeyedStringDictEntry)(nil),            // 9: blaze_query.LabelKeyedStringDictEntry
+	(*StringListDictEntry)(nil),                  // 10: blaze_query.StringListDictEntry
+	(*FilesetEntry)(nil),                         // 11: blaze_query.FilesetEntry
+	(*Attribute)(nil),                            // 12: blaze_query.Attribute
+	(*Rule)(nil),                                 // 13: blaze_query.Rule
+	(*RuleSummary)(nil),                          // 14: blaze_query.RuleSummary
+	(*PackageGroup)(nil),                         // 15: blaze_query.PackageGroup
+	(*EnvironmentGroup)(nil),                     // 16: blaze_query.EnvironmentGroup
+	(*SourceFile)(nil),                           // 17: blaze_query.SourceFile
+	(*GeneratedFile)(nil),                        // 18: blaze_query.GeneratedFile
+	(*Target)(nil),                               // 19: blaze_query.Target
+	(*QueryResult)(nil),                          // 20: blaze_query.QueryResult
+	(*AllowedRuleClassInfo)(nil),                 // 21: blaze_query.AllowedRuleClassInfo
+	(*AttributeDefinition)(nil),                  // 22: blaze_query.AttributeDefinition
+	(*AttributeValue)(nil),                       // 23: blaze_query.AttributeValue
+	(*RuleDefinition)(nil),                       // 24: blaze_query.RuleDefinition
+	(*BuildLanguage)(nil),                        // 25: blaze_query.BuildLanguage
+	(*Attribute_SelectorEntry)(nil),              // 26: blaze_query.Attribute.SelectorEntry
+	(*Attribute_Selector)(nil),                   // 27: blaze_query.Attribute.Selector
+	(*Attribute_SelectorList)(nil),               // 28: blaze_query.Attribute.SelectorList
+	(*AttributeValue_DictEntry)(nil),             // 29: blaze_query.AttributeValue.DictEntry
+}
+var file_gitops_blaze_query_build_proto_depIdxs = []int32{
+	0,  // 0: blaze_query.FilesetEntry.symlink_behavior:type_name -> blaze_query.FilesetEntry.SymlinkBehavior
+	1,  // 1: blaze_query.Attribute.type:type_name -> blaze_query.Attribute.Discriminator
+	2,  // 2: blaze_query.Attribute.tristate_value:type_name -> blaze_query.Attribute.Tristate
+	5,  // 3: blaze_query.Attribute.license:type_name -> blaze_query.License
+	6,  // 4: blaze_query.Attribute.string_dict_value:type_name -> blaze_query.StringDictEntry
+	11, // 5: blaze_query.Attribute.fileset_list_value:type_name -> blaze_query.FilesetEntry
+	8,  // 6: blaze_query.Attribute.label_list_dict_value:type_name -> blaze_query.LabelListDictEntry
+	10, // 7: blaze_query.Attribute.string_list_dict_value:type_name -> blaze_query.StringListDictEntry
+	7,  // 8: blaze_query.Attribute.label_dict_unary_value:type_name -> blaze_query.LabelDictUnaryEntry
+	9,  // 9: blaze_query.Attribute.label_keyed_string_dict_value:type_name -> blaze_query.LabelKeyedStringDictEntry
+	28, // 10: blaze_query.Attribute.selector_list:type_name -> blaze_query.Attribute.SelectorList
+	12, // 11: blaze_query.Rule.attribute:type_name -> blaze_query.Attribute
+	13, // 12: blaze_query.RuleSummary.rule:type_name -> blaze_query.Rule
+	13, // 13: blaze_query.RuleSummary.dependency:type_name -> blaze_query.Rule
+	5,  // 14: blaze_query.SourceFile.license:type_name -> blaze_query.License
+	3,  // 15: blaze_query.Target.type:type_name -> blaze_query.Target.Discriminator
+	13, // 16: blaze_query.Target.rule:type_name -> blaze_query.Rule
+	17, // 17: blaze_query.Target.source_file:type_name -> blaze_query.SourceFile
+	18, // 18: blaze_query.Target.generated_file:type_name -> blaze_query.GeneratedFile
+	15, // 19: blaze_query.Target.package_group:type_name -> blaze_query.PackageGroup
+	16, // 20: blaze_query.Target.environment_group:type_name -> blaze_query.EnvironmentGroup
+	19, // 21: blaze_query.QueryResult.target:type_name -> blaze_query.Target
+	4,  // 22: blaze_query.AllowedRuleClassInfo.policy:type_name -> blaze_query.AllowedRuleClassInfo.AllowedRuleClasses
+	1,  // 23: blaze_query.AttributeDefinition.type:type_name -> blaze_query.Attribute.Discriminator
+	21, // 24: blaze_query.AttributeDefinition.allowed_rule_classes:type_name -> blaze_query.AllowedRuleClassInfo
+	23, // 25: blaze_query.AttributeDefinition.default:type_name -> blaze_query.AttributeValue
+	23, // 26: blaze_query.AttributeValue.list:type_name -> blaze_query.AttributeValue
+	29, // 27: blaze_query.AttributeValue.dict:type_name -> blaze_query.AttributeValue.DictEntry
+	22, // 28: blaze_query.RuleDefinition.attribute:type_name -> blaze_query.AttributeDefinition
+	24, // 29: blaze_query.BuildLanguage.rule:type_name -> blaze_query.RuleDefinition
+	2,  // 30: blaze_query.Attribute.SelectorEntry.tristate_value:type_name -> blaze_query.Attribute.Tristate
+	5,  // 31: blaze_query.Attribute.SelectorEntry.license:type_name -> blaze_query.License
+	6,  // 32: blaze_query.Attribute.SelectorEntry.string_dict_value:type_name -> blaze_query.StringDictEntry
+	11, // 33: blaze_query.Attribute.SelectorEntry.fileset_list_value:type_name -> blaze_query.FilesetEntry
+	8,  // 34: blaze_query.Attribute.SelectorEntry.label_list_dict_value:type_name -> blaze_query.LabelListDictEntry
+	10, // 35: blaze_query.Attribute.SelectorEntry.string_list_dict_value:type_name -> blaze_query.StringListDictEntry
+	7,  // 36: blaze_query.Attribute.SelectorEntry.label_dict_unary_value:type_name -> blaze_query.LabelDictUnaryEntry
+	9,  // 37: blaze_query.Attribute.SelectorEntry.label_keyed_string_dict_value:type_name -> blaze_query.LabelKeyedStringDictEntry
+	26, // 38: blaze_query.Attribute.Selector.entries:type_name -> blaze_query.Attribute.SelectorEntry
+	1,  // 39: blaze_query.Attribute.SelectorList.type:type_name -> blaze_query.Attribute.Discriminator
+	27, // 40: blaze_query.Attribute.SelectorList.elements:type_name -> blaze_query.Attribute.Selector
+	23, // 41: blaze_query.AttributeValue.DictEntry.value:type_name -> blaze_query.AttributeValue
+	42, // [42:42] is the sub-list for method output_type
+	42, // [42:42] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_gitops_blaze_query_build_proto_init() }
+func file_gitops_blaze_query_build_proto_init() {
+	if File_gitops_blaze_query_build_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gitops_blaze_query_build_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*License); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StringDictEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabelDictUnaryEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabelListDictEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabelKeyedStringDictEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StringListDictEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilesetEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Attribute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuleSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PackageGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnvironmentGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourceFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeneratedFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Target); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AllowedRuleClassInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttributeDefinition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttributeValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuleDefinition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BuildLanguage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Attribute_SelectorEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Attribute_Selector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Attribute_SelectorList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gitops_blaze_query_build_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttributeValue_DictEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gitops_blaze_query_build_proto_rawDesc,
+			NumEnums:      5,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gitops_blaze_query_build_proto_goTypes,
+		DependencyIndexes: file_gitops_blaze_query_build_proto_depIdxs,
+		EnumInfos:         file_gitops_blaze_query_build_proto_enumTypes,
+		MessageInfos:      file_gitops_blaze_query_build_proto_msgTypes,
+	}.Build()
+	File_gitops_blaze_query_build_proto = out.File
+	file_gitops_blaze_query_build_proto_rawDesc = nil
+	file_gitops_blaze_query_build_proto_goTypes = nil
+	file_gitops_blaze_query_build_proto_depIdxs = nil
 }
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"flag"
+	"strings"
+	"testing"
+)
+
+func testFlags() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("git_repo", "", "git repo location")
+	fs.String("github_repo", "", "the repo to use for github api requests")
+	fs.String("github_repo_owner", "", "the owner user/organization to use for github api requests")
+	fs.Bool("dry_run", false, "Do not create PRs, just print what would be done")
+	fs.Int("push_parallelism", 1, "Number of image pushes to perform concurrently. Default is serial")
+	return fs
+}
+
+func TestPrintUsage(t *testing.T) {
+	var sb strings.Builder
+	PrintUsage(&sb, testFlags(), []Group{
+		{Title: "Git", Flags: []string{"git_repo"}},
+		{Title: "GitHub", Flags: []string{"github_*"}},
+	})
+	expected := `Git:
+  -git_repo string
+    	git repo location
+
+GitHub:
+  -github_repo string
+    	the repo to use for github api requests
+  -github_repo_owner string
+    	the owner user/organization to use for github api requests
+
+Other:
+  -dry_run
+    	Do not create PRs, just print what would be done
+  -push_parallelism int
+    	Number of image pushes to perform concurrently. Default is serial (default 1)
+
+`
+	if sb.String() != expected {
+		t.Errorf("unexpected usage:\n%s", sb.String())
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	for shell, expected := range map[string][]string{
+		"bash": {"_create_gitops_prs() {", `"doctor completion -dry_run -git_repo`, "complete -o default -F _create_gitops_prs create_gitops_prs"},
+		"zsh":  {"#compdef create_gitops_prs", "'-dry_run[Do not create PRs, just print what would be done]' \\", "'-push_parallelism[Number of image pushes to perform concurrently]:value:_files' \\", "'1::command:(doctor completion)'"},
+		"fish": {"complete -c create_gitops_prs -n __fish_use_subcommand -f -a 'doctor completion'", "complete -c create_gitops_prs -o git_repo -r -d 'git repo location'", "complete -c create_gitops_prs -o dry_run -d"},
+	} {
+		var sb strings.Builder
+		if err := Completion(&sb, shell, "create_gitops_prs", testFlags(), []string{"doctor", "completion"}); err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range expected {
+			if !strings.Contains(sb.String(), e) {
+				t.Errorf("%s completion does not contain %q:\n%s", shell, e, sb.String())
+			}
+		}
+	}
+	if err := Completion(&strings.Builder{}, "tcsh", "p", testFlags(), nil); err == nil {
+		t.Error("expected an error for an unsupported shell")
+	}
+}
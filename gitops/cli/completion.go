@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Shells lists shells supported by Completion
+var Shells = []string{"bash", "zsh", "fish"}
+
+var nonIdent = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+func isBool(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// summary returns the first sentence of the flag usage on a single line
+func summary(f *flag.Flag) string {
+	_, usage := flag.UnquoteUsage(f)
+	usage = strings.Join(strings.Fields(usage), " ")
+	if i := strings.Index(usage, ". "); i >= 0 {
+		usage = usage[:i]
+	}
+	return strings.TrimSuffix(usage, ".")
+}
+
+// Completion writes a completion script for prog with subcommands and flags of fs in the shell syntax
+func Completion(w io.Writer, shell, prog string, fs *flag.FlagSet, subcommands []string) error {
+	var flags []*flag.Flag
+	fs.VisitAll(func(f *flag.Flag) { flags = append(flags, f) })
+	switch shell {
+	case "bash":
+		fn := "_" + nonIdent.ReplaceAllString(prog, "_")
+		var words []string
+		words = append(words, subcommands...)
+		for _, f := range flags {
+			words = append(words, "-"+f.Name)
+		}
+		fmt.Fprintf(w, "%s() {\n", fn)
+		fmt.Fprintf(w, "\tlocal cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+		fmt.Fprintf(w, "\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(words, " "))
+		fmt.Fprintf(w, "}\n")
+		fmt.Fprintf(w, "complete -o default -F %s %s\n", fn, prog)
+	case "zsh":
+		esc := strings.NewReplacer(`'`, `'\''`, `[`, `\[`, `]`, `\]`, `:`, `\:`)
+		fmt.Fprintf(w, "#compdef %s\n\n", prog)
+		fmt.Fprintf(w, "_arguments \\\n")
+		for _, f := range flags {
+			if isBool(f) {
+				fmt.Fprintf(w, "\t'-%s[%s]' \\\n", f.Name, esc.Replace(summary(f)))
+			} else {
+				fmt.Fprintf(w, "\t'-%s[%s]:value:_files' \\\n", f.Name, esc.Replace(summary(f)))
+			}
+		}
+		fmt.Fprintf(w, "\t'1::command:(%s)'\n", strings.Join(subcommands, " "))
+	case "fish":
+		esc := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+		if len(subcommands) > 0 {
+			fmt.Fprintf(w, "complete -c %s -n __fish_use_subcommand -f -a '%s'\n", prog, strings.Join(subcommands, " "))
+		}
+		for _, f := range flags {
+			if isBool(f) {
+				fmt.Fprintf(w, "complete -c %s -o %s -d '%s'\n", prog, f.Name, esc.Replace(summary(f)))
+			} else {
+				fmt.Fprintf(w, "complete -c %s -o %s -r -d '%s'\n", prog, f.Name, esc.Replace(summary(f)))
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported shell %q, supported shells: %s", shell, strings.Join(Shells, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Package cli renders grouped help and shell completion scripts for a flag set
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Group is a titled set of flags shown together in the help output.
+// A flag name ending with * matches all flags with that prefix.
+type Group struct {
+	Title string
+	Flags []string
+}
+
+func (g Group) matches(name string) bool {
+	for _, f := range g.Flags {
+		if f == name || strings.HasSuffix(f, "*") && strings.HasPrefix(name, strings.TrimSuffix(f, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// Grouped returns flags of fs by group. Every flag belongs to the first group matching it.
+// Flags not matched by any group are returned in an additional "Other" group.
+func Grouped(fs *flag.FlagSet, groups []Group) (titles []string, flags [][]*flag.Flag) {
+	byGroup := make([][]*flag.Flag, len(groups)+1)
+	fs.VisitAll(func(f *flag.Flag) {
+		i := len(groups)
+		for j, g := range groups {
+			if g.matches(f.Name) {
+				i = j
+				break
+			}
+		}
+		byGroup[i] = append(byGroup[i], f)
+	})
+	for i, fl := range byGroup {
+		if len(fl) == 0 {
+			continue
+		}
+		title := "Other"
+		if i < len(groups) {
+			title = groups[i].Title
+		}
+		titles = append(titles, title)
+		flags = append(flags, fl)
+	}
+	return titles, flags
+}
+
+// PrintUsage writes flags of fs grouped by groups, formatted like flag.PrintDefaults
+func PrintUsage(w io.Writer, fs *flag.FlagSet, groups []Group) {
+	titles, flags := Grouped(fs, groups)
+	for i, title := range titles {
+		fmt.Fprintf(w, "%s:\n", title)
+		for _, f := range flags[i] {
+			name, usage := flag.UnquoteUsage(f)
+			line := "  -" + f.Name
+			if name != "" {
+				line += " " + name
+			}
+			line += "\n    \t" + strings.ReplaceAll(usage, "\n", "\n    \t")
+			switch {
+			case f.DefValue == "" || f.DefValue == "false" || f.DefValue == "0" || f.DefValue == "0s":
+			case name == "string":
+				line += fmt.Sprintf(" (default %q)", f.DefValue)
+			default:
+				line += fmt.Sprintf(" (default %v)", f.DefValue)
+			}
+			fmt.Fprintln(w, line)
+		}
+		fmt.Fprintln(w)
+	}
+}
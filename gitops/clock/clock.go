@@ -0,0 +1,64 @@
+// Package clock abstracts the time source so code waiting for deadlines can be tested without sleeping
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the time and waits
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time        { return time.Now() }
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// Real is the system clock
+var Real Clock = realClock{}
+
+// Fake is a manually advanced clock. Sleep returns immediately after advancing the time by d.
+// It is safe for concurrent use.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+	// slept is the total duration passed to Sleep
+	slept time.Duration
+}
+
+// NewFake returns a Fake clock set to now
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the current fake time
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Sleep advances the clock by d
+func (f *Fake) Sleep(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	f.slept += d
+}
+
+// Advance moves the clock forward by d without counting it as sleep
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// Slept returns the total duration passed to Sleep
+func (f *Fake) Slept() time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.slept
+}
@@ -0,0 +1,20 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var c Clock = NewFake(start)
+	c.Sleep(30 * time.Second)
+	c.(*Fake).Advance(time.Minute)
+	c.Sleep(30 * time.Second)
+	if got := c.Now(); !got.Equal(start.Add(2 * time.Minute)) {
+		t.Errorf("Now() = %v", got)
+	}
+	if slept := c.(*Fake).Slept(); slept != time.Minute {
+		t.Errorf("Slept() = %v", slept)
+	}
+}
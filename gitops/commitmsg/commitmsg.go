@@ -19,6 +19,28 @@ import (
 const begin = "--- gitops targets begin ---"
 const end = "--- gitops targets end ---"
 
+const subjectPrefix = "GitOps for release branch "
+
+// GenerateSubject generates the subject line of a deployment commit of releaseBranch from branch at commit
+func GenerateSubject(releaseBranch, branch, commit string) string {
+	return subjectPrefix + releaseBranch + " from " + branch + " commit " + commit + "\n"
+}
+
+// ExtractReleaseBranches extracts release branches of the deployment commit subjects in msg. Squash merges list
+// the messages of the merged commits, optionally as a "* " bulleted list, so every line is checked.
+func ExtractReleaseBranches(msg string) (branches []string) {
+	for _, s := range strings.Split(msg, "\n") {
+		s = strings.TrimPrefix(strings.TrimSpace(s), "* ")
+		if !strings.HasPrefix(s, subjectPrefix) {
+			continue
+		}
+		if b, _, _ := strings.Cut(strings.TrimPrefix(s, subjectPrefix), " "); b != "" {
+			branches = append(branches, b)
+		}
+	}
+	return
+}
+
 // ExtractTargets extracts list of gitops targets used in a commit
 func ExtractTargets(msg string) (packages []string) {
 	betweenMarkers := false
@@ -40,6 +62,22 @@ func ExtractTargets(msg string) (packages []string) {
 	return
 }
 
+// RemovedTargets returns targets recorded in the commit message msg that are not in targets.
+// A deployment branch has to be recreated when its last commit has removed targets, as their manifests would stay otherwise.
+func RemovedTargets(msg string, targets []string) []string {
+	current := make(map[string]bool)
+	for _, t := range targets {
+		current[t] = true
+	}
+	var removed []string
+	for _, t := range ExtractTargets(msg) {
+		if !current[t] {
+			removed = append(removed, t)
+		}
+	}
+	return removed
+}
+
 // Generate generates a commit message from a list of targets
 func Generate(targets []string) string {
 	var sb strings.Builder
@@ -56,3 +94,64 @@ func Generate(targets []string) string {
 	sb.WriteByte('\n')
 	return sb.String()
 }
+
+const inputsHashPrefix = "gitops-inputs-hash: "
+
+// GenerateInputsHash generates a commit message line recording the hash of the release train inputs
+func GenerateInputsHash(hash string) string {
+	return inputsHashPrefix + hash + "\n"
+}
+
+// ExtractInputsHash extracts the release train inputs hash recorded in a commit message.
+// Returns empty string if the message has no hash.
+func ExtractInputsHash(msg string) string {
+	for _, s := range strings.Split(msg, "\n") {
+		if strings.HasPrefix(s, inputsHashPrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(s, inputsHashPrefix))
+		}
+	}
+	return ""
+}
+
+// NoteInputsHash extracts the inputs hash of branch from a git note written with WithNoteInputsHash.
+// Returns empty string if the note has no hash of the branch.
+func NoteInputsHash(note, branch string) string {
+	for _, s := range strings.Split(note, "\n") {
+		if b, hash, ok := strings.Cut(strings.TrimSpace(s), " "); ok && b == branch {
+			return hash
+		}
+	}
+	return ""
+}
+
+// WithNoteInputsHash returns note recording hash as the inputs hash of branch. A note lists one branch and hash
+// per line, as deployment branches can point to the same commit.
+func WithNoteInputsHash(note, branch, hash string) string {
+	var lines []string
+	for _, s := range strings.Split(note, "\n") {
+		s = strings.TrimSpace(s)
+		if b, _, _ := strings.Cut(s, " "); s != "" && b != branch {
+			lines = append(lines, s)
+		}
+	}
+	lines = append(lines, branch+" "+hash)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+const toolVersionPrefix = "gitops-tool-version: "
+
+// GenerateToolVersion generates a commit message line recording the version of the tool that made the commit
+func GenerateToolVersion(version string) string {
+	return toolVersionPrefix + version + "\n"
+}
+
+// ExtractToolVersion extracts the tool version recorded in a commit message.
+// Returns empty string if the message has no version.
+func ExtractToolVersion(msg string) string {
+	for _, s := range strings.Split(msg, "\n") {
+		if strings.HasPrefix(s, toolVersionPrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(s, toolVersionPrefix))
+		}
+	}
+	return ""
+}
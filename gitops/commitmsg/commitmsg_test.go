@@ -38,3 +38,62 @@ func ExampleGenerate() {
 	// target2
 	// --- gitops targets end ---
 }
+
+func TestInputsHashRoundtrip(t *testing.T) {
+	msg := "GitOps for release branch master\n" + commitmsg.Generate([]string{"target1"}) + commitmsg.GenerateInputsHash("abc123")
+	if h := commitmsg.ExtractInputsHash(msg); h != "abc123" {
+		t.Errorf("Unexpected inputs hash after parsing: %q", h)
+	}
+	if h := commitmsg.ExtractInputsHash(commitmsg.Generate([]string{"target1"})); h != "" {
+		t.Errorf("Unexpected inputs hash in message without hash: %q", h)
+	}
+}
+
+func TestNoteInputsHash(t *testing.T) {
+	note := commitmsg.WithNoteInputsHash("", "deploy/prod", "abc")
+	note = commitmsg.WithNoteInputsHash(note, "deploy/dev", "def")
+	note = commitmsg.WithNoteInputsHash(note, "deploy/prod", "123")
+	if note != "deploy/dev def\ndeploy/prod 123\n" {
+		t.Errorf("unexpected note %q", note)
+	}
+	if h := commitmsg.NoteInputsHash(note, "deploy/prod"); h != "123" {
+		t.Errorf("unexpected hash of deploy/prod %q", h)
+	}
+	if h := commitmsg.NoteInputsHash(note, "deploy/qa"); h != "" {
+		t.Errorf("unexpected hash of deploy/qa %q", h)
+	}
+}
+
+func TestToolVersionRoundtrip(t *testing.T) {
+	msg := "GitOps for release branch master\n" + commitmsg.Generate([]string{"target1"}) + commitmsg.GenerateToolVersion("v1.2.3 (commit abc123)")
+	if v := commitmsg.ExtractToolVersion(msg); v != "v1.2.3 (commit abc123)" {
+		t.Errorf("Unexpected tool version after parsing: %q", v)
+	}
+	if targets := commitmsg.ExtractTargets(msg); len(targets) != 1 || targets[0] != "target1" {
+		t.Errorf("Unexpected targets after parsing: %v", targets)
+	}
+}
+
+func TestRemovedTargets(t *testing.T) {
+	msg := "GitOps for release branch master\n" + commitmsg.Generate([]string{"//a:prod", "//b:prod"})
+	if removed := commitmsg.RemovedTargets(msg, []string{"//b:prod", "//c:prod"}); len(removed) != 1 || removed[0] != "//a:prod" {
+		t.Errorf("Unexpected removed targets: %v", removed)
+	}
+	if removed := commitmsg.RemovedTargets(msg, []string{"//a:prod", "//b:prod"}); len(removed) != 0 {
+		t.Errorf("Unexpected removed targets: %v", removed)
+	}
+}
+
+func TestExtractReleaseBranches(t *testing.T) {
+	msg := commitmsg.GenerateSubject("master", "main", "abc123") + commitmsg.Generate([]string{"//a:prod"})
+	if b := commitmsg.ExtractReleaseBranches(msg); len(b) != 1 || b[0] != "master" {
+		t.Errorf("Unexpected release branches: %v", b)
+	}
+	squashed := "GitOps deployment deploy/prod (#12)\n\n* " + commitmsg.GenerateSubject("release/1.x", "release/1.x", "def456") + "\n* fix\n"
+	if b := commitmsg.ExtractReleaseBranches(squashed); len(b) != 1 || b[0] != "release/1.x" {
+		t.Errorf("Unexpected release branches of a squash merge: %v", b)
+	}
+	if b := commitmsg.ExtractReleaseBranches("Manual change"); len(b) != 0 {
+		t.Errorf("Unexpected release branches: %v", b)
+	}
+}
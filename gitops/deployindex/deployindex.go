@@ -0,0 +1,131 @@
+// Package deployindex maintains a catalog of release trains in the gitops repository listing what is deployed where
+package deployindex
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Header marks index files maintained by Update
+const Header = "Code generated by rules_gitops, DO NOT EDIT."
+
+// Entry describes the last deployment of a release train
+type Entry struct {
+	Train  string `json:"train"`
+	Branch string `json:"branch"`
+	// SourceBranch and SourceCommit identify the source revision the train was rendered from
+	SourceBranch string   `json:"source_branch,omitempty"`
+	SourceCommit string   `json:"source_commit,omitempty"`
+	Targets      []string `json:"targets"`
+	Images       []string `json:"images,omitempty"`
+}
+
+type yamlIndex struct {
+	Trains []Entry `json:"trains"`
+}
+
+// Update returns content of the index file name with the entry of e.Train added or replaced.
+// Entries of other trains are kept. The format is chosen by the file extension: .md for markdown, .yaml or .yml for YAML.
+func Update(name string, content []byte, e Entry) ([]byte, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md":
+		return updateMarkdown(content, e), nil
+	case ".yaml", ".yml":
+		return updateYAML(content, e)
+	default:
+		return nil, fmt.Errorf("unsupported deployments index %s, expected a .md, .yaml or .yml file", name)
+	}
+}
+
+func updateYAML(content []byte, e Entry) ([]byte, error) {
+	var idx yamlIndex
+	if err := yaml.Unmarshal(content, &idx); err != nil {
+		return nil, fmt.Errorf("unable to parse deployments index: %w", err)
+	}
+	replaced := false
+	for i := range idx.Trains {
+		if idx.Trains[i].Train == e.Train {
+			idx.Trains[i] = e
+			replaced = true
+		}
+	}
+	if !replaced {
+		idx.Trains = append(idx.Trains, e)
+	}
+	sort.SliceStable(idx.Trains, func(i, j int) bool { return idx.Trains[i].Train < idx.Trains[j].Train })
+	b, err := yaml.Marshal(&idx)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte("# "+Header+"\n"), b...), nil
+}
+
+// markdown sections of trains are delimited by comments, so they can be replaced without parsing the markdown
+const (
+	beginMarker = "<!-- begin train "
+	endMarker   = "<!-- end train "
+)
+
+func updateMarkdown(content []byte, e Entry) []byte {
+	sections := make(map[string]string)
+	lines := strings.Split(string(content), "\n")
+	for i := 0; i < len(lines); i++ {
+		train, ok := marker(lines[i], beginMarker)
+		if !ok {
+			continue
+		}
+		var section []string
+		for i++; i < len(lines); i++ {
+			if end, ok := marker(lines[i], endMarker); ok && end == train {
+				break
+			}
+			section = append(section, lines[i])
+		}
+		sections[train] = strings.Join(section, "\n")
+	}
+	sections[e.Train] = markdownSection(e)
+
+	trains := make([]string, 0, len(sections))
+	for train := range sections {
+		trains = append(trains, train)
+	}
+	sort.Strings(trains)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Deployments\n\n<!-- %s -->\n", Header)
+	for _, train := range trains {
+		fmt.Fprintf(&buf, "\n%s%s -->\n%s\n%s%s -->\n", beginMarker, train, sections[train], endMarker, train)
+	}
+	return buf.Bytes()
+}
+
+func marker(line, prefix string) (string, bool) {
+	if !strings.HasPrefix(line, prefix) || !strings.HasSuffix(line, " -->") {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(line, prefix), " -->"), true
+}
+
+func markdownSection(e Entry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", e.Train)
+	fmt.Fprintf(&b, "- Branch: `%s`\n", e.Branch)
+	if e.SourceCommit != "" {
+		fmt.Fprintf(&b, "- Source: `%s` commit `%s`\n", e.SourceBranch, e.SourceCommit)
+	}
+	b.WriteString("\nTargets:\n")
+	for _, t := range e.Targets {
+		fmt.Fprintf(&b, "- `%s`\n", t)
+	}
+	if len(e.Images) > 0 {
+		b.WriteString("\nImages:\n")
+		for _, image := range e.Images {
+			fmt.Fprintf(&b, "- `%s`\n", image)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
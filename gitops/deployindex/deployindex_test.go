@@ -0,0 +1,73 @@
+package deployindex
+
+import (
+	"strings"
+	"testing"
+)
+
+var (
+	prod = Entry{Train: "prod", Branch: "deploy/prod", SourceBranch: "main", SourceCommit: "abc", Targets: []string{"//app:prod.gitops"}, Images: []string{"registry/app@sha256:1"}}
+	dev  = Entry{Train: "dev", Branch: "deploy/dev", SourceBranch: "main", SourceCommit: "abc", Targets: []string{"//app:dev.gitops"}}
+)
+
+func TestUpdateYAML(t *testing.T) {
+	b, err := Update("deployments.yaml", nil, prod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err = Update("deployments.yaml", b, dev); err != nil {
+		t.Fatal(err)
+	}
+	next := prod
+	next.SourceCommit = "def"
+	if b, err = Update("deployments.yaml", b, next); err != nil {
+		t.Fatal(err)
+	}
+	expected := `# Code generated by rules_gitops, DO NOT EDIT.
+trains:
+- branch: deploy/dev
+  source_branch: main
+  source_commit: abc
+  targets:
+  - //app:dev.gitops
+  train: dev
+- branch: deploy/prod
+  images:
+  - registry/app@sha256:1
+  source_branch: main
+  source_commit: def
+  targets:
+  - //app:prod.gitops
+  train: prod
+`
+	if string(b) != expected {
+		t.Errorf("unexpected index:\n%s", b)
+	}
+}
+
+func TestUpdateMarkdown(t *testing.T) {
+	b, err := Update("DEPLOYMENTS.md", nil, prod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err = Update("DEPLOYMENTS.md", b, dev); err != nil {
+		t.Fatal(err)
+	}
+	next := dev
+	next.SourceCommit = "def"
+	if b, err = Update("DEPLOYMENTS.md", b, next); err != nil {
+		t.Fatal(err)
+	}
+	expected := "# Deployments\n\n<!-- Code generated by rules_gitops, DO NOT EDIT. -->\n" +
+		"\n<!-- begin train dev -->\n## dev\n\n- Branch: `deploy/dev`\n- Source: `main` commit `def`\n\nTargets:\n- `//app:dev.gitops`\n<!-- end train dev -->\n" +
+		"\n<!-- begin train prod -->\n## prod\n\n- Branch: `deploy/prod`\n- Source: `main` commit `abc`\n\nTargets:\n- `//app:prod.gitops`\n\nImages:\n- `registry/app@sha256:1`\n<!-- end train prod -->\n"
+	if string(b) != expected {
+		t.Errorf("unexpected index:\n%s", b)
+	}
+}
+
+func TestUpdateUnsupported(t *testing.T) {
+	if _, err := Update("deployments.txt", nil, dev); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("expected unsupported format error, got %v", err)
+	}
+}
@@ -0,0 +1,299 @@
+// Package discovery finds gitops targets of a release branch with bazel cquery and groups them into release trains
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/analysis"
+	"github.com/fasterci/rules_gitops/gitops/bazel"
+	"github.com/fasterci/rules_gitops/gitops/blaze_query"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxStreamedTargetSize limits the size of a single ConfiguredTarget message in streamed cquery output
+const maxStreamedTargetSize = 64 << 20
+
+// DefaultStarlarkExpr prints label, deployment_branch and release_branch_prefix of a gitops target
+// from its GitopsArtifactsInfo provider, separated by tabs
+const DefaultStarlarkExpr = `"\t".join([str(target.label)] + [str(getattr(p, f, "")) for k, p in (providers(target) or {}).items() if k.endswith("%GitopsArtifactsInfo") for f in ["deployment_branch", "release_branch_prefix"]])`
+
+// Options configure bazel cquery execution
+type Options struct {
+	// Bazel runs cquery
+	Bazel *bazel.Command
+	// Dir is the bazel workspace. Default is the current directory
+	Dir string
+	// Streamed parses results one target at a time using --output=streamed_proto (bazel 7+) to bound memory usage
+	Streamed bool
+	// Starlark discovers gitops targets with --output=starlark evaluating StarlarkExpr instead of parsing proto output
+	Starlark     bool
+	StarlarkExpr string
+	// Stderr receives the bazel output. Default is to include it in errors
+	Stderr io.Writer
+}
+
+// Target is the part of a cquery result the tool needs.
+// Everything else is dropped as soon as the target is parsed to keep memory usage bounded.
+type Target struct {
+	Name string
+	// Attrs holds string values of requested attributes
+	Attrs map[string]string
+	// Configurable lists requested attributes set with select() that could not be resolved from the rule
+	Configurable []string
+}
+
+// Warning is a problem with a target that did not stop the query
+type Warning struct {
+	Target  string
+	Message string
+}
+
+func (w Warning) String() string {
+	return w.Target + ": " + w.Message
+}
+
+func newTarget(ct *analysis.ConfiguredTarget, attrs []string, warnings *[]Warning) Target {
+	rule := ct.GetTarget().GetRule()
+	t := Target{Name: rule.GetName()}
+	if len(attrs) == 0 {
+		return t
+	}
+	t.Attrs = make(map[string]string)
+	for _, name := range attrs {
+		v, err := rule.StringAttr(name)
+		if errors.Is(err, blaze_query.ErrConfigurable) {
+			t.Configurable = append(t.Configurable, name)
+			continue
+		}
+		if err != nil {
+			*warnings = append(*warnings, Warning{Target: t.Name, Message: err.Error()})
+			continue
+		}
+		t.Attrs[name] = v
+	}
+	return t
+}
+
+// Query returns the cquery expression matching gitops targets of releaseBranch in target patterns.
+// Patterns starting with - are excluded.
+func Query(releaseBranch string, patterns []string) string {
+	return fmt.Sprintf("attr(deployment_branch, \".+\", attr(release_branch_prefix, \"%s\", kind(gitops, %s)))", releaseBranch, bazel.TargetPatterns(patterns))
+}
+
+// Trains discovers gitops targets of releaseBranch in target patterns and groups them into release trains
+// by their deployment branch. Targets of a train are in the order cquery returned them.
+// Attributes set with select() are resolved for the build configuration.
+// Targets without a deployment branch are skipped with a single warning each.
+func (o *Options) Trains(ctx context.Context, releaseBranch string, patterns []string) (map[string][]string, []Warning, error) {
+	q := Query(releaseBranch, patterns)
+	var discovered []Target
+	var warnings []Warning
+	var err error
+	if o.Starlark {
+		discovered, err = o.CqueryStarlark(ctx, q, o.StarlarkExpr)
+	} else {
+		discovered, warnings, err = o.Cquery(ctx, q, "deployment_branch")
+		if err == nil {
+			var w []Warning
+			discovered, w, err = o.ResolveConfigurable(ctx, discovered)
+			warnings = append(warnings, w...)
+		}
+	}
+	if err != nil {
+		return nil, warnings, err
+	}
+	releaseTrains := make(map[string][]string)
+	for _, t := range discovered {
+		train, ok := t.Attrs["deployment_branch"]
+		if !ok {
+			// the attribute could not be read, which is already reported
+			continue
+		}
+		if train == "" {
+			warnings = append(warnings, Warning{Target: t.Name, Message: "no deployment branch reported, skipping"})
+			continue
+		}
+		releaseTrains[train] = append(releaseTrains[train], t.Name)
+	}
+	return releaseTrains, warnings, nil
+}
+
+// cquery starts bazel cquery args... returning its stdout.
+// wait returns the exit error with the bazel output if Stderr is not set.
+func (o *Options) cquery(ctx context.Context, args ...string) (stdout io.Reader, kill func(), wait func() error, err error) {
+	cmd := o.Bazel.CmdContext(ctx, "cquery", args...)
+	cmd.Dir = o.Dir
+	var stderr bytes.Buffer
+	cmd.Stderr = o.Stderr
+	if o.Stderr == nil {
+		cmd.Stderr = &stderr
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, nil, err
+	}
+	wait = func() error {
+		if err := cmd.Wait(); err != nil {
+			return fmt.Errorf("bazel cquery %s: %w\n%s", args[0], err, stderr.Bytes())
+		}
+		return nil
+	}
+	return out, func() { cmd.Process.Kill() }, wait, nil
+}
+
+// Cquery executes cquery and returns matching targets with the string values of attrs
+func (o *Options) Cquery(ctx context.Context, query string, attrs ...string) ([]Target, []Warning, error) {
+	log.Println("Executing bazel cquery ", query)
+	output := "--output=proto"
+	if o.Streamed {
+		output = "--output=streamed_proto"
+	}
+	stdout, kill, wait, err := o.cquery(ctx, query, output)
+	if err != nil {
+		return nil, nil, err
+	}
+	var targets []Target
+	var warnings []Warning
+	if o.Streamed {
+		err = bazel.ReadDelimited(stdout, maxStreamedTargetSize, func(b []byte) error {
+			ct := &analysis.ConfiguredTarget{}
+			if err := proto.Unmarshal(b, ct); err != nil {
+				return err
+			}
+			targets = append(targets, newTarget(ct, attrs, &warnings))
+			return nil
+		})
+	} else {
+		var buildproto []byte
+		buildproto, err = io.ReadAll(stdout)
+		if err == nil {
+			qr := &analysis.CqueryResult{}
+			if err = proto.Unmarshal(buildproto, qr); err == nil {
+				for _, ct := range qr.Results {
+					targets = append(targets, newTarget(ct, attrs, &warnings))
+				}
+			}
+		}
+	}
+	if err != nil {
+		kill()
+		wait()
+		return nil, warnings, fmt.Errorf("unable to parse cquery output: %w", err)
+	}
+	if err := wait(); err != nil {
+		return nil, warnings, err
+	}
+	return targets, warnings, nil
+}
+
+// ResolveConfigurable reads deployment_branch and release_branch_prefix of targets with attributes set with select()
+// from the GitopsArtifactsInfo provider of the configured target, which holds the values selected for the build configuration
+func (o *Options) ResolveConfigurable(ctx context.Context, targets []Target) ([]Target, []Warning, error) {
+	var pending []string
+	for _, t := range targets {
+		if len(t.Configurable) > 0 {
+			pending = append(pending, t.Name)
+		}
+	}
+	if len(pending) == 0 {
+		return targets, nil, nil
+	}
+	log.Println("resolving configurable attributes of", len(pending), "targets")
+	found, err := o.CqueryStarlark(ctx, "set('"+strings.Join(pending, "' '")+"')", DefaultStarlarkExpr)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolved := make(map[string]Target)
+	for _, t := range found {
+		// labels printed by starlark can start with @ or @@ unlike rule names in proto output
+		resolved[strings.TrimLeft(t.Name, "@")] = t
+	}
+	var warnings []Warning
+	for i, t := range targets {
+		if len(t.Configurable) == 0 {
+			continue
+		}
+		r, ok := resolved[strings.TrimLeft(t.Name, "@")]
+		if !ok {
+			warnings = append(warnings, Warning{Target: t.Name, Message: fmt.Sprintf("unable to resolve configurable attributes %v", t.Configurable)})
+			continue
+		}
+		for _, name := range t.Configurable {
+			if v, ok := r.Attrs[name]; ok {
+				targets[i].Attrs[name] = v
+			}
+		}
+	}
+	return targets, warnings, nil
+}
+
+// CqueryStarlark executes cquery with --output=starlark evaluating expr for every target.
+// expr has to print the target label, deployment branch and optionally release branch prefix separated by tabs.
+// Targets are returned with deployment_branch and release_branch_prefix attributes.
+func (o *Options) CqueryStarlark(ctx context.Context, query, expr string) ([]Target, error) {
+	log.Println("Executing bazel cquery ", query)
+	stdout, kill, wait, err := o.cquery(ctx, query, "--output=starlark", "--starlark:expr="+expr)
+	if err != nil {
+		return nil, err
+	}
+	var targets []Target
+	err = bazel.ReadTabSeparated(stdout, 2, func(fields []string) error {
+		t := Target{
+			Name:  fields[0],
+			Attrs: map[string]string{"deployment_branch": fields[1]},
+		}
+		if len(fields) > 2 {
+			t.Attrs["release_branch_prefix"] = fields[2]
+		}
+		targets = append(targets, t)
+		return nil
+	})
+	if err != nil {
+		kill()
+		wait()
+		return nil, fmt.Errorf("unable to parse starlark cquery output: %w", err)
+	}
+	if err := wait(); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// PushQuery selects image push targets gitops targets depend on by rule kind, name pattern
+// or attribute value in name=value format, like -gitops_dependencies_kind, _name and _attr
+type PushQuery struct {
+	Kinds []string
+	Names []string
+	Attrs []string
+}
+
+// Query returns the cquery expression matching push targets the gitops targets depend on
+func (p PushQuery) Query(targets []string) string {
+	// Create space separated set('//a' '//b' ... '//z') of targets.
+	// Target names need to be quoted to protect from + and other special characters
+	depsList := "set('" + strings.Join(targets, "' '") + "')"
+	var qv []string
+	for _, kind := range p.Kinds {
+		qv = append(qv, fmt.Sprintf("kind(%s, deps(%s))", kind, depsList))
+	}
+	for _, name := range p.Names {
+		qv = append(qv, fmt.Sprintf("filter(%s, deps(%s))", name, depsList))
+	}
+	for _, attr := range p.Attrs {
+		name, value, found := strings.Cut(attr, "=")
+		if !found {
+			value = ".*"
+		}
+		qv = append(qv, fmt.Sprintf("attr(%s, %s, deps(%s))", name, value, depsList))
+	}
+	return strings.Join(qv, " union ")
+}
@@ -0,0 +1,159 @@
+package discovery
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fasterci/rules_gitops/gitops/analysis"
+	"github.com/fasterci/rules_gitops/gitops/bazel"
+	"github.com/fasterci/rules_gitops/gitops/blaze_query"
+	"google.golang.org/protobuf/proto"
+)
+
+// gitopsTarget returns a configured gitops target with deployment_branch train,
+// set with a select() of two different values if train is empty
+func gitopsTarget(name, train string) *analysis.ConfiguredTarget {
+	attr := &blaze_query.Attribute{Name: proto.String("deployment_branch"), Type: blaze_query.Attribute_STRING.Enum()}
+	if train != "" {
+		attr.StringValue = proto.String(train)
+	} else {
+		attr.SelectorList = &blaze_query.Attribute_SelectorList{
+			Type: blaze_query.Attribute_STRING.Enum(),
+			Elements: []*blaze_query.Attribute_Selector{{
+				Entries: []*blaze_query.Attribute_SelectorEntry{
+					{Label: proto.String("//conditions:default"), StringValue: proto.String("dev")},
+					{Label: proto.String("//:prod"), StringValue: proto.String("prod")},
+				},
+			}},
+		}
+	}
+	rule := &blaze_query.Rule{Name: proto.String(name), RuleClass: proto.String("gitops"), Attribute: []*blaze_query.Attribute{attr}}
+	return &analysis.ConfiguredTarget{Target: &blaze_query.Target{Type: blaze_query.Target_RULE.Enum(), Rule: rule}}
+}
+
+// fakeBazel answers cquery with proto.pb, streamed.pb or starlark.txt of dir depending on the output format,
+// and with resolved.txt for starlark queries of explicit target sets
+func fakeBazel(t *testing.T, dir string) *bazel.Command {
+	t.Helper()
+	targets := []*analysis.ConfiguredTarget{
+		gitopsTarget("//app:prod.gitops", "prod"),
+		gitopsTarget("//app:select.gitops", ""),
+		gitopsTarget("//app:api.gitops", "prod"),
+	}
+	b, err := proto.Marshal(&analysis.CqueryResult{Results: targets})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var streamed []byte
+	for _, ct := range targets {
+		m, err := proto.Marshal(ct)
+		if err != nil {
+			t.Fatal(err)
+		}
+		streamed = binary.AppendUvarint(streamed, uint64(len(m)))
+		streamed = append(streamed, m...)
+	}
+	files := map[string]string{
+		"proto.pb":     string(b),
+		"streamed.pb":  string(streamed),
+		"starlark.txt": "@@//app:prod.gitops\tprod\tmaster\n//app:dev.gitops\tdev\n",
+		"resolved.txt": "@//app:select.gitops\tdev\tmaster\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	script := `#!/bin/sh
+[ "$1" = cquery ] || exit 2
+case "$3" in
+--output=proto) cat proto.pb ;;
+--output=streamed_proto) cat streamed.pb ;;
+--output=starlark)
+  case "$2" in
+  set*) cat resolved.txt ;;
+  *) cat starlark.txt ;;
+  esac ;;
+*) echo "unknown output $3" >&2; exit 2 ;;
+esac
+`
+	bin := filepath.Join(dir, "bazel")
+	if err := os.WriteFile(bin, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return &bazel.Command{Bin: bin}
+}
+
+func TestTrains(t *testing.T) {
+	dir := t.TempDir()
+	bzl := fakeBazel(t, dir)
+	ctx := context.Background()
+	expected := map[string][]string{
+		"prod": {"//app:prod.gitops", "//app:api.gitops"},
+		"dev":  {"//app:select.gitops"},
+	}
+	for _, streamed := range []bool{false, true} {
+		o := &Options{Bazel: bzl, Dir: dir, Streamed: streamed}
+		releaseTrains, warnings, err := o.Trains(ctx, "master", []string{"//app/...", "-//app:old.gitops"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(releaseTrains, expected) {
+			t.Errorf("streamed %v: unexpected trains %v", streamed, releaseTrains)
+		}
+		if len(warnings) != 0 {
+			t.Errorf("streamed %v: unexpected warnings %v", streamed, warnings)
+		}
+	}
+
+	o := &Options{Bazel: bzl, Dir: dir, Starlark: true, StarlarkExpr: DefaultStarlarkExpr}
+	releaseTrains, _, err := o.Trains(ctx, "master", []string{"//..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(releaseTrains, map[string][]string{"prod": {"//app:prod.gitops"}, "dev": {"//app:dev.gitops"}}) {
+		t.Errorf("unexpected starlark trains %v", releaseTrains)
+	}
+
+	// configurable attributes the provider does not report are skipped with a warning
+	if err := os.WriteFile(filepath.Join(dir, "resolved.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	releaseTrains, warnings, err := (&Options{Bazel: bzl, Dir: dir}).Trains(ctx, "master", []string{"//..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := releaseTrains["dev"]; ok || len(warnings) != 1 || warnings[0].Target != "//app:select.gitops" {
+		t.Errorf("unexpected trains %v and warnings %v", releaseTrains, warnings)
+	}
+}
+
+func TestCqueryError(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "bazel")
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\necho 'no such package' >&2\nexit 7\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err := (&Options{Bazel: &bazel.Command{Bin: bin}, Dir: dir}).Trains(context.Background(), "master", nil)
+	if err == nil || !strings.Contains(err.Error(), "no such package") {
+		t.Errorf("expected the bazel error output, got %v", err)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	q := Query("release", []string{"//app/...", "-//app/legacy/..."})
+	if q != `attr(deployment_branch, ".+", attr(release_branch_prefix, "release", kind(gitops, //app/... except //app/legacy/...)))` {
+		t.Errorf("unexpected query %s", q)
+	}
+	q = PushQuery{Kinds: []string{"push_oci"}, Names: []string{".*push"}, Attrs: []string{"tags=push", "pushable"}}.Query([]string{"//a:b", "//c:d+e"})
+	expected := "kind(push_oci, deps(set('//a:b' '//c:d+e'))) union filter(.*push, deps(set('//a:b' '//c:d+e'))) union " +
+		"attr(tags, push, deps(set('//a:b' '//c:d+e'))) union attr(pushable, .*, deps(set('//a:b' '//c:d+e')))"
+	if q != expected {
+		t.Errorf("unexpected push query %s", q)
+	}
+}
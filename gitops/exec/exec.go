@@ -13,28 +13,132 @@ package exec
 
 import (
 	"log"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 )
 
+// Command returns exec.Cmd for name arg...
+// On Windows batch files are executed through cmd.exe as CreateProcess can't run them directly.
+func Command(name string, arg ...string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".bat", ".cmd":
+			return exec.Command("cmd.exe", append([]string{"/c", name}, arg...)...)
+		}
+	}
+	return exec.Command(name, arg...)
+}
+
+// Runner executes name arg... in dir with the environment env and returns the combined output.
+// A nil env inherits the process environment. Tests and embedders replace it to avoid running processes.
+type Runner interface {
+	Run(dir string, env []string, name string, arg ...string) (output string, err error)
+}
+
+// RunnerFunc adapts a function to Runner
+type RunnerFunc func(dir string, env []string, name string, arg ...string) (output string, err error)
+
+// Run calls f
+func (f RunnerFunc) Run(dir string, env []string, name string, arg ...string) (string, error) {
+	return f(dir, env, name, arg...)
+}
+
+// OutputRunner is a Runner that can also return the standard output of a command alone,
+// for commands whose output is content rather than messages
+type OutputRunner interface {
+	Runner
+	Output(dir string, env []string, name string, arg ...string) (stdout string, err error)
+}
+
+type defaultRunner struct{}
+
+func (defaultRunner) Run(dir string, env []string, name string, arg ...string) (string, error) {
+	return ExEnv(dir, env, name, arg...)
+}
+
+func (defaultRunner) Output(dir string, env []string, name string, arg ...string) (string, error) {
+	log.Println("executing:", name, strings.Join(arg, " "))
+	cmd := Command(name, arg...)
+	if dir != "" {
+		cmd.Dir = dir
+	}
+	cmd.Env = env
+	b, err := cmd.Output()
+	return string(b), err
+}
+
+// Default executes commands with ExEnv
+var Default Runner = defaultRunner{}
+
+// EnvRunner runs commands with Runner, Default if nil, adding Env to their environment.
+// It scopes variables like credentials to the commands that need them instead of the whole process.
+type EnvRunner struct {
+	Runner Runner
+	Env    []string
+}
+
+// Base returns the runner commands are passed on to
+func (e EnvRunner) Base() Runner {
+	if e.Runner == nil {
+		return Default
+	}
+	return e.Runner
+}
+
+func (e EnvRunner) environ(env []string) []string {
+	if len(e.Env) == 0 {
+		return env
+	}
+	if env == nil {
+		env = os.Environ()
+	}
+	return append(env[:len(env):len(env)], e.Env...)
+}
+
+// Run runs the command with Env added to env, or to the process environment if env is nil
+func (e EnvRunner) Run(dir string, env []string, name string, arg ...string) (string, error) {
+	return e.Base().Run(dir, e.environ(env), name, arg...)
+}
+
+// Output is Run returning the standard output only if Runner is an OutputRunner
+func (e EnvRunner) Output(dir string, env []string, name string, arg ...string) (string, error) {
+	if or, ok := e.Base().(OutputRunner); ok {
+		return or.Output(dir, e.environ(env), name, arg...)
+	}
+	return e.Run(dir, env, name, arg...)
+}
+
 // Ex is a shortcut for executing the command in specified dir
 func Ex(dir, name string, arg ...string) (output string, err error) {
+	return ExEnv(dir, nil, name, arg...)
+}
+
+// ExEnv executes the command in specified dir with the environment env. A nil env inherits the process environment.
+func ExEnv(dir string, env []string, name string, arg ...string) (output string, err error) {
 	log.Println("executing:", name, strings.Join(arg, " "))
-	cmd := exec.Command(name, arg...)
+	cmd := Command(name, arg...)
 	if dir != "" {
 		cmd.Dir = dir
 	}
+	cmd.Env = env
 	b, err := cmd.CombinedOutput()
 	log.Printf("%s", string(b))
 	return string(b), err
 }
 
+// Fatalf terminates the process when a Must function fails.
+// Programs replace it to report the failure before exiting.
+var Fatalf = log.Fatalf
+
 // Mustex executes the command name arg... in directory dir
 // it will exit with fatal error if execution was not successful
 func Mustex(dir, name string, arg ...string) string {
 	ret, err := Ex(dir, name, arg...)
 	if err != nil {
-		log.Fatalf("ERROR: %s", err)
+		Fatalf("ERROR: %s", err)
 	}
 	return ret
 
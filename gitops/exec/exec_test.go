@@ -0,0 +1,31 @@
+package exec
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEnvRunner(t *testing.T) {
+	r := EnvRunner{Env: []string{"GITOPS_GIT_PUSH_PASSWORD=secret"}}
+	out, err := r.Run("", nil, "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "GITOPS_GIT_PUSH_PASSWORD=secret") || !strings.Contains(out, "PATH=") {
+		t.Errorf("expected the process environment with the added variable:\n%s", out)
+	}
+	if _, ok := os.LookupEnv("GITOPS_GIT_PUSH_PASSWORD"); ok {
+		t.Error("the variable leaked into the process environment")
+	}
+	out, err = r.Output("", []string{"A=1"}, "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "A=1\nGITOPS_GIT_PUSH_PASSWORD=secret\n" {
+		t.Errorf("expected the given environment with the added variable, got:\n%s", out)
+	}
+	if out, _ := Default.Run("", nil, "env"); strings.Contains(out, "GITOPS_GIT_PUSH_PASSWORD") {
+		t.Errorf("commands of other runners got the variable:\n%s", out)
+	}
+}
@@ -0,0 +1,96 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// baseEnv are variables every process needs to run that don't carry credentials
+var baseEnv = []string{"PATH", "LANG", "LC_ALL", "TZ", "TMPDIR"}
+
+// Sandbox describes how untrusted binaries are executed
+type Sandbox struct {
+	// RunUnder is a command prefix the binary is executed with, like ["firejail", "--net=none", "--"]
+	RunUnder []string
+	// CleanEnv runs binaries with only PATH, locale, TZ, TMPDIR and AllowEnv variables of the process environment
+	// and HOME pointing to an empty directory, so credentials passed through the environment or stored in HOME
+	// are not visible
+	CleanEnv bool
+	// AllowEnv lists names of additional variables passed with CleanEnv
+	AllowEnv []string
+	// Home is the HOME directory used with CleanEnv
+	Home string
+	// Runner executes the binaries. Default is used if nil
+	Runner Runner
+}
+
+// ParseRunUnder splits a command prefix like "firejail --net=none --" on whitespace.
+// Single and double quotes group words containing spaces.
+func ParseRunUnder(s string) ([]string, error) {
+	var words []string
+	var cur strings.Builder
+	inWord := false
+	var quote rune
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %q", s)
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
+
+// Env returns the environment of sandboxed binaries built from environ, or nil to inherit the process environment
+func (s *Sandbox) Env(environ []string) []string {
+	if s == nil || !s.CleanEnv {
+		return nil
+	}
+	allowed := make(map[string]bool)
+	for _, k := range append(append([]string(nil), baseEnv...), s.AllowEnv...) {
+		allowed[k] = true
+	}
+	env := []string{"HOME=" + s.Home}
+	for _, kv := range environ {
+		k, _, _ := strings.Cut(kv, "=")
+		if allowed[k] && k != "HOME" {
+			env = append(env, kv)
+		}
+	}
+	return env
+}
+
+// Ex executes name arg... in dir under the sandbox
+func (s *Sandbox) Ex(dir, name string, arg ...string) (string, error) {
+	if s != nil && len(s.RunUnder) > 0 {
+		arg = append(append(append([]string(nil), s.RunUnder[1:]...), name), arg...)
+		name = s.RunUnder[0]
+	}
+	runner := Default
+	if s != nil && s.Runner != nil {
+		runner = s.Runner
+	}
+	return runner.Run(dir, s.Env(os.Environ()), name, arg...)
+}
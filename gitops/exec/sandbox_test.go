@@ -0,0 +1,52 @@
+package exec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRunUnder(t *testing.T) {
+	tests := map[string][]string{
+		"":                                  nil,
+		"firejail --net=none --":            {"firejail", "--net=none", "--"},
+		`bwrap --bind / / --setenv A "b c"`: {"bwrap", "--bind", "/", "/", "--setenv", "A", "b c"},
+		`sh -c 'exec "$@"' --`:              {"sh", "-c", `exec "$@"`, "--"},
+	}
+	for in, expected := range tests {
+		got, err := ParseRunUnder(in)
+		if err != nil || !reflect.DeepEqual(got, expected) {
+			t.Errorf("ParseRunUnder(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := ParseRunUnder(`sh -c 'oops`); err == nil {
+		t.Error("expected an error for an unterminated quote")
+	}
+}
+
+func TestSandboxEnv(t *testing.T) {
+	environ := []string{"PATH=/bin", "HOME=/root", "GITHUB_TOKEN=secret", "HELM_CACHE_HOME=/cache", "TZ=UTC"}
+	if env := (&Sandbox{}).Env(environ); env != nil {
+		t.Errorf("expected inherited environment, got %v", env)
+	}
+	s := &Sandbox{CleanEnv: true, AllowEnv: []string{"HELM_CACHE_HOME"}, Home: "/tmp/home"}
+	expected := []string{"HOME=/tmp/home", "PATH=/bin", "HELM_CACHE_HOME=/cache", "TZ=UTC"}
+	if env := s.Env(environ); !reflect.DeepEqual(env, expected) {
+		t.Errorf("unexpected environment %v", env)
+	}
+}
+
+func TestSandboxEx(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "secret")
+	s := &Sandbox{RunUnder: []string{"env", "WRAPPED=1"}, CleanEnv: true, Home: t.TempDir()}
+	out, err := s.Ex("", "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "WRAPPED=1") {
+		t.Errorf("binary was not executed under the wrapper:\n%s", out)
+	}
+	if strings.Contains(out, "GITHUB_TOKEN") {
+		t.Errorf("credentials leaked into the sandbox:\n%s", out)
+	}
+}
@@ -0,0 +1,65 @@
+// Package freeze reads deploy freeze lists
+package freeze
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// All freezes every release train
+const All = "*"
+
+// List maps frozen release trains to the reason of the freeze
+type List map[string]string
+
+// Parse reads a freeze list. Every line names a frozen release train, or * for all trains,
+// optionally followed by a colon and the reason. Empty lines and lines starting with # are ignored.
+func Parse(r io.Reader) (List, error) {
+	l := make(List)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		train, reason, _ := strings.Cut(line, ":")
+		l[strings.TrimSpace(train)] = strings.TrimSpace(reason)
+	}
+	return l, scanner.Err()
+}
+
+// Fetch reads a freeze list from url
+func Fetch(url string) (List, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		// no freeze list published
+		return List{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return Parse(resp.Body)
+}
+
+// Merge adds entries of other to l
+func (l List) Merge(other List) {
+	for k, v := range other {
+		l[k] = v
+	}
+}
+
+// Frozen reports whether train is frozen and why
+func (l List) Frozen(train string) (reason string, frozen bool) {
+	if reason, ok := l[train]; ok {
+		return reason, true
+	}
+	reason, frozen = l[All]
+	return reason, frozen
+}
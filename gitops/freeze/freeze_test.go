@@ -0,0 +1,54 @@
+package freeze
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	l, err := Parse(strings.NewReader(`
+# frozen trains
+prod: incident INC-123
+staging
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reason, ok := l.Frozen("prod"); !ok || reason != "incident INC-123" {
+		t.Errorf("Frozen(prod) = %q, %v", reason, ok)
+	}
+	if reason, ok := l.Frozen("staging"); !ok || reason != "" {
+		t.Errorf("Frozen(staging) = %q, %v", reason, ok)
+	}
+	if _, ok := l.Frozen("dev"); ok {
+		t.Error("dev is not frozen")
+	}
+	l.Merge(List{All: "holidays"})
+	if reason, ok := l.Frozen("dev"); !ok || reason != "holidays" {
+		t.Errorf("Frozen(dev) = %q, %v after freezing all trains", reason, ok)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/freeze" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, "*: end of year freeze")
+	}))
+	defer ts.Close()
+	l, err := Fetch(ts.URL + "/freeze")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reason, ok := l.Frozen("prod"); !ok || reason != "end of year freeze" {
+		t.Errorf("Frozen(prod) = %q, %v", reason, ok)
+	}
+	if l, err := Fetch(ts.URL + "/missing"); err != nil || len(l) != 0 {
+		t.Errorf("Fetch(missing) = %v, %v", l, err)
+	}
+}
@@ -0,0 +1,164 @@
+// Package fsys is a writable extension of io/fs, so code generating files can run against memory in tests
+package fsys
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing/fstest"
+)
+
+// FS is a file system that can be written. Names are slash separated and unrooted, like in io/fs.
+type FS interface {
+	fs.FS
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(name string, perm fs.FileMode) error
+	// Remove removes a file or an empty directory
+	Remove(name string) error
+}
+
+// OS returns the FS of the directory tree rooted at dir
+func OS(dir string) FS {
+	return osFS{FS: os.DirFS(dir), dir: dir}
+}
+
+type osFS struct {
+	fs.FS
+	dir string
+}
+
+func (o osFS) path(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return filepath.Join(o.dir, filepath.FromSlash(name)), nil
+}
+
+func (o osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	p, err := o.path("write", name)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, perm)
+}
+
+func (o osFS) MkdirAll(name string, perm fs.FileMode) error {
+	p, err := o.path("mkdir", name)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(p, perm)
+}
+
+func (o osFS) Remove(name string) error {
+	p, err := o.path("remove", name)
+	if err != nil {
+		return err
+	}
+	return os.Remove(p)
+}
+
+// Mem is an in-memory FS. Parent directories of files exist implicitly. It is safe for concurrent use.
+type Mem struct {
+	mu    sync.Mutex
+	files fstest.MapFS
+}
+
+// NewMem returns a Mem holding files with their content
+func NewMem(files map[string]string) *Mem {
+	m := &Mem{files: make(fstest.MapFS)}
+	for name, content := range files {
+		m.files[name] = &fstest.MapFile{Data: []byte(content), Mode: 0644}
+	}
+	return m
+}
+
+// snapshot copies the file map, so files opened for reading are not affected by later writes
+func (m *Mem) snapshot() fstest.MapFS {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := make(fstest.MapFS, len(m.files))
+	for name, f := range m.files {
+		c[name] = f
+	}
+	return c
+}
+
+// Open implements fs.FS
+func (m *Mem) Open(name string) (fs.File, error) {
+	return m.snapshot().Open(name)
+}
+
+// ReadDir implements fs.ReadDirFS
+func (m *Mem) ReadDir(name string) ([]fs.DirEntry, error) {
+	return m.snapshot().ReadDir(name)
+}
+
+// ReadFile implements fs.ReadFileFS
+func (m *Mem) ReadFile(name string) ([]byte, error) {
+	return m.snapshot().ReadFile(name)
+}
+
+// WriteFile implements FS
+func (m *Mem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[name] = &fstest.MapFile{Data: append([]byte(nil), data...), Mode: perm}
+	return nil
+}
+
+// MkdirAll implements FS
+func (m *Mem) MkdirAll(name string, perm fs.FileMode) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if f, ok := m.files[name]; ok && !f.Mode.IsDir() {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrExist}
+	}
+	m.files[name] = &fstest.MapFile{Mode: fs.ModeDir | perm}
+	return nil
+}
+
+// Remove implements FS
+func (m *Mem) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.files[name]
+	if !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	if f.Mode.IsDir() {
+		for other := range m.files {
+			if path.Dir(other) == name {
+				return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrExist}
+			}
+		}
+	}
+	delete(m.files, name)
+	return nil
+}
+
+// Files returns names of all files in lexical order
+func (m *Mem) Files() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var names []string
+	for name, f := range m.files {
+		if !f.Mode.IsDir() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,56 @@
+package fsys
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testFS(t *testing.T, fsys FS) {
+	t.Helper()
+	if err := fsys.MkdirAll("cloud/prod", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.WriteFile("cloud/prod/a.yaml", []byte("a: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	b, err := fs.ReadFile(fsys, "cloud/prod/a.yaml")
+	if err != nil || string(b) != "a: 1\n" {
+		t.Errorf("ReadFile() = %q, %v", b, err)
+	}
+	entries, err := fs.ReadDir(fsys, "cloud/prod")
+	if err != nil || len(entries) != 1 || entries[0].Name() != "a.yaml" {
+		t.Errorf("ReadDir() = %v, %v", entries, err)
+	}
+	if err := fsys.Remove("cloud/prod"); err == nil {
+		t.Error("expected an error removing a non-empty directory")
+	}
+	if err := fsys.Remove("cloud/prod/a.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.Remove("cloud/prod/a.yaml"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected ErrNotExist, got %v", err)
+	}
+	if err := fsys.WriteFile("../escape.yaml", nil, 0644); err == nil {
+		t.Error("expected an invalid path error")
+	}
+}
+
+func TestOS(t *testing.T) {
+	dir := t.TempDir()
+	testFS(t, OS(dir))
+	if _, err := os.Stat(filepath.Join(dir, "cloud", "prod")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMem(t *testing.T) {
+	m := NewMem(map[string]string{"cloud/dev/b.yaml": "b: 1\n"})
+	testFS(t, m)
+	if files := m.Files(); !reflect.DeepEqual(files, []string{"cloud/dev/b.yaml"}) {
+		t.Errorf("Files() = %v", files)
+	}
+}
@@ -0,0 +1,88 @@
+// Package gc finds manifest directories of the gitops repository no gitops target renders anymore
+package gc
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/commitmsg"
+)
+
+// Orphans returns the topmost directories of existing files without any of the produced files in them or below them.
+// Paths are slash separated and relative to the same root, which is never returned.
+func Orphans(existing, produced []string) []string {
+	// live directories are the ones with produced files in them or below them
+	live := map[string]bool{".": true}
+	for _, f := range produced {
+		for dir := path.Dir(path.Clean(f)); !live[dir]; dir = path.Dir(dir) {
+			live[dir] = true
+		}
+	}
+	orphans := make(map[string]bool)
+	for _, f := range existing {
+		dir := path.Dir(path.Clean(f))
+		if live[dir] {
+			continue
+		}
+		// the topmost directory that is not live
+		for !live[path.Dir(dir)] {
+			dir = path.Dir(dir)
+		}
+		orphans[dir] = true
+	}
+	dirs := make([]string, 0, len(orphans))
+	for dir := range orphans {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// Unowned returns files that are not at or below any of the owned paths.
+// Tool generated files like Namespace manifests are owned by the tool rather than by a gitops target and are never orphaned.
+func Unowned(files, owned []string) []string {
+	var unowned []string
+	for _, f := range files {
+		f = path.Clean(f)
+		found := false
+		for _, o := range owned {
+			o = path.Clean(o)
+			if f == o || strings.HasPrefix(f, o+"/") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unowned = append(unowned, f)
+		}
+	}
+	return unowned
+}
+
+// Owned returns the directories of dirs written by deployment commits of releaseBranch and of no other release branch,
+// by the commit messages history returns for a directory. Directories shared with other release branches rendering
+// into the same gitops path, and ones no deployment commit wrote, are left alone.
+func Owned(dirs []string, releaseBranch string, history func(dir string) ([]string, error)) ([]string, error) {
+	var owned []string
+	for _, dir := range dirs {
+		msgs, err := history(dir)
+		if err != nil {
+			return nil, err
+		}
+		ours, foreign := false, false
+		for _, msg := range msgs {
+			for _, b := range commitmsg.ExtractReleaseBranches(msg) {
+				if b == releaseBranch {
+					ours = true
+				} else {
+					foreign = true
+				}
+			}
+		}
+		if ours && !foreign {
+			owned = append(owned, dir)
+		}
+	}
+	return owned, nil
+}
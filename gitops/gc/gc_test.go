@@ -0,0 +1,86 @@
+package gc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fasterci/rules_gitops/gitops/commitmsg"
+)
+
+func TestOrphans(t *testing.T) {
+	existing := []string{
+		"cloud/prod/app/deployment.yaml",
+		"cloud/prod/app/service.yaml",
+		"cloud/prod/old/deployment.yaml",
+		"cloud/prod/old/jobs/cron.yaml",
+		"cloud/dev/removed/deployment.yaml",
+		"cloud/dev/removed/db/statefulset.yaml",
+		"legacy/service/deployment.yaml",
+		"top.yaml",
+	}
+	produced := []string{
+		"cloud/prod/app/deployment.yaml",
+		"cloud/prod/app/service.yaml",
+		"cloud/prod/new/deployment.yaml",
+		"cloud/dev/kept/deployment.yaml",
+	}
+	expected := []string{"cloud/dev/removed", "cloud/prod/old", "legacy"}
+	if o := Orphans(existing, produced); !reflect.DeepEqual(o, expected) {
+		t.Errorf("unexpected orphans %v", o)
+	}
+	if o := Orphans(existing, existing); len(o) != 0 {
+		t.Errorf("expected no orphans, got %v", o)
+	}
+}
+
+func TestUnowned(t *testing.T) {
+	existing := []string{
+		"cloud/prod/app/deployment.yaml",
+		"cloud/namespaces/prod.yaml",
+		"cloud/namespaces/dev.yaml",
+		"cloud/namespaces-old/legacy.yaml",
+		"cloud/deployments.yaml",
+	}
+	produced := []string{"cloud/prod/app/deployment.yaml"}
+	files := Unowned(existing, []string{"cloud/namespaces", "cloud/deployments.yaml"})
+	expected := []string{"cloud/prod/app/deployment.yaml", "cloud/namespaces-old/legacy.yaml"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("unexpected unowned files %v", files)
+	}
+	if o := Orphans(files, produced); !reflect.DeepEqual(o, []string{"cloud/namespaces-old"}) {
+		t.Errorf("unexpected orphans %v", o)
+	}
+}
+
+func TestOwnedWithSecondReleaseBranch(t *testing.T) {
+	// release/1.x renders into the same gitops path as master, its targets are not discovered for master
+	existing := []string{
+		"cloud/prod/app/deployment.yaml",
+		"cloud/prod/old/deployment.yaml",
+		"cloud/stable/app/deployment.yaml",
+		"cloud/shared/app/deployment.yaml",
+		"manual/configmap.yaml",
+	}
+	produced := []string{"cloud/prod/app/deployment.yaml"}
+	master := commitmsg.GenerateSubject("master", "master", "abc123")
+	stable := commitmsg.GenerateSubject("release/1.x", "release/1.x", "def456")
+	history := map[string][]string{
+		"cloud/prod/old": {master, master},
+		"cloud/stable":   {stable},
+		"cloud/shared":   {stable, master},
+		"manual":         {"Add a config map"},
+	}
+	orphans := Orphans(existing, produced)
+	if expected := []string{"cloud/prod/old", "cloud/shared", "cloud/stable", "manual"}; !reflect.DeepEqual(orphans, expected) {
+		t.Fatalf("unexpected orphans %v", orphans)
+	}
+	owned, err := Owned(orphans, "master", func(dir string) ([]string, error) {
+		return history[dir], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"cloud/prod/old"}; !reflect.DeepEqual(owned, expected) {
+		t.Errorf("unexpected owned orphans %v", owned)
+	}
+}
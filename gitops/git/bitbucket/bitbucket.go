@@ -16,18 +16,29 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
 )
 
 var (
 	apiEndpoint       = flag.String("bitbucket_api_pr_endpoint", "https://bitbucket.tubemogul.info/rest/api/1.0/projects/TM/repos/repo/pull-requests", "bitbucket pull request api endpoint with project and repo")
 	bitbucketUser     = flag.String("bitbucket_user", os.Getenv("BITBUCKET_USER"), "bitbucket api user")
 	bitbucketPassword = flag.String("bitbucket_password", os.Getenv("BITBUCKET_PASSWORD"), "bitbucket api user password")
+	pushUser          = flag.String("bitbucket_push_user", os.Getenv("BITBUCKET_PUSH_USER"), "user for git clone, fetch and push, if different from -bitbucket_user used to create PRs")
+	pushPassword      = flag.String("bitbucket_push_password", os.Getenv("BITBUCKET_PUSH_PASSWORD"), "password or access token of -bitbucket_push_user")
 )
 
+// HTTPClient sends API requests. Callers replace it to configure TLS, proxy and timeouts of the API client
+var HTTPClient = http.DefaultClient
+
 type project struct {
 	Key string `json:"key,omitempty"`
 }
@@ -62,6 +73,33 @@ type pullrequest struct {
 	Reviewers   []account            `json:"reviewers,omitempty"`
 }
 
+type ref struct {
+	DisplayID string `json:"displayId"`
+}
+
+type link struct {
+	Href string `json:"href"`
+}
+
+// pullrequestInfo is a pull request returned by the api
+type pullrequestInfo struct {
+	ID          int    `json:"id"`
+	Version     int    `json:"version"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	FromRef     ref    `json:"fromRef"`
+	ToRef       ref    `json:"toRef"`
+	Links       struct {
+		Self []link `json:"self"`
+	} `json:"links"`
+}
+
+type pullrequestPage struct {
+	Values        []pullrequestInfo `json:"values"`
+	IsLastPage    bool              `json:"isLastPage"`
+	NextPageStart int               `json:"nextPageStart"`
+}
+
 // CreatePR creates a pull request using branch names from and to
 func CreatePR(from, to, title, body string) error {
 	repo := repository{
@@ -95,7 +133,7 @@ func CreatePR(from, to, title, body string) error {
 	}
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	req.SetBasicAuth(*bitbucketUser, *bitbucketPassword)
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := HTTPClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("Unable to send CreatePR request: %w", err)
 	}
@@ -115,3 +153,112 @@ func CreatePR(from, to, title, body string) error {
 	}
 	return fmt.Errorf("Unrecognized bitbucket response %d", resp.StatusCode)
 }
+
+// call sends an api request with the JSON encoding of in, if not nil, and decodes the response into out, if not nil
+func call(method, endpoint string, in, out interface{}) error {
+	var reqBody io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewBuffer(b)
+	}
+	req, err := http.NewRequest(method, endpoint, reqBody)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	req.SetBasicAuth(*bitbucketUser, *bitbucketPassword)
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		responseBody, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s: bitbucket response %s: %s", method, endpoint, resp.Status, responseBody)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// OpenPRs returns open pull requests into branch to
+func OpenPRs(to string) ([]git.PullRequest, error) {
+	var prs []git.PullRequest
+	start := 0
+	for {
+		q := url.Values{}
+		q.Set("state", "OPEN")
+		q.Set("direction", "INCOMING")
+		q.Set("at", "refs/heads/"+to)
+		q.Set("start", strconv.Itoa(start))
+		var page pullrequestPage
+		if err := call("GET", *apiEndpoint+"?"+q.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		for _, pr := range page.Values {
+			p := git.PullRequest{
+				ID:     pr.ID,
+				Source: pr.FromRef.DisplayID,
+				Target: pr.ToRef.DisplayID,
+				Title:  pr.Title,
+				Body:   pr.Description,
+			}
+			if len(pr.Links.Self) > 0 {
+				p.URL = pr.Links.Self[0].Href
+			}
+			prs = append(prs, p)
+		}
+		if page.IsLastPage || len(page.Values) == 0 {
+			return prs, nil
+		}
+		start = page.NextPageStart
+	}
+}
+
+// ClosePR declines pr leaving comment on it
+func ClosePR(pr git.PullRequest, comment string) error {
+	endpoint := fmt.Sprintf("%s/%d", *apiEndpoint, pr.ID)
+	if comment != "" {
+		if err := call("POST", endpoint+"/comments", map[string]string{"text": comment}, nil); err != nil {
+			return err
+		}
+	}
+	// decline requires the current version of the pull request
+	var info pullrequestInfo
+	if err := call("GET", endpoint, nil, &info); err != nil {
+		return err
+	}
+	return call("POST", fmt.Sprintf("%s/decline?version=%d", endpoint, info.Version), struct{}{}, nil)
+}
+
+// Check verifies -bitbucket_user can read pull requests of the repository.
+// Bitbucket does not report write permissions of the user, they are verified when the PR is created.
+func Check() error {
+	var page pullrequestPage
+	if err := call("GET", *apiEndpoint+"?limit=1", nil, &page); err != nil {
+		return fmt.Errorf("unable to list pull requests, check -bitbucket_api_pr_endpoint, -bitbucket_user and -bitbucket_password: %w", err)
+	}
+	return nil
+}
+
+// DiffURL returns a link to the diff of path in the comparison of branch from against branch to.
+// The repository web location is derived from -bitbucket_api_pr_endpoint.
+func DiffURL(from, to, path string) string {
+	web := strings.TrimSuffix(*apiEndpoint, "/pull-requests")
+	web = strings.Replace(web, "/rest/api/1.0/", "/", 1)
+	q := url.Values{}
+	q.Set("sourceBranch", "refs/heads/"+from)
+	q.Set("targetBranch", "refs/heads/"+to)
+	return fmt.Sprintf("%s/compare/diff?%s#%s", web, q.Encode(), path)
+}
+
+// PushCredentials returns -bitbucket_push_user and -bitbucket_push_password
+func PushCredentials() (user, password string) {
+	return *pushUser, *pushPassword
+}
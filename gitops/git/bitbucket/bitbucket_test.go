@@ -16,7 +16,10 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
 )
 
 func TestCreatePRRemote(t *testing.T) {
@@ -55,3 +58,56 @@ func TestCreatePRNew(t *testing.T) {
 		t.Error("Unexpected request body: ", string(buf))
 	}
 }
+
+func TestDiffURL(t *testing.T) {
+	expected := "https://bitbucket.tubemogul.info/projects/TM/repos/repo/compare/diff?sourceBranch=refs%2Fheads%2Fdeploy%2Fprod&targetBranch=refs%2Fheads%2Fmaster#cloud/prod/app.yaml"
+	if u := DiffURL("deploy/prod", "master", "cloud/prod/app.yaml"); u != expected {
+		t.Errorf("unexpected diff url %s", u)
+	}
+}
+
+func TestOpenPRsAndClose(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		calls = append(calls, strings.TrimSpace(r.Method+" "+r.URL.RequestURI()+" "+string(body)))
+		switch {
+		case r.Method == "GET" && r.URL.Query().Get("start") == "0":
+			fmt.Fprint(w, `{"values":[{"id":1,"version":2,"description":"a","fromRef":{"displayId":"deploy/a"},"toRef":{"displayId":"master"},"links":{"self":[{"href":"https://bb/pr/1"}]}}],"isLastPage":false,"nextPageStart":1}`)
+		case r.Method == "GET" && r.URL.Query().Get("start") == "1":
+			fmt.Fprint(w, `{"values":[{"id":5,"version":0,"description":"b","fromRef":{"displayId":"deploy/b"},"toRef":{"displayId":"master"}}],"isLastPage":true}`)
+		case r.Method == "GET":
+			fmt.Fprint(w, `{"id":5,"version":3}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer ts.Close()
+	oldendpoint := *apiEndpoint
+	defer func() { *apiEndpoint = oldendpoint }()
+	*apiEndpoint = ts.URL
+
+	prs, err := OpenPRs("master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []git.PullRequest{
+		{ID: 1, Source: "deploy/a", Target: "master", Body: "a", URL: "https://bb/pr/1"},
+		{ID: 5, Source: "deploy/b", Target: "master", Body: "b"},
+	}
+	if fmt.Sprint(prs) != fmt.Sprint(expected) {
+		t.Errorf("unexpected pull requests %v", prs)
+	}
+	calls = nil
+	if err := ClosePR(prs[1], "superseded"); err != nil {
+		t.Fatal(err)
+	}
+	expectedCalls := []string{
+		`POST /5/comments {"text":"superseded"}`,
+		`GET /5`,
+		`POST /5/decline?version=3 {}`,
+	}
+	if fmt.Sprint(calls) != fmt.Sprint(expectedCalls) {
+		t.Errorf("unexpected calls %q", calls)
+	}
+}
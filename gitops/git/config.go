@@ -0,0 +1,33 @@
+package git
+
+import "fmt"
+
+// ConfigEntry is a git configuration variable passed to git commands through the environment
+type ConfigEntry struct {
+	Key   string
+	Value string
+}
+
+// ConfigEnv returns environment variables adding entries to the configuration of every git command.
+// Entries with the same key are appended in order, like in a configuration file.
+func ConfigEnv(entries []ConfigEntry) []string {
+	if len(entries) == 0 {
+		return nil
+	}
+	env := []string{fmt.Sprintf("GIT_CONFIG_COUNT=%d", len(entries))}
+	for i, e := range entries {
+		env = append(env, fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", i, e.Key), fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, e.Value))
+	}
+	return env
+}
+
+// CredentialHelper returns configuration replacing all credential helpers with one answering
+// with the user name and password read from environment variables userEnv and passwordEnv,
+// so the secrets are never written to the configuration or command line
+func CredentialHelper(userEnv, passwordEnv string) []ConfigEntry {
+	return []ConfigEntry{
+		// an empty value resets the list of helpers configured so far
+		{Key: "credential.helper", Value: ""},
+		{Key: "credential.helper", Value: fmt.Sprintf(`!f() { test "$1" = get && echo "username=$%s" && echo "password=$%s"; }; f`, userEnv, passwordEnv)},
+	}
+}
@@ -0,0 +1,44 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigEnv(t *testing.T) {
+	env := ConfigEnv([]ConfigEntry{{"http.proxy", "http://proxy:3128"}, {"credential.helper", ""}})
+	expected := []string{
+		"GIT_CONFIG_COUNT=2",
+		"GIT_CONFIG_KEY_0=http.proxy",
+		"GIT_CONFIG_VALUE_0=http://proxy:3128",
+		"GIT_CONFIG_KEY_1=credential.helper",
+		"GIT_CONFIG_VALUE_1=",
+	}
+	if !reflect.DeepEqual(env, expected) {
+		t.Error("unexpected env", env)
+	}
+	if ConfigEnv(nil) != nil {
+		t.Error("expected no env for no entries")
+	}
+}
+
+func TestCredentialHelper(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Dir = t.TempDir()
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=example.com\n\n")
+	cmd.Env = append(os.Environ(), "TEST_USER=bot", "TEST_PASSWORD=s3cr3t", "GIT_TERMINAL_PROMPT=0")
+	cmd.Env = append(cmd.Env, ConfigEnv(CredentialHelper("TEST_USER", "TEST_PASSWORD"))...)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "username=bot\n") || !strings.Contains(string(out), "password=s3cr3t\n") {
+		t.Errorf("unexpected credentials %q", out)
+	}
+}
@@ -0,0 +1,137 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/exec"
+)
+
+var (
+	// ErrNonFastForward is returned when the remote rejects a push because the branch has diverged
+	ErrNonFastForward = errors.New("non-fast-forward update rejected")
+	// ErrAuth is returned when git can't authenticate to the remote or access is denied
+	ErrAuth = errors.New("authentication failed")
+	// ErrBranchNotFound is returned when a branch or revision does not exist locally or on the remote
+	ErrBranchNotFound = errors.New("branch not found")
+	// ErrNothingToCommit is returned when a commit is requested without changes
+	ErrNothingToCommit = errors.New("nothing to commit")
+)
+
+// CommandError is a failed git command.
+// errors.Is reports the matching sentinel error (ErrNonFastForward, ErrAuth, ErrBranchNotFound) if git output was recognized.
+type CommandError struct {
+	Args   []string
+	Output string
+	// Kind is one of sentinel errors of the package or nil if the failure was not recognized
+	Kind error
+	Err  error
+}
+
+func (e *CommandError) Error() string {
+	msg := fmt.Sprintf("git %s: %v", strings.Join(e.Args, " "), e.Err)
+	if e.Kind != nil {
+		msg = fmt.Sprintf("git %s: %v: %v", strings.Join(e.Args, " "), e.Kind, e.Err)
+	}
+	if out := strings.TrimSpace(e.Output); out != "" {
+		msg += ": " + out
+	}
+	return msg
+}
+
+func (e *CommandError) Unwrap() []error {
+	if e.Kind == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// output fragments of git and git remote helpers for recognized failures
+var errorPatterns = []struct {
+	kind      error
+	fragments []string
+}{
+	{ErrNonFastForward, []string{"non-fast-forward", "[rejected]", "fetch first", "stale info", "updates were rejected"}},
+	{ErrAuth, []string{"authentication failed", "could not read username", "could not read password", "permission denied", "terminal prompts disabled", "access denied", "returned error: 401", "returned error: 403", "invalid username or password"}},
+	{ErrBranchNotFound, []string{"did not match any file(s) known to git", "couldn't find remote ref", "not a valid object name", "invalid reference", "unknown revision", "not a valid ref"}},
+	{ErrNothingToCommit, []string{"nothing to commit", "nothing added to commit"}},
+}
+
+// classify returns the sentinel error matching git output or nil
+func classify(output string) error {
+	lower := strings.ToLower(output)
+	for _, p := range errorPatterns {
+		for _, f := range p.fragments {
+			if strings.Contains(lower, f) {
+				return p.kind
+			}
+		}
+	}
+	return nil
+}
+
+// runTransfer is run for commands transferring data from or to the remote.
+// With progress the output is streamed to stderr as it is produced, so transfer progress is visible in the logs.
+// Output of a runner other than exec.Default, or an exec.EnvRunner of it, is not streamed.
+func runTransfer(runner exec.Runner, progress bool, dir string, args ...string) (string, error) {
+	base := runner
+	var env []string
+	if e, ok := runner.(exec.EnvRunner); ok {
+		base, env = e.Base(), e.Env
+	}
+	if !progress || (base != nil && base != exec.Default) {
+		return runWith(runner, dir, args...)
+	}
+	log.Println("executing: git", strings.Join(args, " "))
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var buf bytes.Buffer
+	w := io.MultiWriter(os.Stderr, &buf)
+	cmd.Stdout = w
+	cmd.Stderr = w
+	if err := cmd.Run(); err != nil {
+		return buf.String(), &CommandError{Args: args, Output: buf.String(), Kind: classify(buf.String()), Err: err}
+	}
+	return buf.String(), nil
+}
+
+// run executes git with args in dir. Failures are returned as *CommandError.
+func run(dir string, args ...string) (string, error) {
+	return runWith(nil, dir, args...)
+}
+
+// runWith is run using runner, exec.Default if nil
+func runWith(runner exec.Runner, dir string, args ...string) (string, error) {
+	if runner == nil {
+		runner = exec.Default
+	}
+	out, err := runner.Run(dir, nil, "git", args...)
+	if err != nil {
+		return out, &CommandError{Args: args, Output: out, Kind: classify(out), Err: err}
+	}
+	return out, nil
+}
+
+// outputWith is runWith returning the standard output only when runner is an exec.OutputRunner
+func outputWith(runner exec.Runner, dir string, args ...string) (string, error) {
+	if runner == nil {
+		runner = exec.Default
+	}
+	or, ok := runner.(exec.OutputRunner)
+	if !ok {
+		return runWith(runner, dir, args...)
+	}
+	out, err := or.Output(dir, nil, "git", args...)
+	if err != nil {
+		return out, &CommandError{Args: args, Output: out, Kind: classify(out), Err: err}
+	}
+	return out, nil
+}
@@ -0,0 +1,45 @@
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		output string
+		kind   error
+	}{
+		{" ! [rejected]        deploy/dev -> deploy/dev (fetch first)\nerror: failed to push some refs", ErrNonFastForward},
+		{"remote: Invalid username or password.\nfatal: Authentication failed for 'https://github.com/org/repo.git/'", ErrAuth},
+		{"fatal: could not read Username for 'https://github.com': terminal prompts disabled", ErrAuth},
+		{"git@github.com: Permission denied (publickey).", ErrAuth},
+		{"error: pathspec 'deploy/dev' did not match any file(s) known to git", ErrBranchNotFound},
+		{"fatal: couldn't find remote ref refs/heads/missing", ErrBranchNotFound},
+		{"On branch deploy/dev\nnothing to commit, working tree clean", ErrNothingToCommit},
+		{"fatal: unable to access 'https://example.com/repo.git/': Could not resolve host: example.com", nil},
+	}
+	for _, tt := range tests {
+		if kind := classify(tt.output); kind != tt.kind {
+			t.Errorf("classify(%q) = %v, expected %v", tt.output, kind, tt.kind)
+		}
+	}
+}
+
+func TestCommandErrorIs(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+	err := error(&CommandError{Args: []string{"push"}, Output: "! [rejected]", Kind: ErrNonFastForward, Err: exitErr})
+	if !errors.Is(err, ErrNonFastForward) {
+		t.Error("expected ErrNonFastForward")
+	}
+	if !errors.Is(err, exitErr) {
+		t.Error("expected the underlying error")
+	}
+	if errors.Is(err, ErrAuth) {
+		t.Error("unexpected ErrAuth")
+	}
+	var ce *CommandError
+	if !errors.As(err, &ce) || ce.Output != "! [rejected]" {
+		t.Error("expected CommandError")
+	}
+}
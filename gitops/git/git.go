@@ -12,12 +12,14 @@ governing permissions and limitations under the License.
 package git
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
-	oe "os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fasterci/rules_gitops/gitops/exec"
 )
@@ -32,54 +34,247 @@ func Clone(repo, dir, mirrorDir, primaryBranch, gitopsPath string) (*Repo, error
 	if err := os.RemoveAll(dir); err != nil {
 		return nil, fmt.Errorf("unable to clone repo: %w", err)
 	}
-	if mirrorDir != "" {
-		exec.Mustex("", "git", "clone", "-n", "--reference", mirrorDir, repo, dir)
-	} else {
-		exec.Mustex("", "git", "clone", "-n", repo, dir)
+	if err := clone(DefaultRemote, repo, dir, mirrorDir); err != nil {
+		return nil, err
+	}
+	if _, err := run(dir, "config", "--local", "core.sparsecheckout", "true"); err != nil {
+		return nil, err
 	}
-	exec.Mustex(dir, "git", "config", "--local", "core.sparsecheckout", "true")
 	genPath := fmt.Sprintf("%s/\n", gitopsPath)
 	if err := os.WriteFile(filepath.Join(dir, ".git/info/sparse-checkout"), []byte(genPath), 0644); err != nil {
 		return nil, fmt.Errorf("unable to create .git/info/sparse-checkout: %w", err)
 	}
-	exec.Mustex(dir, "git", "checkout", primaryBranch)
+	if _, err := run(dir, "checkout", primaryBranch); err != nil {
+		return nil, err
+	}
 
 	return &Repo{
 		Dir: dir,
 	}, nil
 }
 
+// DefaultRemote is the name of the remote repository used when no other name is configured
+const DefaultRemote = "origin"
+
 func CloneOrCheckout(repo, dir, mirrorDir, primaryBranch, gitopsPath, branchPrefix string) (r *Repo, err error) {
-	newRepo := false
-	if _, err = os.Stat(dir + "/.git"); os.IsNotExist(err) {
-		newRepo = true
+	return CloneOrCheckoutRemote(DefaultRemote, repo, dir, mirrorDir, primaryBranch, gitopsPath, branchPrefix)
+}
+
+// CloneOrCheckoutRemote is CloneOrCheckout using remote as the name of the cloned repository remote.
+// An existing checkout without the remote gets it added.
+func CloneOrCheckoutRemote(remote, repo, dir, mirrorDir, primaryBranch, gitopsPath, branchPrefix string) (r *Repo, err error) {
+	return CloneOrCheckoutOptions(repo, dir, mirrorDir, primaryBranch, gitopsPath, branchPrefix, CloneOptions{Remote: remote})
+}
+
+// CloneOptions tune how CloneOrCheckoutOptions transfers data from the remote
+type CloneOptions struct {
+	// Remote is the name of the repository remote. DefaultRemote is used if empty
+	Remote string
+	// Progress streams git transfer progress to stderr while cloning and fetching
+	Progress bool
+	// Quiet suppresses git transfer output
+	Quiet bool
+	// Fetch limits fetched refs. Entries are refspecs or branch name patterns like deploy/*.
+	// All branches are fetched if empty
+	Fetch []string
+	// Clean discards local state of an existing checkout instead of failing with ErrUnsafeCheckout
+	Clean bool
+	// Runner executes git commands, it is passed on to the returned Repo. exec.Default is used if nil
+	Runner exec.Runner
+}
+
+func (o CloneOptions) transferArgs(args ...string) []string {
+	if o.Progress {
+		args = append(args, "--progress")
+	}
+	if o.Quiet {
+		args = append(args, "--quiet")
+	}
+	return args
+}
+
+// refspecs returns fetch refspecs of the remote, converting branch patterns to refspecs
+func (o CloneOptions) refspecs() []string {
+	if len(o.Fetch) == 0 {
+		return []string{fmt.Sprintf("+refs/heads/*:refs/remotes/%s/*", o.Remote)}
+	}
+	var specs []string
+	for _, f := range o.Fetch {
+		if strings.Contains(f, ":") {
+			specs = append(specs, f)
+		} else {
+			specs = append(specs, fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", f, o.Remote, f))
+		}
+	}
+	return specs
+}
+
+// CloneOrCheckoutOptions is CloneOrCheckout with transfer options.
+// With opts.Fetch only the primary branch is cloned and the other refs are fetched using the configured refspecs.
+// An existing checkout in dir is reused only if it is safe, see opts.Clean, and its primary branch is reset
+// to the fetched remote branch.
+func CloneOrCheckoutOptions(repo, dir, mirrorDir, primaryBranch, gitopsPath, branchPrefix string, opts CloneOptions) (r *Repo, err error) {
+	if opts.Remote == "" {
+		opts.Remote = DefaultRemote
+	}
+	remote := opts.Remote
+	fetch := true
+	existing := false
+	if _, err = os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
 		if err = os.MkdirAll(filepath.Dir(dir), os.ModePerm); err != nil && !os.IsExist(err) {
 			return nil, err
 		}
+		args := []string{"clone", "-n", "--origin", remote}
 		if mirrorDir != "" {
-			exec.Mustex("", "git", "clone", "-n", "--reference", mirrorDir, repo, dir)
+			args = append(args, "--reference", mirrorDir)
+		}
+		if len(opts.Fetch) > 0 {
+			args = append(args, "--single-branch", "--branch", primaryBranch, "--no-tags")
 		} else {
-			exec.Mustex("", "git", "clone", "-n", repo, dir)
+			// full clone has all refs already
+			fetch = false
+		}
+		if _, err = runTransfer(opts.Runner, opts.Progress, "", opts.transferArgs(append(args, repo, dir)...)...); err != nil {
+			return nil, err
 		}
 	} else {
 		//existing repo
-		exec.Mustex(dir, "git", "remote", "set-url", "origin", repo)
-		exec.Mustex(dir, "git", "reset", "--hard")
+		existing = true
+		if err = checkReusable(opts.Runner, dir, remote, repo, opts.Clean); err != nil {
+			return nil, err
+		}
+	}
+	if fetch {
+		if err = setFetchRefspecs(opts.Runner, dir, remote, opts.refspecs()); err != nil {
+			return nil, err
+		}
+	}
+	if _, err = runWith(opts.Runner, dir, "checkout", "-f", primaryBranch); err != nil {
+		return nil, err
 	}
-	exec.Mustex(dir, "git", "checkout", "-f", primaryBranch)
-	if !newRepo {
-		exec.Mustex(dir, "git", "fetch", "origin", "--prune")
-		DeleteLocalBranches(dir, branchPrefix)
+	if fetch {
+		if _, err = runTransfer(opts.Runner, opts.Progress, dir, opts.transferArgs("fetch", remote, "--prune")...); err != nil {
+			return nil, err
+		}
+		(&Repo{Dir: dir, Runner: opts.Runner}).deleteLocalBranches(branchPrefix)
+	}
+	if existing {
+		// the primary branch may not be covered by opts.Fetch
+		tracking := fmt.Sprintf("refs/remotes/%s/%s", remote, primaryBranch)
+		if _, err = runTransfer(opts.Runner, opts.Progress, dir, opts.transferArgs("fetch", remote, "+refs/heads/"+primaryBranch+":"+tracking)...); err != nil {
+			return nil, err
+		}
+		if _, err = runWith(opts.Runner, dir, "reset", "-q", "--hard", tracking); err != nil {
+			return nil, err
+		}
 	}
 
 	return &Repo{
-		Dir: dir,
+		Dir:    dir,
+		Remote: remote,
+		Runner: opts.Runner,
 	}, nil
 }
 
+func clone(remote, repo, dir, mirrorDir string) error {
+	args := []string{"clone", "-n", "--origin", remote}
+	if mirrorDir != "" {
+		args = append(args, "--reference", mirrorDir)
+	}
+	_, err := run("", append(args, repo, dir)...)
+	return err
+}
+
+// setFetchRefspecs replaces fetch refspecs of the remote
+func setFetchRefspecs(runner exec.Runner, dir, remote string, refspecs []string) error {
+	key := "remote." + remote + ".fetch"
+	// fails if the key is not set
+	runWith(runner, dir, "config", "--unset-all", key)
+	for _, spec := range refspecs {
+		if _, err := runWith(runner, dir, "config", "--add", key, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setRemote points remote name of the repository in dir to url, adding the remote if it does not exist
+func setRemote(runner exec.Runner, dir, name, url string) error {
+	if _, err := runWith(runner, dir, "remote", "get-url", name); err != nil {
+		_, err = runWith(runner, dir, "remote", "add", name, url)
+		return err
+	}
+	_, err := runWith(runner, dir, "remote", "set-url", name, url)
+	return err
+}
+
+// UpdateCache creates or updates a persistent bare mirror of repo in cacheDir.
+// The first call clones the mirror, subsequent calls only fetch new objects and refs.
+// The cache can be used as mirrorDir for Clone and CloneOrCheckout. Commands are run with runner, exec.Default if nil.
+func UpdateCache(runner exec.Runner, repo, cacheDir string) error {
+	if _, err := os.Stat(filepath.Join(cacheDir, "HEAD")); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(cacheDir), os.ModePerm); err != nil {
+			return fmt.Errorf("unable to create git cache dir: %w", err)
+		}
+		if _, err := runWith(runner, "", "clone", "--mirror", repo, cacheDir); err != nil {
+			return fmt.Errorf("unable to clone git cache: %w", err)
+		}
+		return nil
+	}
+	if _, err := runWith(runner, cacheDir, "remote", "set-url", "origin", repo); err != nil {
+		return fmt.Errorf("unable to update git cache remote: %w", err)
+	}
+	if _, err := runWith(runner, cacheDir, "fetch", "--prune", "origin"); err != nil {
+		return fmt.Errorf("unable to fetch git cache: %w", err)
+	}
+	return nil
+}
+
+// RemoteBranchExists contacts repo and reports whether it has branch.
+// Unreachable repositories and credential problems are returned as errors. runner is exec.Default if nil.
+func RemoteBranchExists(runner exec.Runner, repo, branch string) (bool, error) {
+	ref := "refs/heads/" + branch
+	out, err := runWith(runner, "", "ls-remote", "--heads", repo, ref)
+	if err != nil {
+		return false, err
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasSuffix(strings.TrimSpace(line), "\t"+ref) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CheckPushAccess verifies the current credentials can push branch to repo without changing the repository.
+// It runs git push --dry-run of an unrelated commit from a temporary repository with runner, exec.Default if nil.
+func CheckPushAccess(runner exec.Runner, repo, branch string) error {
+	dir, err := os.MkdirTemp("", "gitops-preflight")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	if _, err := runWith(runner, dir, "init", "-q"); err != nil {
+		return err
+	}
+	if _, err := runWith(runner, dir, "-c", "user.name=preflight", "-c", "user.email=preflight@localhost", "commit", "-q", "--allow-empty", "-m", "preflight"); err != nil {
+		return err
+	}
+	_, err = runWith(runner, dir, "push", "--dry-run", repo, "HEAD:refs/heads/"+branch)
+	if errors.Is(err, ErrNonFastForward) {
+		// the server accepted the credentials and evaluated the update
+		return nil
+	}
+	return err
+}
+
 // DeleteLocalBranches removes local branches by prefix.
 func DeleteLocalBranches(dir, branchprefix string) {
-	branches := exec.Mustex(dir, "git", "for-each-ref", "--format", "%(refname)", "refs/heads/"+branchprefix)
+	(&Repo{Dir: dir}).deleteLocalBranches(branchprefix)
+}
+
+func (r *Repo) deleteLocalBranches(branchprefix string) {
+	branches := r.mustRun("for-each-ref", "--format", "%(refname)", "refs/heads/"+branchprefix)
 	// returned format:
 	// refs/heads/deploy/dev
 	// refs/heads/deploy/prod
@@ -89,7 +284,7 @@ func DeleteLocalBranches(dir, branchprefix string) {
 		ref := strings.TrimSpace(line)
 		if strings.HasPrefix(ref, "refs/heads/"+branchprefix) {
 			ref = strings.TrimPrefix(ref, "refs/heads/")
-			exec.Mustex(dir, "git", "branch", "-D", ref)
+			r.mustRun("branch", "-D", ref)
 		}
 
 	}
@@ -100,6 +295,25 @@ func DeleteLocalBranches(dir, branchprefix string) {
 type Repo struct {
 	// Dir is the location of the git repo.
 	Dir string
+	// Remote is the name of the remote Push pushes to. DefaultRemote is used if empty
+	Remote string
+	// SignOff adds a Signed-off-by trailer of the committer identity to every commit
+	SignOff bool
+	// Runner executes git commands. exec.Default is used if nil
+	Runner exec.Runner
+}
+
+func (r *Repo) run(args ...string) (string, error) {
+	return runWith(r.Runner, r.Dir, args...)
+}
+
+// mustRun is run terminating the process on failure
+func (r *Repo) mustRun(args ...string) string {
+	out, err := r.run(args...)
+	if err != nil {
+		exec.Fatalf("ERROR: %s", err)
+	}
+	return out
 }
 
 // Clean cleans up the repo
@@ -107,13 +321,19 @@ func (r *Repo) Clean() error {
 	return os.RemoveAll(r.Dir)
 }
 
+// Checkout switches the repo to an existing branch. Returns ErrBranchNotFound if the branch does not exist.
+func (r *Repo) Checkout(branch string) error {
+	_, err := r.run("checkout", branch)
+	return err
+}
+
 // SwitchToBranch switch the repo to specified branch and checkout primaryBranch files over it.
 // if branch does not exist it will be created
 func (r *Repo) SwitchToBranch(branch, primaryBranch string) (new bool) {
-	if _, err := exec.Ex(r.Dir, "git", "checkout", branch); err != nil {
+	if err := r.Checkout(branch); err != nil {
 		// error checking out, create new
-		exec.Mustex(r.Dir, "git", "branch", branch, primaryBranch)
-		exec.Mustex(r.Dir, "git", "checkout", branch)
+		r.mustRun("branch", branch, primaryBranch)
+		r.mustRun("checkout", branch)
 		return true
 	}
 	return false
@@ -121,14 +341,14 @@ func (r *Repo) SwitchToBranch(branch, primaryBranch string) (new bool) {
 
 // RecreateBranch discards a branch content and reset it from primaryBranch.
 func (r *Repo) RecreateBranch(branch, primaryBranch string) {
-	exec.Mustex(r.Dir, "git", "checkout", primaryBranch)
-	exec.Mustex(r.Dir, "git", "branch", "-f", branch, primaryBranch)
-	exec.Mustex(r.Dir, "git", "checkout", branch)
+	r.mustRun("checkout", primaryBranch)
+	r.mustRun("branch", "-f", branch, primaryBranch)
+	r.mustRun("checkout", branch)
 }
 
 // GetLastCommitMessage fetches the commit message from the most recent change of the branch
 func (r *Repo) GetLastCommitMessage() (msg string) {
-	msg, err := exec.Ex(r.Dir, "git", "log", "-1", "--pretty=%B")
+	msg, err := r.run("log", "-1", "--pretty=%B")
 	if err != nil {
 		return ""
 	}
@@ -136,29 +356,359 @@ func (r *Repo) GetLastCommitMessage() (msg string) {
 }
 
 // Commit all changes to the current branch. returns true if there were any changes
-func (r *Repo) Commit(message, gitopsPath string) bool {
-	exec.Mustex(r.Dir, "git", "add", gitopsPath)
-	if r.IsClean() {
+// Untracked files are committed only if they are located in gitopsPath or extraPaths.
+func (r *Repo) Commit(message, gitopsPath string, extraPaths ...string) bool {
+	err := r.CommitChanges(message, gitopsPath, extraPaths...)
+	if errors.Is(err, ErrNothingToCommit) {
 		return false
 	}
-	exec.Mustex(r.Dir, "git", "commit", "-a", "-m", message)
+	if err != nil {
+		exec.Fatalf("ERROR: %s", err)
+	}
 	return true
 }
 
+// CommitChanges commits all changes to the current branch. Returns ErrNothingToCommit if there were no changes.
+// Untracked files are committed only if they are located in gitopsPath or extraPaths.
+func (r *Repo) CommitChanges(message, gitopsPath string, extraPaths ...string) error {
+	if _, err := r.run(append([]string{"add", gitopsPath}, extraPaths...)...); err != nil {
+		return err
+	}
+	if r.IsClean() {
+		return ErrNothingToCommit
+	}
+	args := []string{"commit", "-a", "-m", message}
+	if r.SignOff {
+		args = append(args, "--signoff")
+	}
+	_, err := r.run(args...)
+	return err
+}
+
+// SetIdentity configures the author and committer identity used for commits in the repo
+func (r *Repo) SetIdentity(name, email string) {
+	if name != "" {
+		r.mustRun("config", "--local", "user.name", name)
+	}
+	if email != "" {
+		r.mustRun("config", "--local", "user.email", email)
+	}
+}
+
+// SignOffTrailer returns the Signed-off-by trailer of the configured committer identity
+func (r *Repo) SignOffTrailer() (string, error) {
+	ident, err := r.run("var", "GIT_COMMITTER_IDENT")
+	if err != nil {
+		return "", fmt.Errorf("unable to get committer identity: %w", err)
+	}
+	// format: Name <email> timestamp timezone
+	end := strings.LastIndex(ident, ">")
+	if end < 0 {
+		return "", fmt.Errorf("unexpected committer identity %q", ident)
+	}
+	return "Signed-off-by: " + ident[:end+1], nil
+}
+
+// CommitsWithoutTrailer returns commits reachable from branch but neither from base nor from the remote tracking
+// branch of branch, whose message does not contain trailer line. Commits the remote already has are not pushed again,
+// so commits made before the trailer was required, or by another identity, do not block later updates.
+func (r *Repo) CommitsWithoutTrailer(base, branch, trailer string) ([]string, error) {
+	remote := r.Remote
+	if remote == "" {
+		remote = DefaultRemote
+	}
+	args := []string{"log", "--format=%H%x00%B%x1e", branch, "--not", base}
+	if tracking := fmt.Sprintf("refs/remotes/%s/%s", remote, branch); r.refExists(tracking) {
+		args = append(args, tracking)
+	}
+	out, err := r.run(args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list commits of %s: %w", branch, err)
+	}
+	var missing []string
+	for _, rec := range strings.Split(out, "\x1e") {
+		hash, msg, found := strings.Cut(strings.TrimSpace(rec), "\x00")
+		if !found {
+			continue
+		}
+		present := false
+		for _, line := range strings.Split(msg, "\n") {
+			if strings.TrimSpace(line) == trailer {
+				present = true
+				break
+			}
+		}
+		if !present {
+			missing = append(missing, hash)
+		}
+	}
+	return missing, nil
+}
+
+// ChangedFiles stages all changes under gitopsPath and returns the list of added or modified files
+// relative to the repository root. Deleted files are not included.
+func (r *Repo) ChangedFiles(gitopsPath string) ([]string, error) {
+	return r.stagedFiles(gitopsPath, "d")
+}
+
+// DeletedFiles stages all changes under gitopsPath and returns the list of deleted files
+// relative to the repository root.
+func (r *Repo) DeletedFiles(gitopsPath string) ([]string, error) {
+	return r.stagedFiles(gitopsPath, "D")
+}
+
+// StagedDiff stages all changes under gitopsPath and returns them as a unified diff against HEAD
+func (r *Repo) StagedDiff(gitopsPath string) (string, error) {
+	if _, err := r.run("add", gitopsPath); err != nil {
+		return "", err
+	}
+	return r.run("diff", "--cached", "--no-color", "--no-ext-diff", "HEAD", "--", gitopsPath)
+}
+
+func (r *Repo) stagedFiles(gitopsPath, filter string) ([]string, error) {
+	if _, err := r.run("add", gitopsPath); err != nil {
+		return nil, err
+	}
+	out, err := r.run("diff", "--cached", "--name-only", "--diff-filter="+filter, "--", gitopsPath)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		if f := strings.TrimSpace(line); f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// FileChange is a file added, modified, deleted or renamed between two revisions
+type FileChange struct {
+	// Status is one of "added", "modified", "deleted" or "renamed"
+	Status string
+	Path   string
+	// OldPath is the previous location of a renamed file
+	OldPath string
+}
+
+// BranchChanges returns files under path changed on branch since it diverged from base
+func (r *Repo) BranchChanges(base, branch, path string) ([]FileChange, error) {
+	out, err := r.run("diff", "--name-status", "-M", base+"..."+branch, "--", path)
+	if err != nil {
+		return nil, err
+	}
+	var changes []FileChange
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) < 2 || fields[0] == "" {
+			continue
+		}
+		c := FileChange{Path: fields[len(fields)-1]}
+		switch fields[0][0] {
+		case 'A':
+			c.Status = "added"
+		case 'D':
+			c.Status = "deleted"
+		case 'R':
+			c.Status = "renamed"
+			c.OldPath = fields[1]
+		default:
+			c.Status = "modified"
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// HasUnmergedChanges returns true if files under path changed on branch since it diverged from base have
+// a different content in base, so merging branch would change base. Squash merged branches have none.
+func (r *Repo) HasUnmergedChanges(base, branch, path string) (bool, error) {
+	out, err := r.run("diff", "--name-only", base+"..."+branch, "--", path)
+	if err != nil {
+		return false, err
+	}
+	files := strings.Fields(out)
+	if len(files) == 0 {
+		return false, nil
+	}
+	out, err = r.run(append([]string{"diff", "--name-only", base, branch, "--"}, files...)...)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) != "", nil
+}
+
+// FileAt returns the content of path at revision rev. found is false if the file does not exist at rev.
+func (r *Repo) FileAt(rev, path string) (content []byte, found bool, err error) {
+	out, err := r.run("ls-tree", "--name-only", rev, "--", path)
+	if err != nil {
+		return nil, false, err
+	}
+	if strings.TrimSpace(out) == "" {
+		return nil, false, nil
+	}
+	// stdout only, so warnings printed by git do not end up in the content
+	out, err = outputWith(r.Runner, r.Dir, "show", rev+":"+path)
+	if err != nil {
+		return nil, false, err
+	}
+	return []byte(out), true, nil
+}
+
+// Discard drops all uncommitted changes under gitopsPath, including untracked files
+func (r *Repo) Discard(gitopsPath string) {
+	r.mustRun("reset", "-q", "--hard", "HEAD")
+	r.mustRun("clean", "-fdq", "--", gitopsPath)
+}
+
+// Restore reverts paths to their content at HEAD, both in the index and the working tree
+func (r *Repo) Restore(paths ...string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	_, err := r.run(append([]string{"checkout", "-q", "HEAD", "--"}, paths...)...)
+	return err
+}
+
 // IsClean returns true if there is no local changes (nothing to commit)
 func (r *Repo) IsClean() bool {
-	cmd := oe.Command("git", "status", "--porcelain")
-	cmd.Dir = r.Dir
-	b, err := cmd.CombinedOutput()
+	return len(r.mustRun("status", "--porcelain")) == 0
+}
+
+// Note returns the note of rev in the notes ref, empty if rev has no note
+func (r *Repo) Note(ref, rev string) (string, error) {
+	if !r.refExists(ref) {
+		return "", nil
+	}
+	out, err := r.run("notes", "--ref="+ref, "show", rev)
+	if err != nil {
+		if strings.Contains(out, "no note found") {
+			return "", nil
+		}
+		return "", err
+	}
+	return out, nil
+}
+
+// SetNote replaces the note of rev in the notes ref
+func (r *Repo) SetNote(ref, rev, note string) error {
+	_, err := r.run("notes", "--ref="+ref, "add", "-f", "-m", note, rev)
+	return err
+}
+
+// FetchRef replaces the local ref with the ref of the remote. It fails if the remote does not have ref.
+func (r *Repo) FetchRef(ref string) error {
+	remote := r.Remote
+	if remote == "" {
+		remote = DefaultRemote
+	}
+	_, err := r.run("fetch", "-q", remote, "+"+ref+":"+ref)
+	return err
+}
+
+// PushRef pushes the local ref to the remote without force, so updates made by others are not overwritten
+func (r *Repo) PushRef(ref string) error {
+	remote := r.Remote
+	if remote == "" {
+		remote = DefaultRemote
+	}
+	_, err := r.run("push", "-q", remote, ref+":"+ref)
+	return err
+}
+
+func (r *Repo) refExists(ref string) bool {
+	_, err := r.run("rev-parse", "--verify", "-q", ref)
+	return err == nil
+}
+
+// Rev returns the commit hash of ref
+func (r *Repo) Rev(ref string) (string, error) {
+	out, err := r.run("rev-parse", "--verify", "-q", ref+"^{commit}")
+	return strings.TrimSpace(out), err
+}
+
+// LastChange returns the committer time of the last commit of rev changing path, zero if no commit changed it
+func (r *Repo) LastChange(rev, path string) (time.Time, error) {
+	out, err := r.run("log", "-1", "--format=%ct", rev, "--", path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	out = strings.TrimSpace(out)
+	if out == "" {
+		return time.Time{}, nil
+	}
+	sec, err := strconv.ParseInt(out, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected commit time %q: %w", out, err)
+	}
+	return time.Unix(sec, 0), nil
+}
+
+// CommitMessages returns the messages of the commits of rev that changed path, newest first
+func (r *Repo) CommitMessages(rev, path string) ([]string, error) {
+	out, err := r.run("log", "-z", "--format=%B", rev, "--", path)
 	if err != nil {
-		log.Fatalf("ERROR: %s", err)
+		return nil, err
+	}
+	var msgs []string
+	for _, m := range strings.Split(out, "\x00") {
+		if m = strings.TrimSpace(m); m != "" {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs, nil
+}
+
+// FetchBranch updates the remote tracking branch of branch and returns the name of the tracking ref
+func (r *Repo) FetchBranch(branch string) (string, error) {
+	remote := r.Remote
+	if remote == "" {
+		remote = DefaultRemote
+	}
+	tracking := fmt.Sprintf("refs/remotes/%s/%s", remote, branch)
+	_, err := r.run("fetch", "-q", remote, "+refs/heads/"+branch+":"+tracking)
+	return tracking, err
+}
+
+// Rebase replays commits of branch missing from onto on top of onto. The branch stays checked out.
+// On conflicts the rebase is aborted, branch is left unchanged and the error is returned.
+func (r *Repo) Rebase(branch, onto string) error {
+	if _, err := r.run("rebase", "-q", onto, branch); err != nil {
+		if _, abortErr := r.run("rebase", "--abort"); abortErr != nil {
+			log.Printf("unable to abort rebase of %s: %v", branch, abortErr)
+		}
+		return err
 	}
-	return len(b) == 0
+	return nil
+}
+
+// UpdateBranch points the local branch to rev without checking it out
+func (r *Repo) UpdateBranch(branch, rev string) error {
+	_, err := r.run("update-ref", "refs/heads/"+branch, rev)
+	return err
 }
 
 // Push pushes all local changes to the remote repository
 // all changes should be already commited
-func (r *Repo) Push(branches []string) {
-	args := append([]string{"push", "origin", "-f", "--set-upstream"}, branches...)
-	exec.Mustex(r.Dir, "git", args...)
+// Rejected updates are reported as ErrNonFastForward and credential problems as ErrAuth.
+func (r *Repo) Push(branches []string) error {
+	remote := r.Remote
+	if remote == "" {
+		remote = DefaultRemote
+	}
+	args := append([]string{"push", remote, "-f", "--set-upstream"}, branches...)
+	_, err := r.run(args...)
+	return err
+}
+
+// SetRemote adds a remote with the name and url or updates the url of an existing one
+func (r *Repo) SetRemote(name, url string) error {
+	return setRemote(r.Runner, r.Dir, name, url)
+}
+
+// PushTo force pushes branches to an additional remote without changing their upstream
+func (r *Repo) PushTo(remote string, branches []string) error {
+	args := append([]string{"push", remote, "-f"}, branches...)
+	_, err := r.run(args...)
+	return err
 }
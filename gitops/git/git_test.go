@@ -0,0 +1,430 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCloneOptionsRefspecs(t *testing.T) {
+	o := CloneOptions{Remote: "origin"}
+	if specs := o.refspecs(); !reflect.DeepEqual(specs, []string{"+refs/heads/*:refs/remotes/origin/*"}) {
+		t.Error("unexpected default refspecs", specs)
+	}
+	o.Fetch = []string{"master", "deploy/*", "+refs/notes/*:refs/notes/*"}
+	expected := []string{
+		"+refs/heads/master:refs/remotes/origin/master",
+		"+refs/heads/deploy/*:refs/remotes/origin/deploy/*",
+		"+refs/notes/*:refs/notes/*",
+	}
+	if specs := o.refspecs(); !reflect.DeepEqual(specs, expected) {
+		t.Error("unexpected refspecs", specs)
+	}
+}
+
+func TestCloneOptionsTransferArgs(t *testing.T) {
+	args := CloneOptions{Progress: true}.transferArgs("fetch", "origin")
+	if !reflect.DeepEqual(args, []string{"fetch", "origin", "--progress"}) {
+		t.Error("unexpected args", args)
+	}
+}
+
+func TestRemotePreflight(t *testing.T) {
+	tmp := t.TempDir()
+	remote := filepath.Join(tmp, "remote.git")
+	work := filepath.Join(tmp, "work")
+	for _, args := range [][]string{
+		{"init", "-q", "--bare", remote},
+		{"init", "-q", work},
+		{"-C", work, "-c", "user.name=test", "-c", "user.email=test@localhost", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"-C", work, "push", "-q", remote, "HEAD:refs/heads/master"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if ok, err := RemoteBranchExists(nil, remote, "master"); err != nil || !ok {
+		t.Errorf("RemoteBranchExists(nil, master) = %v, %v", ok, err)
+	}
+	if ok, err := RemoteBranchExists(nil, remote, "main"); err != nil || ok {
+		t.Errorf("RemoteBranchExists(nil, main) = %v, %v", ok, err)
+	}
+	if _, err := RemoteBranchExists(nil, filepath.Join(tmp, "missing.git"), "master"); err == nil {
+		t.Error("expected an error for a missing repository")
+	}
+	if err := CheckPushAccess(nil, remote, "deploy/preflight"); err != nil {
+		t.Errorf("CheckPushAccess() = %v", err)
+	}
+	if ok, _ := RemoteBranchExists(nil, remote, "deploy/preflight"); ok {
+		t.Error("CheckPushAccess created a branch")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	work := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", work},
+		{"-C", work, "-c", "user.name=test", "-c", "user.email=test@localhost", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	r := &Repo{Dir: work}
+	r.SetIdentity("test", "test@localhost")
+	if err := os.MkdirAll(filepath.Join(work, "cloud"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{"a.yaml", "b.yaml"} {
+		if err := os.WriteFile(filepath.Join(work, "cloud", f), []byte("v1\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !r.Commit("add", "cloud") {
+		t.Fatal("expected a commit")
+	}
+	for _, f := range []string{"a.yaml", "b.yaml"} {
+		if err := os.WriteFile(filepath.Join(work, "cloud", f), []byte("v2\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if files, err := r.ChangedFiles("cloud"); err != nil || len(files) != 2 {
+		t.Fatalf("ChangedFiles() = %v, %v", files, err)
+	}
+	if err := r.Restore("cloud/a.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	files, err := r.ChangedFiles("cloud")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0] != "cloud/b.yaml" {
+		t.Errorf("unexpected changed files after restore: %v", files)
+	}
+	if b, _ := os.ReadFile(filepath.Join(work, "cloud", "a.yaml")); string(b) != "v1\n" {
+		t.Errorf("unexpected restored content %q", b)
+	}
+	diff, err := r.StagedDiff("cloud")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "diff --git a/cloud/b.yaml b/cloud/b.yaml") || !strings.Contains(diff, "-v1\n+v2\n") || strings.Contains(diff, "a.yaml") {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+	if err := os.Remove(filepath.Join(work, "cloud", "a.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if files, err := r.DeletedFiles("cloud"); err != nil || len(files) != 1 || files[0] != "cloud/a.yaml" {
+		t.Errorf("DeletedFiles() = %v, %v", files, err)
+	}
+}
+
+func TestRebaseOntoMovedBase(t *testing.T) {
+	tmp := t.TempDir()
+	remote := filepath.Join(tmp, "remote.git")
+	other := filepath.Join(tmp, "other")
+	gitT(t, "init", "-q", "--bare", remote)
+	gitT(t, "init", "-q", other)
+	write := func(dir, name, content string) {
+		if err := os.MkdirAll(filepath.Join(dir, "cloud"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "cloud", name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	advance := func(name, content string) {
+		write(other, name, content)
+		gitT(t, "-C", other, "add", "-A")
+		gitT(t, "-C", other, "-c", "user.name=test", "-c", "user.email=test@localhost", "commit", "-q", "-m", name)
+		gitT(t, "-C", other, "push", "-q", remote, "HEAD:refs/heads/master")
+	}
+	advance("base.yaml", "v1\n")
+
+	r, err := CloneOrCheckoutOptions(remote, filepath.Join(tmp, "gitops"), "", "master", "cloud", "deploy/", CloneOptions{Quiet: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.SetIdentity("test", "test@localhost")
+	base, err := r.Rev("origin/master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.SwitchToBranch("deploy/dev", "master")
+	write(r.Dir, "dev.yaml", "v1\n")
+	if !r.Commit("dev", "cloud") {
+		t.Fatal("expected a commit")
+	}
+	r.SwitchToBranch("deploy/prod", "master")
+	write(r.Dir, "base.yaml", "prod\n")
+	if !r.Commit("prod", "cloud") {
+		t.Fatal("expected a commit")
+	}
+
+	advance("base.yaml", "v2\n")
+	tracking, err := r.FetchBranch("master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	moved, err := r.Rev(tracking)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved == base {
+		t.Fatal("expected the base to move")
+	}
+	if err := r.Rebase("deploy/dev", tracking); err != nil {
+		t.Fatal(err)
+	}
+	if out := gitT(t, "-C", r.Dir, "merge-base", "deploy/dev", tracking); out != moved {
+		t.Errorf("deploy/dev is not based on %s: %s", moved, out)
+	}
+	prod, _ := r.Rev("deploy/prod")
+	if err := r.Rebase("deploy/prod", tracking); err == nil {
+		t.Fatal("expected a conflict")
+	}
+	if after, _ := r.Rev("deploy/prod"); after != prod {
+		t.Errorf("conflicting branch was changed: %s != %s", after, prod)
+	}
+	if err := r.UpdateBranch("master", moved); err != nil {
+		t.Fatal(err)
+	}
+	if rev, _ := r.Rev("master"); rev != moved {
+		t.Errorf("master = %s, want %s", rev, moved)
+	}
+	if changed, err := r.LastChange("master", "cloud"); err != nil || time.Since(changed) > time.Hour {
+		t.Errorf("unexpected last change %v: %v", changed, err)
+	}
+	if changed, err := r.LastChange("master", "missing"); err != nil || !changed.IsZero() {
+		t.Errorf("expected no change of a missing path, got %v: %v", changed, err)
+	}
+	if msgs, err := r.CommitMessages("deploy/dev", "cloud"); err != nil || len(msgs) < 2 || msgs[0] != "dev" {
+		t.Errorf("unexpected commit messages %q: %v", msgs, err)
+	}
+	if msgs, err := r.CommitMessages("master", "missing"); err != nil || len(msgs) != 0 {
+		t.Errorf("expected no commit messages of a missing path, got %q: %v", msgs, err)
+	}
+}
+
+// fakeRunner records git commands instead of running them. Commands in fail fail once with output classified
+// as ErrBranchNotFound, other commands return their entry in out.
+type fakeRunner struct {
+	calls []string
+	fail  map[string]bool
+	out   map[string]string
+}
+
+func (f *fakeRunner) Run(dir string, env []string, name string, arg ...string) (string, error) {
+	cmd := strings.Join(arg, " ")
+	f.calls = append(f.calls, cmd)
+	if f.fail[cmd] {
+		delete(f.fail, cmd)
+		return "error: pathspec did not match any file(s) known to git", errors.New("exit status 1")
+	}
+	return f.out[cmd], nil
+}
+
+func TestRepoRunner(t *testing.T) {
+	f := &fakeRunner{fail: map[string]bool{"checkout deploy/dev": true}}
+	r := &Repo{Dir: "/gitops", Runner: f}
+	if !r.SwitchToBranch("deploy/dev", "master") {
+		t.Error("expected a new branch")
+	}
+	if expected := []string{"checkout deploy/dev", "branch deploy/dev master", "checkout deploy/dev"}; !reflect.DeepEqual(f.calls, expected) {
+		t.Errorf("unexpected commands %q", f.calls)
+	}
+
+	f.calls = nil
+	r.RecreateBranch("deploy/dev", "master")
+	if expected := []string{"checkout master", "branch -f deploy/dev master", "checkout deploy/dev"}; !reflect.DeepEqual(f.calls, expected) {
+		t.Errorf("unexpected commands %q", f.calls)
+	}
+
+	f.calls = nil
+	if err := r.CommitChanges("msg", "cloud"); !errors.Is(err, ErrNothingToCommit) {
+		t.Errorf("expected ErrNothingToCommit, got %v", err)
+	}
+	f.out = map[string]string{"status --porcelain": " M cloud/a.yaml\n"}
+	r.SignOff = true
+	if err := r.CommitChanges("msg", "cloud"); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"add cloud", "status --porcelain", "add cloud", "status --porcelain", "commit -a -m msg --signoff"}; !reflect.DeepEqual(f.calls, expected) {
+		t.Errorf("unexpected commands %q", f.calls)
+	}
+
+	f.fail = map[string]bool{"checkout deploy/prod": true}
+	if err := r.Checkout("deploy/prod"); !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("expected ErrBranchNotFound, got %v", err)
+	}
+
+	f.calls = nil
+	f.out = map[string]string{"ls-tree --name-only HEAD -- cloud/a.yaml": "cloud/a.yaml\n", "show HEAD:cloud/a.yaml": "kind: Service\n"}
+	if content, found, err := r.FileAt("HEAD", "cloud/a.yaml"); err != nil || !found || string(content) != "kind: Service\n" {
+		t.Errorf("unexpected content %q, %v: %v", content, found, err)
+	}
+	if expected := []string{"ls-tree --name-only HEAD -- cloud/a.yaml", "show HEAD:cloud/a.yaml"}; !reflect.DeepEqual(f.calls, expected) {
+		t.Errorf("unexpected commands %q", f.calls)
+	}
+}
+
+func TestCommitsWithoutTrailer(t *testing.T) {
+	tmp := t.TempDir()
+	remote := filepath.Join(tmp, "remote.git")
+	gitT(t, "init", "-q", "--bare", remote)
+	seed := filepath.Join(tmp, "seed")
+	gitT(t, "init", "-q", seed)
+	gitT(t, "-C", seed, "-c", "user.name=test", "-c", "user.email=test@localhost", "commit", "-q", "--allow-empty", "-m", "base")
+	gitT(t, "-C", seed, "push", "-q", remote, "HEAD:refs/heads/master")
+
+	r, err := CloneOrCheckoutOptions(remote, filepath.Join(tmp, "gitops"), "", "master", "cloud", "deploy/", CloneOptions{Quiet: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.SetIdentity("test", "test@localhost")
+	trailer, err := r.SignOffTrailer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := func(name string) {
+		if err := os.MkdirAll(filepath.Join(r.Dir, "cloud"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(r.Dir, "cloud", name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if !r.Commit(name, "cloud") {
+			t.Fatal("expected a commit")
+		}
+	}
+	// pushed before sign-off was required
+	r.SwitchToBranch("deploy/dev", "master")
+	commit("unsigned.yaml")
+	if missing, err := r.CommitsWithoutTrailer("master", "deploy/dev", trailer); err != nil || len(missing) != 1 {
+		t.Errorf("expected the unsigned commit, got %v: %v", missing, err)
+	}
+	if err := r.Push([]string{"deploy/dev"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.FetchBranch("deploy/dev"); err != nil {
+		t.Fatal(err)
+	}
+
+	r.SignOff = true
+	commit("signed.yaml")
+	if missing, err := r.CommitsWithoutTrailer("master", "deploy/dev", trailer); err != nil || len(missing) != 0 {
+		t.Errorf("expected pushed commits to be skipped, got %v: %v", missing, err)
+	}
+	r.SignOff = false
+	commit("new-unsigned.yaml")
+	if missing, err := r.CommitsWithoutTrailer("master", "deploy/dev", trailer); err != nil || len(missing) != 1 {
+		t.Errorf("expected the new unsigned commit, got %v: %v", missing, err)
+	}
+}
+
+func TestHasUnmergedChanges(t *testing.T) {
+	tmp := t.TempDir()
+	remote := filepath.Join(tmp, "remote.git")
+	gitT(t, "init", "-q", "--bare", remote)
+	seed := filepath.Join(tmp, "seed")
+	gitT(t, "init", "-q", seed)
+	gitT(t, "-C", seed, "-c", "user.name=test", "-c", "user.email=test@localhost", "commit", "-q", "--allow-empty", "-m", "base")
+	gitT(t, "-C", seed, "push", "-q", remote, "HEAD:refs/heads/master")
+
+	r, err := CloneOrCheckoutOptions(remote, filepath.Join(tmp, "gitops"), "", "master", "cloud", "deploy/", CloneOptions{Quiet: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.SetIdentity("test", "test@localhost")
+	write := func(name, content string) {
+		if err := os.MkdirAll(filepath.Join(r.Dir, "cloud"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(r.Dir, "cloud", name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.SwitchToBranch("deploy/dev", "master")
+	if pending, err := r.HasUnmergedChanges("master", "deploy/dev", "cloud"); err != nil || pending {
+		t.Errorf("expected no changes on a new branch, got %v: %v", pending, err)
+	}
+	write("dev.yaml", "v1")
+	if !r.Commit("dev", "cloud") {
+		t.Fatal("expected a commit")
+	}
+	if pending, err := r.HasUnmergedChanges("master", "deploy/dev", "cloud"); err != nil || !pending {
+		t.Errorf("expected unmerged changes, got %v: %v", pending, err)
+	}
+
+	// squash merge of the branch together with a change of another train
+	if err := r.Checkout("master"); err != nil {
+		t.Fatal(err)
+	}
+	write("dev.yaml", "v1")
+	write("prod.yaml", "v1")
+	if !r.Commit("squashed", "cloud") {
+		t.Fatal("expected a commit")
+	}
+	if pending, err := r.HasUnmergedChanges("master", "deploy/dev", "cloud"); err != nil || pending {
+		t.Errorf("expected squash merged changes to be merged, got %v: %v", pending, err)
+	}
+}
+
+func TestNotes(t *testing.T) {
+	tmp := t.TempDir()
+	remote := filepath.Join(tmp, "remote.git")
+	gitT(t, "init", "-q", "--bare", remote)
+	seed := filepath.Join(tmp, "seed")
+	gitT(t, "init", "-q", seed)
+	gitT(t, "-C", seed, "-c", "user.name=test", "-c", "user.email=test@localhost", "commit", "-q", "--allow-empty", "-m", "base")
+	gitT(t, "-C", seed, "push", "-q", remote, "HEAD:refs/heads/master")
+	clone := func(name string) *Repo {
+		r, err := CloneOrCheckoutOptions(remote, filepath.Join(tmp, name), "", "master", "cloud", "deploy/", CloneOptions{Quiet: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.SetIdentity("test", "test@localhost")
+		return r
+	}
+	const ref = "refs/notes/test"
+
+	r := clone("a")
+	if err := r.FetchRef(ref); err == nil {
+		t.Error("expected an error fetching a missing ref")
+	}
+	if note, err := r.Note(ref, "HEAD"); err != nil || note != "" {
+		t.Errorf("expected no note, got %q: %v", note, err)
+	}
+	if err := r.SetNote(ref, "HEAD", "deploy/dev abc"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.PushRef(ref); err != nil {
+		t.Fatal(err)
+	}
+
+	other := clone("b")
+	if err := other.FetchRef(ref); err != nil {
+		t.Fatal(err)
+	}
+	if note, err := other.Note(ref, "HEAD"); err != nil || note != "deploy/dev abc\n" {
+		t.Errorf("unexpected note %q: %v", note, err)
+	}
+	if err := other.SetNote(ref, "HEAD", "deploy/dev def"); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.PushRef(ref); err != nil {
+		t.Fatal(err)
+	}
+	// notes pushed by others are not overwritten
+	if err := r.SetNote(ref, "HEAD", "deploy/dev 123"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.PushRef(ref); err == nil {
+		t.Error("expected a rejected push")
+	}
+}
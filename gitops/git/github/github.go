@@ -2,13 +2,18 @@ package github
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
+	"strings"
 
+	"github.com/fasterci/rules_gitops/gitops/git"
 	"github.com/google/go-github/v32/github"
 	"golang.org/x/oauth2"
 )
@@ -17,38 +22,60 @@ var (
 	repoOwner            = flag.String("github_repo_owner", "", "the owner user/organization to use for github api requests")
 	repo                 = flag.String("github_repo", "", "the repo to use for github api requests")
 	pat                  = flag.String("github_access_token", os.Getenv("GITHUB_TOKEN"), "the access token to authenticate requests")
+	pushToken            = flag.String("github_push_token", os.Getenv("GITHUB_PUSH_TOKEN"), "the access token used for git clone, fetch and push, if different from -github_access_token used to create PRs")
 	githubEnterpriseHost = flag.String("github_enterprise_host", "", "The host name of the private enterprise github, e.g. git.corp.adobe.com")
 )
 
-func CreatePR(from, to, title, body string) error {
+// HTTPClient sends API requests. Callers replace it to configure TLS, proxy and timeouts of the API client
+var HTTPClient = http.DefaultClient
+
+// httpClient returns HTTPClient authenticating requests with -github_access_token
+func httpClient(ctx context.Context) (*http.Client, error) {
 	if *repoOwner == "" {
-		return errors.New("github_repo_owner must be set")
+		return nil, errors.New("github_repo_owner must be set")
 	}
 	if *repo == "" {
-		return errors.New("github_repo must be set")
+		return nil, errors.New("github_repo must be set")
 	}
 	if *pat == "" {
-		return errors.New("github_access_token must be set")
+		return nil, errors.New("github_access_token must be set")
 	}
 
-	ctx := context.Background()
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: *pat},
 	)
-	tc := oauth2.NewClient(ctx, ts)
+	tc := *HTTPClient
+	tc.Transport = &oauth2.Transport{Base: HTTPClient.Transport, Source: oauth2.ReuseTokenSource(nil, ts)}
+	return &tc, nil
+}
 
-	var gh *github.Client
+func newClient(ctx context.Context) (*github.Client, error) {
+	tc, err := httpClient(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if *githubEnterpriseHost != "" {
 		baseUrl := "https://" + *githubEnterpriseHost + "/api/v3/"
 		uploadUrl := "https://" + *githubEnterpriseHost + "/api/uploads/"
-		var err error
-		gh, err = github.NewEnterpriseClient(baseUrl, uploadUrl, tc)
-		if err != nil {
-			log.Println("Error in creating github client", err)
-			return nil
-		}
-	} else {
-		gh = github.NewClient(tc)
+		return github.NewEnterpriseClient(baseUrl, uploadUrl, tc)
+	}
+	return github.NewClient(tc), nil
+}
+
+func CreatePR(from, to, title, body string) error {
+	return createPR(from, to, title, body, false)
+}
+
+// CreateDraftPR creates a draft pull request using branch names from and to
+func CreateDraftPR(from, to, title, body string) error {
+	return createPR(from, to, title, body, true)
+}
+
+func createPR(from, to, title, body string, draft bool) error {
+	ctx := context.Background()
+	gh, err := newClient(ctx)
+	if err != nil {
+		return err
 	}
 
 	pr := &github.NewPullRequest{
@@ -58,7 +85,7 @@ func CreatePR(from, to, title, body string) error {
 		Body:                &body,
 		Issue:               nil,
 		MaintainerCanModify: new(bool),
-		Draft:               new(bool),
+		Draft:               &draft,
 	}
 	createdPr, resp, err := gh.PullRequests.Create(ctx, *repoOwner, *repo, pr)
 	if err == nil {
@@ -66,6 +93,11 @@ func CreatePR(from, to, title, body string) error {
 		return err
 	}
 
+	if resp == nil {
+		// transport errors and timeouts have no response
+		return err
+	}
+
 	if resp.StatusCode == http.StatusUnprocessableEntity {
 		// Handle the case: "Create PR" request fails because it already exists
 		log.Println("Reusing existing PR")
@@ -83,3 +115,95 @@ func CreatePR(from, to, title, body string) error {
 
 	return err
 }
+
+// OpenPRs returns open pull requests into branch to
+func OpenPRs(to string) ([]git.PullRequest, error) {
+	ctx := context.Background()
+	gh, err := newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := &github.PullRequestListOptions{
+		State:       "open",
+		Base:        to,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	var prs []git.PullRequest
+	for {
+		page, resp, err := gh.PullRequests.List(ctx, *repoOwner, *repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range page {
+			prs = append(prs, git.PullRequest{
+				ID:     pr.GetNumber(),
+				Source: pr.GetHead().GetRef(),
+				Target: pr.GetBase().GetRef(),
+				Title:  pr.GetTitle(),
+				Body:   pr.GetBody(),
+				URL:    pr.GetHTMLURL(),
+				Draft:  pr.GetDraft(),
+			})
+		}
+		if resp.NextPage == 0 {
+			return prs, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ClosePR closes pr leaving comment on it
+func ClosePR(pr git.PullRequest, comment string) error {
+	ctx := context.Background()
+	gh, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+	if comment != "" {
+		if _, _, err := gh.Issues.CreateComment(ctx, *repoOwner, *repo, pr.ID, &github.IssueComment{Body: &comment}); err != nil {
+			return err
+		}
+	}
+	_, _, err = gh.PullRequests.Edit(ctx, *repoOwner, *repo, pr.ID, &github.PullRequest{State: github.String("closed")})
+	return err
+}
+
+// Check verifies -github_access_token is valid and can open pull requests in the repository
+func Check() error {
+	ctx := context.Background()
+	gh, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+	r, resp, err := gh.Repositories.Get(ctx, *repoOwner, *repo)
+	if err != nil {
+		return fmt.Errorf("unable to access repository %s/%s, check -github_repo_owner, -github_repo and the token: %w", *repoOwner, *repo, err)
+	}
+	// classic tokens report their scopes, fine-grained tokens do not
+	if scopes := resp.Header.Get("X-OAuth-Scopes"); scopes != "" && !strings.Contains(scopes, "repo") {
+		return fmt.Errorf("token scopes %q do not include repo or public_repo", scopes)
+	}
+	if !r.GetPermissions()["push"] {
+		return fmt.Errorf("token has no write access to %s/%s, required to open pull requests", *repoOwner, *repo)
+	}
+	return nil
+}
+
+// DiffURL returns a link to the diff of path in the comparison of branch from against branch to
+func DiffURL(from, to, path string) string {
+	host := "github.com"
+	if *githubEnterpriseHost != "" {
+		host = *githubEnterpriseHost
+	}
+	// github anchors files in the diff view by sha256 of the path
+	sum := sha256.Sum256([]byte(path))
+	return fmt.Sprintf("https://%s/%s/%s/compare/%s...%s#diff-%s", host, *repoOwner, *repo, to, from, hex.EncodeToString(sum[:]))
+}
+
+// PushCredentials returns the git user name and password for -github_push_token or empty strings if it is not set
+func PushCredentials() (user, password string) {
+	if *pushToken == "" {
+		return "", ""
+	}
+	return "x-access-token", *pushToken
+}
@@ -0,0 +1,16 @@
+package github
+
+import "testing"
+
+func TestCreatePRTransportError(t *testing.T) {
+	setFlags(t, "")
+	host := "127.0.0.1:1"
+	oldHost := githubEnterpriseHost
+	githubEnterpriseHost = &host
+	t.Cleanup(func() { githubEnterpriseHost = oldHost })
+
+	// the request fails without a response
+	if err := CreatePR("deploy/prod", "master", "title", "body"); err == nil {
+		t.Error("expected an error")
+	}
+}
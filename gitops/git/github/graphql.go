@@ -0,0 +1,389 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+var useGraphQL = flag.Bool("github_graphql", false, "list, open and close pull requests of all release trains with batched GitHub GraphQL API requests instead of one REST request per operation")
+
+// graphqlBatchSize is the maximum number of operations sent in one GraphQL request
+const graphqlBatchSize = 20
+
+// graphqlURL overrides the GraphQL endpoint in tests
+var graphqlURL string
+
+// GraphQLEnabled reports whether -github_graphql is set
+func GraphQLEnabled() bool {
+	return *useGraphQL
+}
+
+func graphqlEndpoint() string {
+	if graphqlURL != "" {
+		return graphqlURL
+	}
+	if *githubEnterpriseHost != "" {
+		return "https://" + *githubEnterpriseHost + "/api/graphql"
+	}
+	return "https://api.github.com/graphql"
+}
+
+type graphqlError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path"`
+}
+
+// graphqlResult is the data of a GraphQL response by top level field alias and errors of the fields
+type graphqlResult struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []graphqlError             `json:"errors"`
+}
+
+// fieldError returns the error of the field at path or nil
+func (r *graphqlResult) fieldError(path ...string) error {
+	for _, e := range r.Errors {
+		if len(e.Path) != len(path) {
+			continue
+		}
+		matches := true
+		for i := range path {
+			if e.Path[i] != path[i] {
+				matches = false
+			}
+		}
+		if matches {
+			return errors.New(e.Message)
+		}
+	}
+	return nil
+}
+
+// graphql sends query with variables. It fails if the request fails or an error is not related to a field.
+func graphql(ctx context.Context, client *http.Client, query string, vars map[string]interface{}) (*graphqlResult, error) {
+	b, err := json.Marshal(map[string]interface{}{"query": query, "variables": vars})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", graphqlEndpoint(), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github graphql: %s: %s", resp.Status, body)
+	}
+	var r graphqlResult
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("github graphql: %w", err)
+	}
+	for _, e := range r.Errors {
+		if len(e.Path) == 0 {
+			return nil, fmt.Errorf("github graphql: %s", e.Message)
+		}
+	}
+	return &r, nil
+}
+
+// lookupNodes returns the node id of the repository and of its pull requests with numbers in a single request
+func lookupNodes(ctx context.Context, client *http.Client, numbers []int) (repoID string, prIDs map[int]string, err error) {
+	var q strings.Builder
+	q.WriteString("query($owner: String!, $name: String!) {\n  repository(owner: $owner, name: $name) {\n    id\n")
+	for _, n := range numbers {
+		fmt.Fprintf(&q, "    pr%d: pullRequest(number: %d) { id }\n", n, n)
+	}
+	q.WriteString("  }\n}")
+	r, err := graphql(ctx, client, q.String(), map[string]interface{}{"owner": *repoOwner, "name": *repo})
+	if err != nil {
+		return "", nil, err
+	}
+	if err := r.fieldError("repository"); err != nil {
+		return "", nil, fmt.Errorf("unable to find repository %s/%s: %w", *repoOwner, *repo, err)
+	}
+	// the repository object has the id field and a pull request object per alias, null if it does not exist
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(r.Data["repository"], &fields); err != nil {
+		return "", nil, err
+	}
+	if fields == nil {
+		return "", nil, fmt.Errorf("repository %s/%s not found", *repoOwner, *repo)
+	}
+	if err := json.Unmarshal(fields["id"], &repoID); err != nil {
+		return "", nil, err
+	}
+	prIDs = make(map[int]string)
+	for _, n := range numbers {
+		var pr *struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(fields[fmt.Sprintf("pr%d", n)], &pr); err != nil {
+			return "", nil, err
+		}
+		if pr != nil {
+			prIDs[n] = pr.ID
+		}
+	}
+	return repoID, prIDs, nil
+}
+
+// openPRsPageSize is the number of pull requests listed per GraphQL request, the maximum the API allows
+const openPRsPageSize = 100
+
+// QueryOpenPRs returns open pull requests into branch to with one GraphQL query per 100 pull requests
+func QueryOpenPRs(to string) ([]git.PullRequest, error) {
+	ctx := context.Background()
+	client, err := httpClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := fmt.Sprintf(`query($owner: String!, $name: String!, $base: String!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequests(states: OPEN, baseRefName: $base, first: %d, after: $after) {
+      nodes { number headRefName baseRefName title body url isDraft }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`, openPRsPageSize)
+	vars := map[string]interface{}{"owner": *repoOwner, "name": *repo, "base": to, "after": nil}
+	var prs []git.PullRequest
+	for {
+		r, err := graphql(ctx, client, q, vars)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.fieldError("repository"); err != nil {
+			return nil, fmt.Errorf("unable to find repository %s/%s: %w", *repoOwner, *repo, err)
+		}
+		var repository *struct {
+			PullRequests struct {
+				Nodes []struct {
+					Number      int    `json:"number"`
+					HeadRefName string `json:"headRefName"`
+					BaseRefName string `json:"baseRefName"`
+					Title       string `json:"title"`
+					Body        string `json:"body"`
+					URL         string `json:"url"`
+					IsDraft     bool   `json:"isDraft"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"pullRequests"`
+		}
+		if err := json.Unmarshal(r.Data["repository"], &repository); err != nil {
+			return nil, err
+		}
+		if repository == nil {
+			return nil, fmt.Errorf("repository %s/%s not found", *repoOwner, *repo)
+		}
+		for _, pr := range repository.PullRequests.Nodes {
+			prs = append(prs, git.PullRequest{
+				ID:     pr.Number,
+				Source: pr.HeadRefName,
+				Target: pr.BaseRefName,
+				Title:  pr.Title,
+				Body:   pr.Body,
+				URL:    pr.URL,
+				Draft:  pr.IsDraft,
+			})
+		}
+		if !repository.PullRequests.PageInfo.HasNextPage {
+			return prs, nil
+		}
+		vars["after"] = repository.PullRequests.PageInfo.EndCursor
+	}
+}
+
+// CreatePRs opens pull requests with batched createPullRequest mutations
+func CreatePRs(prs []git.NewPullRequest) (urls []string, errs []error) {
+	urls = make([]string, len(prs))
+	errs = make([]error, len(prs))
+	failAll := func(err error) ([]string, []error) {
+		for i := range errs {
+			errs[i] = err
+		}
+		return urls, errs
+	}
+	if len(prs) == 0 {
+		return urls, errs
+	}
+	ctx := context.Background()
+	client, err := httpClient(ctx)
+	if err != nil {
+		return failAll(err)
+	}
+	repoID, _, err := lookupNodes(ctx, client, nil)
+	if err != nil {
+		return failAll(err)
+	}
+	for start := 0; start < len(prs); start += graphqlBatchSize {
+		end := start + graphqlBatchSize
+		if end > len(prs) {
+			end = len(prs)
+		}
+		var decls []string
+		var fields strings.Builder
+		vars := make(map[string]interface{})
+		for i := start; i < end; i++ {
+			pr := prs[i]
+			body := pr.Body
+			if body == "" {
+				body = pr.Title
+			}
+			decls = append(decls, fmt.Sprintf("$in%d: CreatePullRequestInput!", i))
+			fmt.Fprintf(&fields, "  pr%d: createPullRequest(input: $in%d) { pullRequest { url } }\n", i, i)
+			vars[fmt.Sprintf("in%d", i)] = map[string]interface{}{
+				"repositoryId":        repoID,
+				"headRefName":         pr.Source,
+				"baseRefName":         pr.Target,
+				"title":               pr.Title,
+				"body":                body,
+				"draft":               pr.Draft,
+				"maintainerCanModify": false,
+			}
+		}
+		q := "mutation(" + strings.Join(decls, ", ") + ") {\n" + fields.String() + "}"
+		r, err := graphql(ctx, client, q, vars)
+		for i := start; i < end; i++ {
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			alias := fmt.Sprintf("pr%d", i)
+			if ferr := r.fieldError(alias); ferr != nil {
+				if strings.Contains(ferr.Error(), "already exists") {
+					// same as the REST API: the PR of the deployment branch is reused
+					log.Println("Reusing existing PR from", prs[i].Source)
+					continue
+				}
+				errs[i] = ferr
+				continue
+			}
+			var created struct {
+				PullRequest struct {
+					URL string `json:"url"`
+				} `json:"pullRequest"`
+			}
+			if err := json.Unmarshal(r.Data[alias], &created); err != nil {
+				errs[i] = err
+				continue
+			}
+			urls[i] = created.PullRequest.URL
+			log.Println("Created PR: ", urls[i])
+		}
+	}
+	return urls, errs
+}
+
+// ClosePRs closes pull requests leaving comments on them with batched addComment and closePullRequest mutations
+func ClosePRs(prs []git.PullRequest, comments []string) []error {
+	errs := make([]error, len(prs))
+	if len(prs) == 0 {
+		return errs
+	}
+	ctx := context.Background()
+	client, err := httpClient(ctx)
+	if err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	for start := 0; start < len(prs); start += graphqlBatchSize {
+		end := start + graphqlBatchSize
+		if end > len(prs) {
+			end = len(prs)
+		}
+		var numbers []int
+		for i := start; i < end; i++ {
+			numbers = append(numbers, prs[i].ID)
+		}
+		_, ids, err := lookupNodes(ctx, client, numbers)
+		if err != nil {
+			for i := start; i < end; i++ {
+				errs[i] = err
+			}
+			continue
+		}
+		var decls []string
+		var fields strings.Builder
+		vars := make(map[string]interface{})
+		for i := start; i < end; i++ {
+			id, ok := ids[prs[i].ID]
+			if !ok {
+				errs[i] = fmt.Errorf("pull request %d not found", prs[i].ID)
+				continue
+			}
+			if comments[i] != "" {
+				decls = append(decls, fmt.Sprintf("$comment%d: AddCommentInput!", i))
+				fmt.Fprintf(&fields, "  comment%d: addComment(input: $comment%d) { clientMutationId }\n", i, i)
+				vars[fmt.Sprintf("comment%d", i)] = map[string]interface{}{"subjectId": id, "body": comments[i]}
+			}
+			decls = append(decls, fmt.Sprintf("$close%d: ClosePullRequestInput!", i))
+			fmt.Fprintf(&fields, "  close%d: closePullRequest(input: $close%d) { clientMutationId }\n", i, i)
+			vars[fmt.Sprintf("close%d", i)] = map[string]interface{}{"pullRequestId": id}
+		}
+		if len(decls) == 0 {
+			continue
+		}
+		q := "mutation(" + strings.Join(decls, ", ") + ") {\n" + fields.String() + "}"
+		r, err := graphql(ctx, client, q, vars)
+		for i := start; i < end; i++ {
+			switch {
+			case errs[i] != nil:
+			case err != nil:
+				errs[i] = err
+			default:
+				if ferr := r.fieldError(fmt.Sprintf("comment%d", i)); ferr != nil {
+					errs[i] = ferr
+				} else if ferr := r.fieldError(fmt.Sprintf("close%d", i)); ferr != nil {
+					errs[i] = ferr
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// MarkReady marks draft pull request pr ready for review. The REST API has no such operation, it uses the
+// markPullRequestReadyForReview mutation.
+func MarkReady(pr git.PullRequest) error {
+	ctx := context.Background()
+	client, err := httpClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, ids, err := lookupNodes(ctx, client, []int{pr.ID})
+	if err != nil {
+		return err
+	}
+	id, ok := ids[pr.ID]
+	if !ok {
+		return fmt.Errorf("pull request %d not found", pr.ID)
+	}
+	q := "mutation($ready: MarkPullRequestReadyForReviewInput!) {\n  ready: markPullRequestReadyForReview(input: $ready) { clientMutationId }\n}"
+	r, err := graphql(ctx, client, q, map[string]interface{}{"ready": map[string]interface{}{"pullRequestId": id}})
+	if err != nil {
+		return err
+	}
+	return r.fieldError("ready")
+}
@@ -0,0 +1,208 @@
+package github
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+// fakeGraphQL answers repository lookups, open pull request queries and mutations the way the GitHub GraphQL API does.
+// Mutations of inputs with the head branch deploy/dev fail because the PR already exists,
+// of deploy/broken because the branch does not exist.
+func fakeGraphQL(t *testing.T, requests *[]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		b, _ := io.ReadAll(r.Body)
+		var raw map[string]interface{}
+		if err := json.Unmarshal(b, &raw); err != nil {
+			t.Fatal(err)
+		}
+		*requests = append(*requests, raw)
+		if err := json.Unmarshal(b, &req); err != nil {
+			t.Fatal(err)
+		}
+		data := make(map[string]interface{})
+		var errs []map[string]interface{}
+		if strings.Contains(req.Query, "pullRequests(") {
+			// two pages of open pull requests
+			page := map[string]interface{}{
+				"nodes": []map[string]interface{}{
+					{"number": 1, "headRefName": "deploy/prod", "baseRefName": "master", "title": "prod", "body": "b1", "url": "https://github.com/org/repo/pull/1", "isDraft": true},
+				},
+				"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "c1"},
+			}
+			if req.Variables["after"] == "c1" {
+				page = map[string]interface{}{
+					"nodes": []map[string]interface{}{
+						{"number": 2, "headRefName": "deploy/dev", "baseRefName": "master", "title": "dev", "body": "b2", "url": "https://github.com/org/repo/pull/2", "isDraft": false},
+					},
+					"pageInfo": map[string]interface{}{"hasNextPage": false, "endCursor": "c2"},
+				}
+			}
+			data["repository"] = map[string]interface{}{"pullRequests": page}
+		} else if strings.HasPrefix(req.Query, "query") {
+			repository := map[string]interface{}{"id": "R_1"}
+			for _, line := range strings.Split(req.Query, "\n") {
+				line = strings.TrimSpace(line)
+				if strings.HasPrefix(line, "pr") {
+					alias := line[:strings.Index(line, ":")]
+					repository[alias] = map[string]string{"id": "PR_" + strings.TrimPrefix(alias, "pr")}
+				}
+			}
+			data["repository"] = repository
+		} else {
+			for name, v := range req.Variables {
+				input := v.(map[string]interface{})
+				switch {
+				case strings.HasPrefix(name, "in"):
+					alias := "pr" + strings.TrimPrefix(name, "in")
+					switch input["headRefName"] {
+					case "deploy/dev":
+						data[alias] = nil
+						errs = append(errs, map[string]interface{}{"message": "A pull request already exists for org:deploy/dev.", "path": []string{alias}})
+					case "deploy/broken":
+						data[alias] = nil
+						errs = append(errs, map[string]interface{}{"message": "Head sha can't be blank", "path": []string{alias}})
+					default:
+						data[alias] = map[string]interface{}{"pullRequest": map[string]string{"url": "https://github.com/org/repo/pull/" + input["headRefName"].(string)}}
+					}
+				default:
+					data[name] = map[string]interface{}{"clientMutationId": nil}
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "errors": errs})
+	}))
+}
+
+func setFlags(t *testing.T, url string) {
+	owner, name, token := "org", "repo", "token"
+	oldOwner, oldRepo, oldPat := repoOwner, repo, pat
+	repoOwner, repo, pat, graphqlURL = &owner, &name, &token, url
+	t.Cleanup(func() {
+		repoOwner, repo, pat, graphqlURL = oldOwner, oldRepo, oldPat, ""
+	})
+}
+
+func TestCreatePRs(t *testing.T) {
+	var requests []map[string]interface{}
+	ts := fakeGraphQL(t, &requests)
+	defer ts.Close()
+	setFlags(t, ts.URL)
+
+	prs := []git.NewPullRequest{
+		{Source: "deploy/prod", Target: "master", Title: "prod", Body: "body", Draft: true},
+		{Source: "deploy/dev", Target: "master", Title: "dev"},
+		{Source: "deploy/broken", Target: "master", Title: "broken"},
+	}
+	urls, errs := CreatePRs(prs)
+	if !reflect.DeepEqual(urls, []string{"https://github.com/org/repo/pull/deploy/prod", "", ""}) {
+		t.Errorf("unexpected urls %v", urls)
+	}
+	if errs[0] != nil || errs[1] != nil || errs[2] == nil || !strings.Contains(errs[2].Error(), "Head sha") {
+		t.Errorf("unexpected errors %v", errs)
+	}
+	// the repository lookup and a single mutation for all PRs
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	vars := requests[1]["variables"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"repositoryId":        "R_1",
+		"headRefName":         "deploy/prod",
+		"baseRefName":         "master",
+		"title":               "prod",
+		"body":                "body",
+		"draft":               true,
+		"maintainerCanModify": false,
+	}
+	if !reflect.DeepEqual(vars["in0"], expected) {
+		t.Errorf("unexpected input %v", vars["in0"])
+	}
+	if body := vars["in1"].(map[string]interface{})["body"]; body != "dev" {
+		t.Errorf("expected the title as the default body, got %v", body)
+	}
+}
+
+func TestClosePRs(t *testing.T) {
+	var requests []map[string]interface{}
+	ts := fakeGraphQL(t, &requests)
+	defer ts.Close()
+	setFlags(t, ts.URL)
+
+	errs := ClosePRs([]git.PullRequest{{ID: 3}, {ID: 5}}, []string{"superseded", ""})
+	if errs[0] != nil || errs[1] != nil {
+		t.Errorf("unexpected errors %v", errs)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	vars := requests[1]["variables"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"comment0": map[string]interface{}{"subjectId": "PR_3", "body": "superseded"},
+		"close0":   map[string]interface{}{"pullRequestId": "PR_3"},
+		"close1":   map[string]interface{}{"pullRequestId": "PR_5"},
+	}
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("unexpected variables %v", vars)
+	}
+}
+
+func TestMarkReady(t *testing.T) {
+	var requests []map[string]interface{}
+	ts := fakeGraphQL(t, &requests)
+	defer ts.Close()
+	setFlags(t, ts.URL)
+
+	if err := MarkReady(git.PullRequest{ID: 7, Source: "deploy/prod", Draft: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if q := requests[1]["query"].(string); !strings.Contains(q, "markPullRequestReadyForReview") {
+		t.Errorf("unexpected mutation %s", q)
+	}
+	vars := requests[1]["variables"].(map[string]interface{})
+	expected := map[string]interface{}{"ready": map[string]interface{}{"pullRequestId": "PR_7"}}
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("unexpected variables %v", vars)
+	}
+}
+
+func TestQueryOpenPRs(t *testing.T) {
+	var requests []map[string]interface{}
+	ts := fakeGraphQL(t, &requests)
+	defer ts.Close()
+	setFlags(t, ts.URL)
+
+	prs, err := QueryOpenPRs("master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []git.PullRequest{
+		{ID: 1, Source: "deploy/prod", Target: "master", Title: "prod", Body: "b1", URL: "https://github.com/org/repo/pull/1", Draft: true},
+		{ID: 2, Source: "deploy/dev", Target: "master", Title: "dev", Body: "b2", URL: "https://github.com/org/repo/pull/2"},
+	}
+	if !reflect.DeepEqual(prs, expected) {
+		t.Errorf("unexpected pull requests %+v", prs)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected a request per page, got %d", len(requests))
+	}
+	if base := requests[0]["variables"].(map[string]interface{})["base"]; base != "master" {
+		t.Errorf("unexpected base %v", base)
+	}
+}
@@ -1,13 +1,18 @@
 package gitlab
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"errors"
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
+	"strings"
 
+	"github.com/fasterci/rules_gitops/gitops/git"
 	"github.com/xanzy/go-gitlab"
 )
 
@@ -15,16 +20,23 @@ var (
 	gitlabHost  = flag.String("gitlab_host", "https://gitlab.com", "The host name of the gitlab instance")
 	repo        = flag.String("gitlab_repo", "", "the repo to use for gitlab api requests")
 	accessToken = flag.String("gitlab_access_token", os.Getenv("GITLAB_TOKEN"), "the access token to authenticate requests")
+	pushToken   = flag.String("gitlab_push_token", os.Getenv("GITLAB_PUSH_TOKEN"), "the access token used for git clone, fetch and push, if different from -gitlab_access_token used to create MRs")
 )
 
-func CreatePR(from, to, title, body string) error {
+// HTTPClient sends API requests. Callers replace it to configure TLS, proxy and timeouts of the API client
+var HTTPClient = http.DefaultClient
+
+func newClient() (*gitlab.Client, error) {
 	if *accessToken == "" {
-		return errors.New("gitlab_access_token must be set")
+		return nil, errors.New("gitlab_access_token must be set")
 	}
+	return gitlab.NewClient(*accessToken, gitlab.WithBaseURL(*gitlabHost), gitlab.WithHTTPClient(HTTPClient))
+}
 
+func CreatePR(from, to, title, body string) error {
 	opts := gitlab.CreateMergeRequestOptions{
 		Title:              &title,
-		Description:        nil,
+		Description:        &body,
 		SourceBranch:       &from,
 		TargetBranch:       &to,
 		Labels:             nil,
@@ -38,7 +50,7 @@ func CreatePR(from, to, title, body string) error {
 		AllowCollaboration: nil,
 	}
 
-	gl, err := gitlab.NewClient(*accessToken, gitlab.WithBaseURL(*gitlabHost))
+	gl, err := newClient()
 	if err != nil {
 		return err
 	}
@@ -49,6 +61,11 @@ func CreatePR(from, to, title, body string) error {
 		return nil
 	}
 
+	if resp == nil {
+		// transport errors and timeouts have no response
+		return err
+	}
+
 	if resp.StatusCode == http.StatusConflict {
 		// Handle the case: "Create MR" request fails because it already exists for this source branch
 		log.Println("Reusing existing MR")
@@ -66,3 +83,120 @@ func CreatePR(from, to, title, body string) error {
 
 	return err
 }
+
+// CreateDraftPR creates a draft merge request using branch names from and to
+func CreateDraftPR(from, to, title, body string) error {
+	return CreatePR(from, to, "Draft: "+title, body)
+}
+
+// OpenPRs returns open merge requests into branch to
+func OpenPRs(to string) ([]git.PullRequest, error) {
+	gl, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+	opts := &gitlab.ListProjectMergeRequestsOptions{
+		ListOptions:  gitlab.ListOptions{PerPage: 100},
+		State:        gitlab.String("opened"),
+		TargetBranch: &to,
+	}
+	var prs []git.PullRequest
+	for {
+		page, resp, err := gl.MergeRequests.ListProjectMergeRequests(*repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, mr := range page {
+			prs = append(prs, git.PullRequest{
+				ID:     mr.IID,
+				Source: mr.SourceBranch,
+				Target: mr.TargetBranch,
+				Title:  mr.Title,
+				Body:   mr.Description,
+				URL:    mr.WebURL,
+				Draft:  mr.Draft || mr.WorkInProgress,
+			})
+		}
+		if resp.NextPage == 0 {
+			return prs, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ClosePR closes merge request pr leaving comment on it
+func ClosePR(pr git.PullRequest, comment string) error {
+	gl, err := newClient()
+	if err != nil {
+		return err
+	}
+	if comment != "" {
+		if _, _, err := gl.Notes.CreateMergeRequestNote(*repo, pr.ID, &gitlab.CreateMergeRequestNoteOptions{Body: &comment}); err != nil {
+			return err
+		}
+	}
+	_, _, err = gl.MergeRequests.UpdateMergeRequest(*repo, pr.ID, &gitlab.UpdateMergeRequestOptions{StateEvent: gitlab.String("close")})
+	return err
+}
+
+// draftPrefixes mark merge requests as draft when the title starts with one of them
+var draftPrefixes = []string{"draft:", "[draft]", "(draft)", "wip:", "[wip]"}
+
+// MarkReady marks draft merge request pr ready by removing the draft prefix from its title
+func MarkReady(pr git.PullRequest) error {
+	gl, err := newClient()
+	if err != nil {
+		return err
+	}
+	title := pr.Title
+	for trimmed := ""; trimmed != title; {
+		trimmed = title
+		for _, p := range draftPrefixes {
+			if strings.HasPrefix(strings.ToLower(title), p) {
+				title = strings.TrimSpace(title[len(p):])
+			}
+		}
+	}
+	_, _, err = gl.MergeRequests.UpdateMergeRequest(*repo, pr.ID, &gitlab.UpdateMergeRequestOptions{Title: &title})
+	return err
+}
+
+// Check verifies -gitlab_access_token is valid and can open merge requests in the project
+func Check() error {
+	gl, err := newClient()
+	if err != nil {
+		return err
+	}
+	p, _, err := gl.Projects.GetProject(*repo, nil)
+	if err != nil {
+		return fmt.Errorf("unable to access project %s, check -gitlab_host, -gitlab_repo and the token: %w", *repo, err)
+	}
+	var level gitlab.AccessLevelValue
+	if p.Permissions != nil {
+		if p.Permissions.ProjectAccess != nil && p.Permissions.ProjectAccess.AccessLevel > level {
+			level = p.Permissions.ProjectAccess.AccessLevel
+		}
+		if p.Permissions.GroupAccess != nil && p.Permissions.GroupAccess.AccessLevel > level {
+			level = p.Permissions.GroupAccess.AccessLevel
+		}
+	}
+	if level < gitlab.DeveloperPermissions {
+		return fmt.Errorf("token has access level %d to %s, developer (30) or higher is required to push branches and open merge requests", level, *repo)
+	}
+	return nil
+}
+
+// DiffURL returns a link to the diff of path in the comparison of branch from against branch to
+func DiffURL(from, to, path string) string {
+	// gitlab anchors files in the diff view by sha1 of the path
+	sum := sha1.Sum([]byte(path))
+	return fmt.Sprintf("%s/%s/-/compare/%s...%s#%s", strings.TrimSuffix(*gitlabHost, "/"), *repo, to, from, hex.EncodeToString(sum[:]))
+}
+
+// PushCredentials returns the git user name and password for -gitlab_push_token or empty strings if it is not set
+func PushCredentials() (user, password string) {
+	if *pushToken == "" {
+		return "", ""
+	}
+	return "oauth2", *pushToken
+}
@@ -1,6 +1,13 @@
 package gitlab
 
-import "testing"
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
 
 func TestCreatePRRemote(t *testing.T) {
 	t.Skip("Manual")
@@ -58,3 +65,51 @@ func TestCreatePRRemote(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffURL(t *testing.T) {
+	r := "group/deployments"
+	old := repo
+	defer func() { repo = old }()
+	repo = &r
+	expected := "https://gitlab.com/group/deployments/-/compare/master...deploy/prod#cf0c1f8a6d7fa0851c58d5fbaaa7cf02d756886d"
+	if u := DiffURL("deploy/prod", "master", "cloud/prod/app.yaml"); u != expected {
+		t.Errorf("unexpected diff url %s", u)
+	}
+}
+
+func TestMarkReady(t *testing.T) {
+	var title string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/api/v4/" {
+			// the client probes the rate limit when it is created
+			return
+		}
+		if r.Method != http.MethodPut || r.URL.EscapedPath() != "/api/v4/projects/group%2Frepo/merge_requests/7" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+		var opts struct {
+			Title string `json:"title"`
+		}
+		json.NewDecoder(r.Body).Decode(&opts)
+		title = opts.Title
+		w.Write([]byte(`{"iid": 7}`))
+	}))
+	defer ts.Close()
+	host, project, token := ts.URL, "group/repo", "token"
+	oldHost, oldRepo, oldToken := gitlabHost, repo, accessToken
+	gitlabHost, repo, accessToken = &host, &project, &token
+	defer func() { gitlabHost, repo, accessToken = oldHost, oldRepo, oldToken }()
+
+	for _, tt := range []struct{ draft, ready string }{
+		{"Draft: deploy prod", "deploy prod"},
+		{"[Draft] WIP: deploy prod", "deploy prod"},
+		{"deploy prod", "deploy prod"},
+	} {
+		if err := MarkReady(git.PullRequest{ID: 7, Title: tt.draft, Draft: true}); err != nil {
+			t.Fatal(err)
+		}
+		if title != tt.ready {
+			t.Errorf("MarkReady(%q) set title %q, expected %q", tt.draft, title, tt.ready)
+		}
+	}
+}
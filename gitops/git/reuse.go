@@ -0,0 +1,93 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/exec"
+)
+
+// ErrUnsafeCheckout is returned when an existing checkout can't be reused without losing or committing local state
+var ErrUnsafeCheckout = errors.New("existing checkout is not safe to reuse")
+
+// inProgress maps state files of the git directory to the interrupted operation and the command aborting it
+var inProgress = []struct {
+	file, operation string
+	abort           []string
+}{
+	{"rebase-merge", "rebase", []string{"rebase", "--abort"}},
+	{"rebase-apply", "rebase", []string{"rebase", "--abort"}},
+	{"MERGE_HEAD", "merge", []string{"merge", "--abort"}},
+	{"CHERRY_PICK_HEAD", "cherry-pick", []string{"cherry-pick", "--abort"}},
+	{"REVERT_HEAD", "revert", []string{"revert", "--abort"}},
+}
+
+// checkReusable verifies an existing checkout in dir uses repo as remote, has no interrupted rebase, merge,
+// cherry-pick or revert and no local changes or untracked files.
+// With clean the problems are fixed instead: the remote url is replaced, interrupted operations are aborted
+// and local changes and untracked files are removed.
+func checkReusable(runner exec.Runner, dir, remote, repo string, clean bool) error {
+	var problems []string
+	url, err := runWith(runner, dir, "remote", "get-url", remote)
+	if err != nil {
+		// a checkout without the remote gets it added
+		if _, err := runWith(runner, dir, "remote", "add", remote, repo); err != nil {
+			return err
+		}
+	} else if url = strings.TrimSpace(url); url != repo {
+		if !clean {
+			problems = append(problems, fmt.Sprintf("remote %s points to %s instead of %s", remote, url, repo))
+		} else if _, err := runWith(runner, dir, "remote", "set-url", remote, repo); err != nil {
+			return err
+		}
+	}
+
+	gitDir, err := runWith(runner, dir, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return err
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	for _, op := range inProgress {
+		if _, err := os.Stat(filepath.Join(gitDir, op.file)); err != nil {
+			continue
+		}
+		if !clean {
+			problems = append(problems, op.operation+" in progress")
+			continue
+		}
+		log.Printf("aborting %s in progress in %s", op.operation, dir)
+		if _, err := runWith(runner, dir, op.abort...); err != nil {
+			return err
+		}
+	}
+
+	status, err := runWith(runner, dir, "status", "--porcelain", "--untracked-files=all")
+	if err != nil {
+		return err
+	}
+	if status = strings.TrimSpace(status); status != "" {
+		if !clean {
+			lines := strings.Split(status, "\n")
+			if len(lines) > 5 {
+				lines = append(lines[:5], "...")
+			}
+			problems = append(problems, "local changes: "+strings.Join(lines, ", "))
+		} else {
+			log.Printf("discarding local changes in %s", dir)
+			if _, err := runWith(runner, dir, "reset", "-q", "--hard"); err != nil {
+				return err
+			}
+			if _, err := runWith(runner, dir, "clean", "-ffdxq"); err != nil {
+				return err
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s: %s", ErrUnsafeCheckout, dir, strings.Join(problems, "; "))
+	}
+	return nil
+}
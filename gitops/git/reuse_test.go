@@ -0,0 +1,67 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func gitT(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestReuseCheckout(t *testing.T) {
+	tmp := t.TempDir()
+	remote := filepath.Join(tmp, "remote.git")
+	other := filepath.Join(tmp, "other")
+	dir := filepath.Join(tmp, "gitops")
+	gitT(t, "init", "-q", "--bare", remote)
+	gitT(t, "init", "-q", other)
+	commit := func(msg string) {
+		gitT(t, "-C", other, "-c", "user.name=test", "-c", "user.email=test@localhost", "commit", "-q", "--allow-empty", "-m", msg)
+		gitT(t, "-C", other, "push", "-q", remote, "HEAD:refs/heads/master")
+	}
+	commit("first")
+
+	opts := CloneOptions{Quiet: true}
+	if _, err := CloneOrCheckoutOptions(remote, dir, "", "master", "cloud", "deploy/", opts); err != nil {
+		t.Fatal(err)
+	}
+
+	// leftovers of a previous run are not reused
+	commit("second")
+	if err := os.MkdirAll(filepath.Join(dir, "cloud"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cloud", "leftover.yaml"), []byte("a: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := CloneOrCheckoutOptions(remote, dir, "", "master", "cloud", "deploy/", opts)
+	if !errors.Is(err, ErrUnsafeCheckout) || !strings.Contains(err.Error(), "leftover.yaml") {
+		t.Fatalf("expected ErrUnsafeCheckout for local changes, got %v", err)
+	}
+
+	// a checkout of another repository is not reused
+	if _, err := CloneOrCheckoutOptions(other, dir, "", "master", "cloud", "deploy/", opts); !errors.Is(err, ErrUnsafeCheckout) {
+		t.Fatalf("expected ErrUnsafeCheckout for another remote, got %v", err)
+	}
+
+	opts.Clean = true
+	if _, err := CloneOrCheckoutOptions(remote, dir, "", "master", "cloud", "deploy/", opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "cloud", "leftover.yaml")); !os.IsNotExist(err) {
+		t.Errorf("expected leftover file to be removed, got %v", err)
+	}
+	if head, expected := gitT(t, "-C", dir, "rev-parse", "HEAD"), gitT(t, "-C", other, "rev-parse", "HEAD"); head != expected {
+		t.Errorf("expected the reused checkout to be at the remote commit %s, got %s", expected, head)
+	}
+}
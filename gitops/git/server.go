@@ -13,3 +13,82 @@ func (f ServerFunc) CreatePR(from, to, title, body string) error {
 
 	return f(from, to, title, body)
 }
+
+// PullRequest is an open pull request found on the git server
+type PullRequest struct {
+	// ID is the pull request number on the git server
+	ID     int
+	Source string
+	Target string
+	Title  string
+	Body   string
+	URL    string
+	// Draft is set for draft pull requests that are not ready for review
+	Draft bool
+}
+
+// Reconciler finds and closes pull requests created by previous runs
+type Reconciler interface {
+	// OpenPRs returns open pull requests into branch to
+	OpenPRs(to string) ([]PullRequest, error)
+	// ClosePR closes pr leaving comment on it
+	ClosePR(pr PullRequest, comment string) error
+}
+
+// ReconcilerFuncs adapts a pair of functions to the Reconciler interface
+type ReconcilerFuncs struct {
+	List  func(to string) ([]PullRequest, error)
+	Close func(pr PullRequest, comment string) error
+}
+
+func (f ReconcilerFuncs) OpenPRs(to string) ([]PullRequest, error) {
+	return f.List(to)
+}
+
+func (f ReconcilerFuncs) ClosePR(pr PullRequest, comment string) error {
+	return f.Close(pr, comment)
+}
+
+// ReadyMarker marks draft pull requests ready for review
+type ReadyMarker interface {
+	MarkReady(pr PullRequest) error
+}
+
+// ReadyFunc adapts a function to the ReadyMarker interface
+type ReadyFunc func(pr PullRequest) error
+
+func (f ReadyFunc) MarkReady(pr PullRequest) error {
+	return f(pr)
+}
+
+// NewPullRequest is a pull request to open
+type NewPullRequest struct {
+	Source string
+	Target string
+	Title  string
+	Body   string
+	Draft  bool
+}
+
+// BatchServer opens and closes several pull requests with few requests to the git server
+type BatchServer interface {
+	// CreatePRs opens prs. It returns web links of the opened pull requests, empty for pull requests that already
+	// existed, and errors. Both are aligned with prs.
+	CreatePRs(prs []NewPullRequest) (urls []string, errs []error)
+	// ClosePRs closes prs leaving the comment of the same index on each of them. Errors are aligned with prs.
+	ClosePRs(prs []PullRequest, comments []string) []error
+}
+
+// BatchFuncs adapts a pair of functions to the BatchServer interface
+type BatchFuncs struct {
+	Create func(prs []NewPullRequest) ([]string, []error)
+	Close  func(prs []PullRequest, comments []string) []error
+}
+
+func (f BatchFuncs) CreatePRs(prs []NewPullRequest) ([]string, []error) {
+	return f.Create(prs)
+}
+
+func (f BatchFuncs) ClosePRs(prs []PullRequest, comments []string) []error {
+	return f.Close(prs, comments)
+}
@@ -0,0 +1,101 @@
+package imagepolicy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+)
+
+// Policy restricts image references used in rendered manifests
+type Policy struct {
+	// AllowedRepositories lists registries or repository prefixes images must come from.
+	// "gcr.io/project" allows gcr.io/project/app and gcr.io/project/team/app. Empty list allows everything.
+	AllowedRepositories []string
+	// DeniedTags lists tags that must not be used, like "latest"
+	DeniedTags []string
+	// RequireDigest rejects images not pinned by digest
+	RequireDigest bool
+}
+
+// Enabled returns true if the policy has any restrictions
+func (p *Policy) Enabled() bool {
+	return len(p.AllowedRepositories) > 0 || len(p.DeniedTags) > 0 || p.RequireDigest
+}
+
+// Violation is an image reference not allowed by the policy
+type Violation struct {
+	File   string `json:"file"`
+	Image  string `json:"image"`
+	Reason string `json:"reason"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: image %s: %s", v.File, v.Image, v.Reason)
+}
+
+// Check returns reasons why the image is not allowed by the policy
+func (p *Policy) Check(image string) []string {
+	var reasons []string
+	repository, tag, digest := manifests.SplitImage(image)
+	if len(p.AllowedRepositories) > 0 {
+		normalized := Normalize(repository)
+		allowed := false
+		for _, a := range p.AllowedRepositories {
+			a = strings.TrimSuffix(Normalize(a), "/")
+			if normalized == a || strings.HasPrefix(normalized, a+"/") {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			reasons = append(reasons, fmt.Sprintf("repository %s is not in the allowed list", normalized))
+		}
+	}
+	if tag == "" && digest == "" {
+		// implicit latest
+		tag = "latest"
+	}
+	if tag != "" && digest == "" {
+		for _, denied := range p.DeniedTags {
+			if tag == denied {
+				reasons = append(reasons, fmt.Sprintf("tag %q is denied", tag))
+			}
+		}
+	}
+	if p.RequireDigest && digest == "" {
+		reasons = append(reasons, "image is not pinned by digest")
+	}
+	return reasons
+}
+
+// CheckFile returns violations of the policy by images used in a manifest file
+func (p *Policy) CheckFile(path string) ([]Violation, error) {
+	images, err := manifests.ImagesInFiles([]string{path})
+	if err != nil {
+		return nil, err
+	}
+	var violations []Violation
+	for _, img := range images {
+		for _, reason := range p.Check(img) {
+			violations = append(violations, Violation{File: path, Image: img, Reason: reason})
+		}
+	}
+	return violations, nil
+}
+
+// Normalize expands a repository name to its fully qualified form the same way docker does:
+// "busybox" becomes "docker.io/library/busybox" and "org/app" becomes "docker.io/org/app".
+func Normalize(repository string) string {
+	first, rest, found := strings.Cut(repository, "/")
+	if !found {
+		return "docker.io/library/" + repository
+	}
+	if !strings.ContainsAny(first, ".:") && first != "localhost" {
+		return "docker.io/" + repository
+	}
+	if first == "index.docker.io" {
+		return "docker.io/" + rest
+	}
+	return repository
+}
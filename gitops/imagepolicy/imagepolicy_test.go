@@ -0,0 +1,56 @@
+package imagepolicy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"busybox":                  "docker.io/library/busybox",
+		"org/app":                  "docker.io/org/app",
+		"index.docker.io/org/app":  "docker.io/org/app",
+		"gcr.io/project/app":       "gcr.io/project/app",
+		"localhost/app":            "localhost/app",
+		"registry.local:5000/team": "registry.local:5000/team",
+	}
+	for in, expected := range tests {
+		if got := Normalize(in); got != expected {
+			t.Errorf("Normalize(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p := Policy{
+		AllowedRepositories: []string{"gcr.io/project", "docker.io/library/busybox"},
+		DeniedTags:          []string{"latest"},
+	}
+	tests := []struct {
+		image   string
+		reasons []string
+	}{
+		{"gcr.io/project/app:v1", nil},
+		{"gcr.io/project/team/app@sha256:abc", nil},
+		{"busybox:1.36", nil},
+		{"gcr.io/projectx/app:v1", []string{"repository gcr.io/projectx/app is not in the allowed list"}},
+		{"gcr.io/project/app", []string{`tag "latest" is denied`}},
+		{"quay.io/app:latest", []string{"repository quay.io/app is not in the allowed list", `tag "latest" is denied`}},
+		{"gcr.io/project/app:latest@sha256:abc", nil},
+	}
+	for _, tt := range tests {
+		if reasons := p.Check(tt.image); !reflect.DeepEqual(reasons, tt.reasons) {
+			t.Errorf("Check(%q) = %v, expected %v", tt.image, reasons, tt.reasons)
+		}
+	}
+}
+
+func TestRequireDigest(t *testing.T) {
+	p := Policy{RequireDigest: true}
+	if reasons := p.Check("gcr.io/project/app:v1"); len(reasons) != 1 {
+		t.Error("expected digest violation", reasons)
+	}
+	if reasons := p.Check("gcr.io/project/app@sha256:abc"); len(reasons) != 0 {
+		t.Error("unexpected violation", reasons)
+	}
+}
@@ -0,0 +1,87 @@
+package manifests
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ImageChange is a difference in versions of an image repository between two sets of image references
+type ImageChange struct {
+	Repository string
+	// Old and New are comma separated versions of the repository. Old is empty for added and New for removed images
+	Old string
+	New string
+}
+
+func (c ImageChange) String() string {
+	switch {
+	case c.Old == "":
+		return fmt.Sprintf("image %s: added %s", c.Repository, c.New)
+	case c.New == "":
+		return fmt.Sprintf("image %s: removed %s", c.Repository, c.Old)
+	}
+	return fmt.Sprintf("image %s: %s → %s", c.Repository, c.Old, c.New)
+}
+
+// ImageChanges compares image references by repository and returns changes sorted by repository
+func ImageChanges(old, new []string) []ImageChange {
+	oldVersions := versionsByRepository(old)
+	newVersions := versionsByRepository(new)
+	repos := make(map[string]bool)
+	for r := range oldVersions {
+		repos[r] = true
+	}
+	for r := range newVersions {
+		repos[r] = true
+	}
+	var changes []ImageChange
+	for r := range repos {
+		if o, n := oldVersions[r], newVersions[r]; o != n {
+			changes = append(changes, ImageChange{Repository: r, Old: o, New: n})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Repository < changes[j].Repository })
+	return changes
+}
+
+func versionsByRepository(images []string) map[string]string {
+	sets := make(map[string]map[string]bool)
+	for _, img := range images {
+		repository, tag, digest := SplitImage(img)
+		if sets[repository] == nil {
+			sets[repository] = make(map[string]bool)
+		}
+		sets[repository][imageVersion(tag, digest)] = true
+	}
+	versions := make(map[string]string)
+	for r, set := range sets {
+		vv := make([]string, 0, len(set))
+		for v := range set {
+			vv = append(vv, v)
+		}
+		sort.Strings(vv)
+		versions[r] = strings.Join(vv, ", ")
+	}
+	return versions
+}
+
+// imageVersion formats tag and digest like v1.2.3 (sha256:0123456789ab)
+func imageVersion(tag, digest string) string {
+	if digest != "" {
+		algo, hex, _ := strings.Cut(digest, ":")
+		if len(hex) > 12 {
+			hex = hex[:12]
+		}
+		digest = algo + ":" + hex
+	}
+	switch {
+	case tag != "" && digest != "":
+		return tag + " (" + digest + ")"
+	case digest != "":
+		return digest
+	case tag != "":
+		return tag
+	}
+	return "latest"
+}
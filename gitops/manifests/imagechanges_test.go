@@ -0,0 +1,32 @@
+package manifests
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImageChanges(t *testing.T) {
+	old := []string{
+		"gcr.io/p/app:v1.2.3@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		"gcr.io/p/sidecar:1.0",
+		"redis:7",
+	}
+	new := []string{
+		"gcr.io/p/app:v1.2.4@sha256:fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9",
+		"gcr.io/p/sidecar:1.0",
+		"busybox",
+	}
+	changes := ImageChanges(old, new)
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	expected := []string{
+		"image busybox: added latest",
+		"image gcr.io/p/app: v1.2.3 (sha256:2c26b46b68ff) → v1.2.4 (sha256:fcde2b2edba5)",
+		"image redis: removed 7",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected changes %q", got)
+	}
+}
@@ -0,0 +1,73 @@
+package manifests
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+
+	"github.com/fasterci/rules_gitops/gitops/fsys"
+)
+
+// KustomizationFile is the name of index files written by WriteKustomization
+const KustomizationFile = "kustomization.yaml"
+
+// KustomizationHeader marks kustomization files maintained by WriteKustomization
+const KustomizationHeader = "# Code generated by rules_gitops, DO NOT EDIT.\n"
+
+// kustomizationNames are file names kustomize recognizes as kustomizations, they are never listed as resources
+var kustomizationNames = map[string]bool{"kustomization.yaml": true, "kustomization.yml": true, "Kustomization": true}
+
+// WriteKustomization writes a kustomization.yaml into dir listing yaml and json files of dir as resources in lexical order.
+// The file is removed if dir has no manifests left. Kustomizations not written by WriteKustomization are left untouched
+// and false is returned.
+func WriteKustomization(dir string) (bool, error) {
+	return WriteKustomizationFS(fsys.OS(dir), ".")
+}
+
+// WriteKustomizationFS is WriteKustomization for the directory dir of files
+func WriteKustomizationFS(files fsys.FS, dir string) (bool, error) {
+	entries, err := fs.ReadDir(files, dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	name := path.Join(dir, KustomizationFile)
+	var resources []string
+	for _, e := range entries {
+		if kustomizationNames[e.Name()] {
+			if e.Name() != KustomizationFile {
+				return false, nil
+			}
+			b, err := fs.ReadFile(files, name)
+			if err != nil {
+				return false, err
+			}
+			if !bytes.HasPrefix(b, []byte(KustomizationHeader)) {
+				return false, nil
+			}
+			continue
+		}
+		if !e.IsDir() && IsManifest(e.Name()) {
+			resources = append(resources, e.Name())
+		}
+	}
+	if len(resources) == 0 {
+		if err := files.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return false, err
+		}
+		return true, nil
+	}
+	sort.Strings(resources)
+	var buf bytes.Buffer
+	buf.WriteString(KustomizationHeader)
+	buf.WriteString("apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nresources:\n")
+	for _, r := range resources {
+		fmt.Fprintf(&buf, "- %s\n", r)
+	}
+	return true, files.WriteFile(name, buf.Bytes(), 0644)
+}
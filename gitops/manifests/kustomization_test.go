@@ -0,0 +1,87 @@
+package manifests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fasterci/rules_gitops/gitops/fsys"
+)
+
+func TestWriteKustomization(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"service.yaml", "deployment.yaml", "config.json", "README.md"} {
+		if err := os.WriteFile(filepath.Join(dir, f), []byte(configMap), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub.yaml"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ok, err := WriteKustomization(dir)
+	if err != nil || !ok {
+		t.Fatalf("WriteKustomization() = %v, %v", ok, err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, KustomizationFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := KustomizationHeader + `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- config.json
+- deployment.yaml
+- service.yaml
+`
+	if string(b) != expected {
+		t.Errorf("unexpected kustomization:\n%s", b)
+	}
+
+	// regenerating does not list the kustomization itself and removes it once manifests are gone
+	for _, f := range []string{"service.yaml", "deployment.yaml", "config.json"} {
+		if err := os.Remove(filepath.Join(dir, f)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ok, err := WriteKustomization(dir); err != nil || !ok {
+		t.Fatalf("WriteKustomization() = %v, %v", ok, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, KustomizationFile)); !os.IsNotExist(err) {
+		t.Errorf("expected kustomization to be removed, got %v", err)
+	}
+
+	// hand written kustomizations are kept
+	custom := "resources:\n- service.yaml\n"
+	if err := os.WriteFile(filepath.Join(dir, KustomizationFile), []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := WriteKustomization(dir); err != nil || ok {
+		t.Fatalf("WriteKustomization() = %v, %v, expected the custom kustomization to be kept", ok, err)
+	}
+	if b, _ := os.ReadFile(filepath.Join(dir, KustomizationFile)); string(b) != custom {
+		t.Errorf("custom kustomization was modified:\n%s", b)
+	}
+}
+
+func TestWriteKustomizationFS(t *testing.T) {
+	files := fsys.NewMem(map[string]string{
+		"cloud/prod/service.yaml":       configMap,
+		"cloud/prod/kustomization.yaml": KustomizationHeader + "resources:\n- old.yaml\n",
+		"cloud/dev/kustomization.yaml":  KustomizationHeader + "resources:\n- gone.yaml\n",
+	})
+	for _, dir := range []string{"cloud/prod", "cloud/dev"} {
+		if ok, err := WriteKustomizationFS(files, dir); err != nil || !ok {
+			t.Fatalf("WriteKustomizationFS(%s) = %v, %v", dir, ok, err)
+		}
+	}
+	b, err := files.ReadFile("cloud/prod/kustomization.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := KustomizationHeader + "apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nresources:\n- service.yaml\n"; string(b) != expected {
+		t.Errorf("unexpected kustomization:\n%s", b)
+	}
+	if names := files.Files(); len(names) != 2 {
+		t.Errorf("expected the empty index to be removed, got %v", names)
+	}
+}
@@ -0,0 +1,138 @@
+package manifests
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// IsManifest returns true if the file name has a yaml or json extension
+func IsManifest(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// Decode reads all objects from a yaml or json stream. Empty documents are skipped.
+func Decode(in io.Reader) ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(in, 1024)
+	var objs []*unstructured.Unstructured
+	var err error
+	for err == nil || isEmptyYamlError(err) {
+		var obj unstructured.Unstructured
+		err = decoder.Decode(&obj)
+		if err != nil {
+			continue
+		}
+		objs = append(objs, &obj)
+	}
+	if err != io.EOF {
+		return nil, err
+	}
+	return objs, nil
+}
+
+// DecodeFile reads all objects from a yaml or json file
+func DecodeFile(path string) ([]*unstructured.Unstructured, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f)
+}
+
+// DecodeDir reads all objects from yaml and json files found recursively in dir.
+// Files are read in lexical order.
+func DecodeDir(dir string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !IsManifest(path) {
+			return err
+		}
+		o, err := DecodeFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+		objs = append(objs, o...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
+
+func isEmptyYamlError(err error) bool {
+	return strings.Contains(err.Error(), "is missing in 'null'")
+}
+
+// Images returns sorted unique image references found in objects.
+// Any string value of an "image" field is considered an image reference, so custom resources are covered too.
+func Images(objs []*unstructured.Unstructured) []string {
+	set := make(map[string]bool)
+	for _, obj := range objs {
+		findImages(obj.Object, set)
+	}
+	images := make([]string, 0, len(set))
+	for img := range set {
+		images = append(images, img)
+	}
+	sort.Strings(images)
+	return images
+}
+
+// ImagesInFiles returns sorted unique image references found in yaml and json files.
+// Files with other extensions are ignored.
+func ImagesInFiles(paths []string) ([]string, error) {
+	var objs []*unstructured.Unstructured
+	for _, path := range paths {
+		if !IsManifest(path) {
+			continue
+		}
+		o, err := DecodeFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+		objs = append(objs, o...)
+	}
+	return Images(objs), nil
+}
+
+func findImages(v interface{}, set map[string]bool) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if s, ok := val.(string); ok && k == "image" && s != "" {
+				set[s] = true
+				continue
+			}
+			findImages(val, set)
+		}
+	case []interface{}:
+		for _, val := range t {
+			findImages(val, set)
+		}
+	}
+}
+
+// SplitImage splits an image reference into repository, tag and digest.
+// Tag and digest are empty if not present.
+func SplitImage(image string) (repository, tag, digest string) {
+	repository, digest, _ = strings.Cut(image, "@")
+	// tag separator is the last colon after the last slash, colon before it belongs to registry port
+	slash := strings.LastIndex(repository, "/")
+	if colon := strings.LastIndex(repository, ":"); colon > slash {
+		repository, tag = repository[:colon], repository[colon+1:]
+	}
+	return
+}
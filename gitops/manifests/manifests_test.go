@@ -0,0 +1,88 @@
+package manifests
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: busybox:1.36
+      containers:
+      - name: app
+        image: gcr.io/project/app@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+---
+---
+apiVersion: flink.apache.org/v1beta1
+kind: FlinkDeployment
+metadata:
+  name: job
+spec:
+  image: localhost:5000/flink:1.17
+`
+
+func TestImages(t *testing.T) {
+	objs, err := Decode(strings.NewReader(deployment))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("expected 2 objects, got %d", len(objs))
+	}
+	expected := []string{
+		"busybox:1.36",
+		"gcr.io/project/app@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		"localhost:5000/flink:1.17",
+	}
+	if images := Images(objs); !reflect.DeepEqual(images, expected) {
+		t.Error("unexpected images", images)
+	}
+}
+
+func TestSplitImage(t *testing.T) {
+	tests := []struct {
+		image, repository, tag, digest string
+	}{
+		{"busybox", "busybox", "", ""},
+		{"busybox:1.36", "busybox", "1.36", ""},
+		{"localhost:5000/flink:1.17", "localhost:5000/flink", "1.17", ""},
+		{"localhost:5000/flink", "localhost:5000/flink", "", ""},
+		{"gcr.io/p/app:v1@sha256:abc", "gcr.io/p/app", "v1", "sha256:abc"},
+	}
+	for _, tt := range tests {
+		repository, tag, digest := SplitImage(tt.image)
+		if repository != tt.repository || tag != tt.tag || digest != tt.digest {
+			t.Errorf("SplitImage(%q) = %q, %q, %q", tt.image, repository, tag, digest)
+		}
+	}
+}
+
+func TestDecodeDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "ns"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ns", "app.yaml"), []byte(deployment), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# not a manifest"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	objs, err := DecodeDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 2 || objs[0].GetKind() != "Deployment" || objs[1].GetKind() != "FlinkDeployment" {
+		t.Errorf("unexpected objects %v", objs)
+	}
+}
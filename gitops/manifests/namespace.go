@@ -0,0 +1,45 @@
+package manifests
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Namespaces returns sorted unique namespaces objects are placed in.
+// Namespaces defined by Namespace objects in objs are excluded.
+func Namespaces(objs []*unstructured.Unstructured) []string {
+	defined := make(map[string]bool)
+	for _, obj := range objs {
+		if obj.GetAPIVersion() == "v1" && obj.GetKind() == "Namespace" {
+			defined[obj.GetName()] = true
+		}
+	}
+	set := make(map[string]bool)
+	for _, obj := range objs {
+		if ns := obj.GetNamespace(); ns != "" && !defined[ns] {
+			set[ns] = true
+		}
+	}
+	namespaces := make([]string, 0, len(set))
+	for ns := range set {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// NewNamespace returns a Namespace object with the labels and annotations. Empty maps are omitted.
+func NewNamespace(name string, labels, annotations map[string]string) *unstructured.Unstructured {
+	ns := &unstructured.Unstructured{}
+	ns.SetAPIVersion("v1")
+	ns.SetKind("Namespace")
+	ns.SetName(name)
+	if len(labels) > 0 {
+		ns.SetLabels(labels)
+	}
+	if len(annotations) > 0 {
+		ns.SetAnnotations(annotations)
+	}
+	return ns
+}
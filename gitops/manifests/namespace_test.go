@@ -0,0 +1,66 @@
+package manifests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+const namespaced = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: defined
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+  namespace: team-b
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+  namespace: defined
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: c
+  namespace: team-a
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: d
+`
+
+func TestNamespaces(t *testing.T) {
+	objs, err := Decode(strings.NewReader(namespaced))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ns := Namespaces(objs); !reflect.DeepEqual(ns, []string{"team-a", "team-b"}) {
+		t.Error("unexpected namespaces", ns)
+	}
+}
+
+func TestNewNamespace(t *testing.T) {
+	ns := NewNamespace("team-a", map[string]string{"istio-injection": "enabled"}, nil)
+	b, err := yaml.Marshal(ns.Object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `apiVersion: v1
+kind: Namespace
+metadata:
+  labels:
+    istio-injection: enabled
+  name: team-a
+`
+	if string(b) != expected {
+		t.Errorf("unexpected namespace:\n%s", b)
+	}
+}
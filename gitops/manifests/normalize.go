@@ -0,0 +1,59 @@
+package manifests
+
+import (
+	"bytes"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ServerFields are fields populated by the API server that some renderers emit, like kubectl create --dry-run.
+// They don't change the desired state of the object.
+var ServerFields = [][]string{
+	{"status"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "generation"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "uid"},
+	{"metadata", "selfLink"},
+	{"metadata", "managedFields"},
+}
+
+// StripServerFields removes ServerFields from the object
+func StripServerFields(obj *unstructured.Unstructured) {
+	for _, f := range ServerFields {
+		unstructured.RemoveNestedField(obj.Object, f...)
+	}
+	// an empty metadata left after stripping is equivalent to no metadata
+	if m, ok := obj.Object["metadata"].(map[string]interface{}); ok && len(m) == 0 {
+		delete(obj.Object, "metadata")
+	}
+}
+
+// EquivalentManifests returns true if both yaml or json streams contain the same objects in the same order
+// once ServerFields and ignored fields are stripped. Formatting and comments are ignored.
+func EquivalentManifests(a, b []byte, ignored ...[]string) (bool, error) {
+	oa, err := Decode(bytes.NewReader(a))
+	if err != nil {
+		return false, err
+	}
+	ob, err := Decode(bytes.NewReader(b))
+	if err != nil {
+		return false, err
+	}
+	if len(oa) != len(ob) {
+		return false, nil
+	}
+	for i := range oa {
+		for _, f := range ignored {
+			unstructured.RemoveNestedField(oa[i].Object, f...)
+			unstructured.RemoveNestedField(ob[i].Object, f...)
+		}
+		StripServerFields(oa[i])
+		StripServerFields(ob[i])
+		if !reflect.DeepEqual(oa[i].Object, ob[i].Object) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,58 @@
+package manifests
+
+import "testing"
+
+const configMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cfg
+  labels:
+    app: demo
+data:
+  key: value
+`
+
+func TestEquivalentManifests(t *testing.T) {
+	tests := []struct {
+		name       string
+		a, b       string
+		equivalent bool
+	}{
+		{"identical", configMap, configMap, true},
+		{"server fields", configMap, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  creationTimestamp: null
+  generation: 3
+  name: cfg
+  labels: {app: demo}
+data:
+  key: value
+status: {}
+`, true},
+		{"json", configMap, `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cfg","labels":{"app":"demo"}},"data":{"key":"value"}}`, true},
+		{"data change", configMap, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cfg
+  labels:
+    app: demo
+data:
+  key: other
+`, false},
+		{"extra object", configMap, configMap + "---\n" + configMap, false},
+	}
+	for _, tt := range tests {
+		eq, err := EquivalentManifests([]byte(tt.a), []byte(tt.b))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if eq != tt.equivalent {
+			t.Errorf("%s: expected equivalent=%v", tt.name, tt.equivalent)
+		}
+	}
+	if _, err := EquivalentManifests([]byte(configMap), []byte("key: [")); err == nil {
+		t.Error("expected an error for invalid yaml")
+	}
+}
@@ -0,0 +1,67 @@
+package manifests
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// Stamp adds labels and annotations to the object metadata. Values already set on the object are kept.
+func Stamp(obj *unstructured.Unstructured, labels, annotations map[string]string) {
+	if len(labels) > 0 {
+		obj.SetLabels(merge(obj.GetLabels(), labels))
+	}
+	if len(annotations) > 0 {
+		obj.SetAnnotations(merge(obj.GetAnnotations(), annotations))
+	}
+}
+
+func merge(existing, add map[string]string) map[string]string {
+	if existing == nil {
+		existing = make(map[string]string, len(add))
+	}
+	for k, v := range add {
+		if _, ok := existing[k]; !ok {
+			existing[k] = v
+		}
+	}
+	return existing
+}
+
+// StampFile adds labels and annotations to every object of a yaml or json file and writes it back as yaml.
+// Objects are written in the original order, formatting and comments are not preserved.
+func StampFile(path string, labels, annotations map[string]string) error {
+	objs, err := DecodeFile(path)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for i, obj := range objs {
+		Stamp(obj, labels, annotations)
+		b, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(b)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// LabelValue converts s to a valid label value: at most 63 alphanumeric characters, '-', '_' or '.',
+// starting and ending with an alphanumeric character
+func LabelValue(s string) string {
+	v := invalidLabelChars.ReplaceAllString(s, "-")
+	if len(v) > 63 {
+		v = v[:63]
+	}
+	return strings.Trim(v, "-_.")
+}
@@ -0,0 +1,99 @@
+package manifests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStampFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cfg.yaml")
+	in := configMap + "---\n" + `apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+  labels:
+    app.kubernetes.io/managed-by: Helm
+`
+	if err := os.WriteFile(path, []byte(in), 0644); err != nil {
+		t.Fatal(err)
+	}
+	labels := map[string]string{"app.kubernetes.io/managed-by": "rules_gitops", "team": "payments"}
+	annotations := map[string]string{"example.com/source-commit": "abc"}
+	if err := StampFile(path, labels, annotations); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `apiVersion: v1
+data:
+  key: value
+kind: ConfigMap
+metadata:
+  annotations:
+    example.com/source-commit: abc
+  labels:
+    app: demo
+    app.kubernetes.io/managed-by: rules_gitops
+    team: payments
+  name: cfg
+---
+apiVersion: v1
+kind: Service
+metadata:
+  annotations:
+    example.com/source-commit: abc
+  labels:
+    app.kubernetes.io/managed-by: Helm
+    team: payments
+  name: svc
+`
+	if string(b) != expected {
+		t.Errorf("unexpected stamped file:\n%s", b)
+	}
+
+	// stamps differing only by ignored fields are equivalent
+	eq, err := EquivalentManifests(b, []byte(`apiVersion: v1
+data:
+  key: value
+kind: ConfigMap
+metadata:
+  annotations:
+    example.com/source-commit: def
+  labels:
+    app: demo
+    app.kubernetes.io/managed-by: rules_gitops
+    team: payments
+  name: cfg
+---
+apiVersion: v1
+kind: Service
+metadata:
+  annotations:
+    example.com/source-commit: def
+  labels:
+    app.kubernetes.io/managed-by: Helm
+    team: payments
+  name: svc
+`), []string{"metadata", "annotations", "example.com/source-commit"})
+	if err != nil || !eq {
+		t.Errorf("expected manifests to be equivalent, got %v, %v", eq, err)
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	tests := map[string]string{
+		"prod":         "prod",
+		"team/prod-us": "team-prod-us",
+		"-weird name-": "weird-name",
+		"":             "",
+		"0123456789012345678901234567890123456789012345678901234567890123456789": "012345678901234567890123456789012345678901234567890123456789012",
+	}
+	for in, expected := range tests {
+		if got := LabelValue(in); got != expected {
+			t.Errorf("LabelValue(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Alert is an incident opened for a failed deployment pipeline
+type Alert struct {
+	// DedupKey groups repeated alerts for the same failure into one incident
+	DedupKey string
+	Summary  string
+	Source   string
+	Details  map[string]string
+}
+
+// Alerter opens incidents in an incident management service
+type Alerter interface {
+	Alert(a Alert) error
+}
+
+// PagerDuty triggers incidents with the PagerDuty Events API v2
+type PagerDuty struct {
+	RoutingKey string
+	// URL of the events endpoint, default is https://events.pagerduty.com/v2/enqueue
+	URL string
+}
+
+func (p PagerDuty) Alert(a Alert) error {
+	url := p.URL
+	if url == "" {
+		url = "https://events.pagerduty.com/v2/enqueue"
+	}
+	event := map[string]interface{}{
+		"routing_key":  p.RoutingKey,
+		"event_action": "trigger",
+		"dedup_key":    a.DedupKey,
+		"payload": map[string]interface{}{
+			"summary":        a.Summary,
+			"source":         a.Source,
+			"severity":       "error",
+			"custom_details": a.Details,
+		},
+	}
+	return postJSON(url, nil, event)
+}
+
+// Opsgenie creates alerts with the Opsgenie Alert API
+type Opsgenie struct {
+	APIKey string
+	// URL of the api, default is https://api.opsgenie.com. Use https://api.eu.opsgenie.com for EU accounts
+	URL string
+}
+
+func (o Opsgenie) Alert(a Alert) error {
+	url := o.URL
+	if url == "" {
+		url = "https://api.opsgenie.com"
+	}
+	alert := map[string]interface{}{
+		"message": truncate(a.Summary, 130),
+		"alias":   a.DedupKey,
+		"source":  a.Source,
+		"details": a.Details,
+	}
+	return postJSON(url+"/v2/alerts", map[string]string{"Authorization": "GenieKey " + o.APIKey}, alert)
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
+func postJSON(url string, headers map[string]string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s: %s", url, resp.Status, body)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type request struct {
+	path   string
+	auth   string
+	fields map[string]interface{}
+}
+
+func recordServer(t *testing.T, status int) (*httptest.Server, *request) {
+	var got request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got.path = r.URL.Path
+		got.auth = r.Header.Get("Authorization")
+		if err := json.Unmarshal(b, &got.fields); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(status)
+	}))
+	return ts, &got
+}
+
+var testAlert = Alert{
+	DedupKey: "rules_gitops:master:prod",
+	Summary:  "GitOps deployment master failed for train prod",
+	Source:   "create_gitops_prs",
+	Details:  map[string]string{"pr": "deploy/prod: boom"},
+}
+
+func TestPagerDuty(t *testing.T) {
+	ts, got := recordServer(t, http.StatusAccepted)
+	defer ts.Close()
+	if err := (PagerDuty{RoutingKey: "key", URL: ts.URL + "/v2/enqueue"}).Alert(testAlert); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"routing_key":  "key",
+		"event_action": "trigger",
+		"dedup_key":    "rules_gitops:master:prod",
+		"payload": map[string]interface{}{
+			"summary":        "GitOps deployment master failed for train prod",
+			"source":         "create_gitops_prs",
+			"severity":       "error",
+			"custom_details": map[string]interface{}{"pr": "deploy/prod: boom"},
+		},
+	}
+	if got.path != "/v2/enqueue" || !reflect.DeepEqual(got.fields, expected) {
+		t.Errorf("unexpected request %s %v", got.path, got.fields)
+	}
+}
+
+func TestOpsgenie(t *testing.T) {
+	ts, got := recordServer(t, http.StatusAccepted)
+	defer ts.Close()
+	if err := (Opsgenie{APIKey: "key", URL: ts.URL}).Alert(testAlert); err != nil {
+		t.Fatal(err)
+	}
+	if got.path != "/v2/alerts" || got.auth != "GenieKey key" || got.fields["alias"] != "rules_gitops:master:prod" {
+		t.Errorf("unexpected request %s %s %v", got.path, got.auth, got.fields)
+	}
+}
+
+func TestAlertError(t *testing.T) {
+	ts, _ := recordServer(t, http.StatusUnauthorized)
+	defer ts.Close()
+	if err := (Opsgenie{APIKey: "key", URL: ts.URL}).Alert(testAlert); err == nil {
+		t.Error("expected an error for a rejected alert")
+	}
+}
@@ -0,0 +1,57 @@
+// Package notify sends run notifications to people and incident management services
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTP describes an SMTP server and the envelope of notification emails
+type SMTP struct {
+	// Addr is the host:port of the server
+	Addr string
+	// User and Password authenticate with PLAIN auth if User is set
+	User     string
+	Password string
+	From     string
+	To       []string
+}
+
+// Send sends an email with subject and plain text body to all recipients.
+// The connection is upgraded with STARTTLS if the server supports it.
+func (c SMTP) Send(subject, body string) error {
+	if c.From == "" || len(c.To) == 0 {
+		return errors.New("email sender and recipients must be set")
+	}
+	var auth smtp.Auth
+	if c.User != "" {
+		host, _, err := net.SplitHostPort(c.Addr)
+		if err != nil {
+			return fmt.Errorf("invalid smtp server address %s: %w", c.Addr, err)
+		}
+		auth = smtp.PlainAuth("", c.User, c.Password, host)
+	}
+	return smtp.SendMail(c.Addr, auth, c.From, c.To, message(c.From, c.To, subject, body, time.Now()))
+}
+
+// message formats an RFC 5322 plain text message
+func message(from string, to []string, subject, body string, date time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	// SMTP requires CRLF line endings
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.Bytes()
+}
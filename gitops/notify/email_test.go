@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessage(t *testing.T) {
+	date := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	m := message("gitops@example.com", []string{"a@example.com", "b@example.com"}, "GitOps deployment master: 1 PR", "line 1\nline 2\n", date)
+	expected := "From: gitops@example.com\r\n" +
+		"To: a@example.com, b@example.com\r\n" +
+		"Subject: GitOps deployment master: 1 PR\r\n" +
+		"Date: Mon, 02 Jan 2023 03:04:05 +0000\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: 8bit\r\n" +
+		"\r\n" +
+		"line 1\r\nline 2\r\n"
+	if string(m) != expected {
+		t.Errorf("unexpected message:\n%q", m)
+	}
+}
+
+func TestMessageEncodesSubject(t *testing.T) {
+	m := message("a@example.com", []string{"b@example.com"}, "image app: v1 → v2", "", time.Unix(0, 0))
+	if want := "Subject: =?utf-8?q?image_app:_v1_=E2=86=92_v2?=\r\n"; !strings.Contains(string(m), want) {
+		t.Errorf("subject is not encoded:\n%q", m)
+	}
+}
+
+func TestSendRequiresRecipients(t *testing.T) {
+	if err := (SMTP{Addr: "localhost:25", From: "a@example.com"}).Send("s", "b"); err == nil {
+		t.Error("expected an error without recipients")
+	}
+}
@@ -0,0 +1,108 @@
+// Package platforms verifies pushed images are available for the platforms clusters run on
+package platforms
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// Parse parses platforms like linux/amd64 or linux/arm64/v8
+func Parse(specs []string) ([]v1.Platform, error) {
+	var ps []v1.Platform
+	for _, s := range specs {
+		p, err := v1.ParsePlatform(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid platform %q: %w", s, err)
+		}
+		if p.OS == "" || p.Architecture == "" {
+			return nil, fmt.Errorf("invalid platform %q: expected os/arch[/variant]", s)
+		}
+		ps = append(ps, *p)
+	}
+	return ps, nil
+}
+
+// Verifier checks images provide all expected platforms
+type Verifier struct {
+	Platforms []v1.Platform
+	// Options are passed to every registry request, like authentication and transport
+	Options []remote.Option
+}
+
+// Verify fetches the image and returns an error if it can't be fetched, it is missing one of the expected platforms
+// or a per-platform manifest of an image index is not reachable.
+// A single platform image is accepted only if exactly one platform is expected and the image config matches it.
+func (v *Verifier) Verify(image string) error {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return fmt.Errorf("invalid image reference: %w", err)
+	}
+	desc, err := remote.Get(ref, v.Options...)
+	if err != nil {
+		return err
+	}
+	if desc.MediaType.IsIndex() {
+		idx, err := desc.ImageIndex()
+		if err != nil {
+			return err
+		}
+		m, err := idx.IndexManifest()
+		if err != nil {
+			return err
+		}
+		var missing, unreachable []string
+		for _, want := range v.Platforms {
+			found := false
+			for _, d := range m.Manifests {
+				if d.Platform == nil || !d.Platform.Satisfies(want) {
+					continue
+				}
+				found = true
+				if _, err := remote.Head(ref.Context().Digest(d.Digest.String()), v.Options...); err != nil {
+					unreachable = append(unreachable, fmt.Sprintf("%s (%s): %v", want, d.Digest, err))
+				}
+			}
+			if !found {
+				missing = append(missing, want.String())
+			}
+		}
+		var problems []string
+		if len(missing) > 0 {
+			problems = append(problems, "image index is missing platforms "+strings.Join(missing, ", "))
+		}
+		if len(unreachable) > 0 {
+			problems = append(problems, "platform manifests are not reachable: "+strings.Join(unreachable, "; "))
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("%s", strings.Join(problems, "; "))
+		}
+		return nil
+	}
+	if !desc.MediaType.IsImage() {
+		return fmt.Errorf("unexpected media type %s", desc.MediaType)
+	}
+	img, err := desc.Image()
+	if err != nil {
+		return err
+	}
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return err
+	}
+	have := cfg.Platform()
+	if have == nil {
+		have = &v1.Platform{}
+	}
+	if len(v.Platforms) > 1 || (len(v.Platforms) == 1 && !have.Satisfies(v.Platforms[0])) {
+		var want []string
+		for _, p := range v.Platforms {
+			want = append(want, p.String())
+		}
+		return fmt.Errorf("single platform image %s, expected an image index with platforms %s", have, strings.Join(want, ", "))
+	}
+	return nil
+}
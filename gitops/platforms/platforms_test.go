@@ -0,0 +1,123 @@
+package platforms
+
+import (
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+func platformImage(t *testing.T, p string) v1.Image {
+	t.Helper()
+	img, err := random.Image(256, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pl, err := v1.ParsePlatform(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg = cfg.DeepCopy()
+	cfg.OS, cfg.Architecture, cfg.Variant = pl.OS, pl.Architecture, pl.Variant
+	img, err = mutate.ConfigFile(img, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func TestParse(t *testing.T) {
+	ps, err := Parse([]string{"linux/amd64", "linux/arm64/v8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 2 || ps[1].Architecture != "arm64" || ps[1].Variant != "v8" {
+		t.Errorf("unexpected platforms %v", ps)
+	}
+	if _, err := Parse([]string{"linux"}); err == nil {
+		t.Error("expected an error for a platform without architecture")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer srv.Close()
+	reg := strings.TrimPrefix(srv.URL, "http://")
+
+	amd64 := platformImage(t, "linux/amd64")
+	arm64 := platformImage(t, "linux/arm64")
+	idx := mutate.AppendManifests(empty.Index,
+		mutate.IndexAddendum{Add: amd64, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}},
+		mutate.IndexAddendum{Add: arm64, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}}},
+	)
+	write := func(image string, idx v1.ImageIndex, img v1.Image) {
+		ref, err := name.ParseReference(image)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if idx != nil {
+			err = remote.WriteIndex(ref, idx)
+		} else {
+			err = remote.Write(ref, img)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(reg+"/multi:1", idx, nil)
+	write(reg+"/single:1", nil, amd64)
+	write(reg+"/broken:1", idx, nil)
+	arm64Digest, err := arm64.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	broken, err := name.ParseReference(reg + "/broken@" + arm64Digest.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Delete(broken); err != nil {
+		t.Fatal(err)
+	}
+
+	both, err := Parse([]string{"linux/amd64", "linux/arm64"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		image     string
+		platforms []v1.Platform
+		errText   string
+	}{
+		{image: reg + "/multi:1", platforms: both},
+		{image: reg + "/multi:1", platforms: both[1:]},
+		{image: reg + "/multi:1", platforms: append(both, v1.Platform{OS: "linux", Architecture: "s390x"}), errText: "missing platforms linux/s390x"},
+		{image: reg + "/single:1", platforms: both[:1]},
+		{image: reg + "/single:1", platforms: both, errText: "single platform image linux/amd64"},
+		{image: reg + "/single:1", platforms: both[1:], errText: "single platform image linux/amd64"},
+		{image: reg + "/broken:1", platforms: both, errText: "platform manifests are not reachable: linux/arm64"},
+		{image: reg + "/missing:1", platforms: both, errText: "NAME_UNKNOWN"},
+	}
+	for _, tt := range tests {
+		v := Verifier{Platforms: tt.platforms}
+		err := v.Verify(tt.image)
+		if tt.errText == "" && err != nil {
+			t.Errorf("Verify(%s, %v): unexpected error %v", tt.image, tt.platforms, err)
+		}
+		if tt.errText != "" && (err == nil || !strings.Contains(err.Error(), tt.errText)) {
+			t.Errorf("Verify(%s, %v): expected error containing %q, got %v", tt.image, tt.platforms, tt.errText, err)
+		}
+	}
+}
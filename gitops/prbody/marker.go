@@ -0,0 +1,58 @@
+package prbody
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+const markerPrefix = "<!-- rules_gitops:"
+
+var markerRe = regexp.MustCompile(`<!-- rules_gitops:(\{.*?\}) -->`)
+
+// Marker identifies the release train and the run that generated a deployment PR.
+// It is embedded in the PR body as a hidden HTML comment.
+type Marker struct {
+	Train string `json:"train"`
+	// Target is the gitops target of a per-target deployment branch, empty for release train branches
+	Target string `json:"target,omitempty"`
+	// ReleaseBranch, BranchPrefix and BranchSuffix tell apart pipelines deploying trains of the same name
+	ReleaseBranch string `json:"release_branch,omitempty"`
+	BranchPrefix  string `json:"branch_prefix,omitempty"`
+	BranchSuffix  string `json:"branch_suffix,omitempty"`
+	SourceCommit  string `json:"source_commit,omitempty"`
+	RunID         string `json:"run_id,omitempty"`
+	ToolVersion   string `json:"tool_version,omitempty"`
+}
+
+// SamePipeline reports whether m and o were generated for the same release train of the same release branch
+// with the same deployment branch prefix and suffix. Markers of other pipelines, and markers of older versions
+// that do not record the release branch, never match.
+func (m Marker) SamePipeline(o Marker) bool {
+	return m.ReleaseBranch != "" && m.Train == o.Train && m.ReleaseBranch == o.ReleaseBranch &&
+		m.BranchPrefix == o.BranchPrefix && m.BranchSuffix == o.BranchSuffix
+}
+
+// String returns the marker as an HTML comment
+func (m Marker) String() string {
+	b, _ := json.Marshal(m)
+	return markerPrefix + string(b) + " -->"
+}
+
+// WithMarker appends m to body, replacing a marker already present in body
+func WithMarker(body string, m Marker) string {
+	body = strings.TrimRight(markerRe.ReplaceAllString(body, ""), "\n")
+	return body + "\n\n" + m.String() + "\n"
+}
+
+// ParseMarker returns the marker embedded in body. ok is false if body has no valid marker.
+func ParseMarker(body string) (m Marker, ok bool) {
+	match := markerRe.FindStringSubmatch(body)
+	if match == nil {
+		return Marker{}, false
+	}
+	if err := json.Unmarshal([]byte(match[1]), &m); err != nil || m.Train == "" {
+		return Marker{}, false
+	}
+	return m, true
+}
@@ -0,0 +1,65 @@
+package prbody
+
+import "testing"
+
+func TestMarkerRoundTrip(t *testing.T) {
+	m := Marker{Train: "prod", SourceCommit: "0123abcd", RunID: "42"}
+	body := WithMarker("deploy/prod\n", m)
+	expected := "deploy/prod\n\n<!-- rules_gitops:{\"train\":\"prod\",\"source_commit\":\"0123abcd\",\"run_id\":\"42\"} -->\n"
+	if body != expected {
+		t.Errorf("unexpected body:\n%q", body)
+	}
+	got, ok := ParseMarker(body)
+	if !ok || got != m {
+		t.Errorf("ParseMarker() = %+v, %v", got, ok)
+	}
+}
+
+func TestWithMarkerReplaces(t *testing.T) {
+	body := WithMarker("text", Marker{Train: "prod", RunID: "1"})
+	body = WithMarker(body, Marker{Train: "prod", RunID: "2"})
+	expected := "text\n\n<!-- rules_gitops:{\"train\":\"prod\",\"run_id\":\"2\"} -->\n"
+	if body != expected {
+		t.Errorf("unexpected body:\n%q", body)
+	}
+}
+
+func TestParseMarkerMissing(t *testing.T) {
+	for _, body := range []string{
+		"",
+		"GitOps deployment deploy/prod",
+		"<!-- rules_gitops:{not json} -->",
+		`<!-- rules_gitops:{"run_id":"1"} -->`,
+	} {
+		if m, ok := ParseMarker(body); ok {
+			t.Errorf("ParseMarker(%q) = %+v, expected no marker", body, m)
+		}
+	}
+}
+
+func TestMarkerTarget(t *testing.T) {
+	m := Marker{Train: "prod", Target: "//services/api:prod.gitops", RunID: "42"}
+	got, ok := ParseMarker(WithMarker("deploy/prod/services/api/prod", m))
+	if !ok || got != m {
+		t.Errorf("ParseMarker() = %+v, %v", got, ok)
+	}
+}
+
+func TestMarkerSamePipeline(t *testing.T) {
+	m := Marker{Train: "prod", ReleaseBranch: "release/a", BranchPrefix: "deploy/", RunID: "1"}
+	same := Marker{Train: "prod", ReleaseBranch: "release/a", BranchPrefix: "deploy/", RunID: "2", Target: "//svc:prod.gitops"}
+	if !m.SamePipeline(same) {
+		t.Errorf("expected %+v to match %+v", m, same)
+	}
+	for _, o := range []Marker{
+		{Train: "dev", ReleaseBranch: "release/a", BranchPrefix: "deploy/"},
+		{Train: "prod", ReleaseBranch: "release/b", BranchPrefix: "deploy/"},
+		{Train: "prod", ReleaseBranch: "release/a", BranchPrefix: "deploy/", BranchSuffix: "-a"},
+		{Train: "prod", ReleaseBranch: "release/a", BranchPrefix: "gitops/"},
+		{Train: "prod"},
+	} {
+		if m.SamePipeline(o) || o.SamePipeline(m) {
+			t.Errorf("expected %+v not to match %+v", o, m)
+		}
+	}
+}
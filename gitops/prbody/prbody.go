@@ -0,0 +1,81 @@
+package prbody
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+// LinkFunc returns a link to the diff of a file or an empty string if the file can't be linked
+type LinkFunc func(path string) string
+
+type node struct {
+	name     string
+	children map[string]*node
+	change   *git.FileChange
+}
+
+// FileTree renders changes as a markdown nested list following the directory structure.
+// File names are linked using link. At most maxFiles files are listed, 0 means no limit.
+func FileTree(changes []git.FileChange, link LinkFunc, maxFiles int) string {
+	if len(changes) == 0 {
+		return ""
+	}
+	sorted := append([]git.FileChange(nil), changes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+	omitted := 0
+	if maxFiles > 0 && len(sorted) > maxFiles {
+		omitted = len(sorted) - maxFiles
+		sorted = sorted[:maxFiles]
+	}
+	root := &node{children: map[string]*node{}}
+	for i := range sorted {
+		c := &sorted[i]
+		n := root
+		parts := strings.Split(c.Path, "/")
+		for _, p := range parts[:len(parts)-1] {
+			child, ok := n.children[p+"/"]
+			if !ok {
+				child = &node{name: p + "/", children: map[string]*node{}}
+				n.children[p+"/"] = child
+			}
+			n = child
+		}
+		name := parts[len(parts)-1]
+		n.children[name] = &node{name: name, change: c}
+	}
+	var sb strings.Builder
+	write(&sb, root, 0, link)
+	if omitted > 0 {
+		fmt.Fprintf(&sb, "- ... and %d more file(s)\n", omitted)
+	}
+	return sb.String()
+}
+
+func write(sb *strings.Builder, n *node, depth int, link LinkFunc) {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	indent := strings.Repeat("  ", depth)
+	for _, name := range names {
+		child := n.children[name]
+		if child.change == nil {
+			fmt.Fprintf(sb, "%s- %s\n", indent, child.name)
+			write(sb, child, depth+1, link)
+			continue
+		}
+		label := child.name
+		if u := link(child.change.Path); u != "" {
+			label = fmt.Sprintf("[%s](%s)", child.name, u)
+		}
+		status := child.change.Status
+		if child.change.OldPath != "" {
+			status += " from " + child.change.OldPath
+		}
+		fmt.Fprintf(sb, "%s- %s (%s)\n", indent, label, status)
+	}
+}
@@ -0,0 +1,44 @@
+package prbody
+
+import (
+	"testing"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+func TestFileTree(t *testing.T) {
+	changes := []git.FileChange{
+		{Status: "modified", Path: "cloud/prod/us-east/app.yaml"},
+		{Status: "added", Path: "cloud/prod/eu-west/app.yaml"},
+		{Status: "renamed", Path: "cloud/prod/us-east/svc.yaml", OldPath: "cloud/prod/us-east/service.yaml"},
+		{Status: "deleted", Path: "cloud/dev/old.yaml"},
+	}
+	link := func(path string) string {
+		if path == "cloud/dev/old.yaml" {
+			return ""
+		}
+		return "https://git/diff#" + path
+	}
+	expected := `- cloud/
+  - dev/
+    - old.yaml (deleted)
+  - prod/
+    - eu-west/
+      - [app.yaml](https://git/diff#cloud/prod/eu-west/app.yaml) (added)
+    - us-east/
+      - [app.yaml](https://git/diff#cloud/prod/us-east/app.yaml) (modified)
+      - [svc.yaml](https://git/diff#cloud/prod/us-east/svc.yaml) (renamed from cloud/prod/us-east/service.yaml)
+`
+	if tree := FileTree(changes, link, 0); tree != expected {
+		t.Errorf("unexpected tree:\n%s", tree)
+	}
+
+	expected = `- cloud/
+  - dev/
+    - old.yaml (deleted)
+- ... and 3 more file(s)
+`
+	if tree := FileTree(changes, link, 1); tree != expected {
+		t.Errorf("unexpected truncated tree:\n%s", tree)
+	}
+}
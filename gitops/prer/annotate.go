@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	oe "os/exec"
+	"sort"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/report"
+)
+
+// annotation formats the per train summary table of the run as markdown
+func annotation(s *runSummary) (markdown, style string) {
+	var trains []string
+	for train := range s.Trains {
+		trains = append(trains, train)
+	}
+	sort.Strings(trains)
+	updated := make(map[string]bool)
+	for _, b := range s.UpdatedBranches {
+		if train := alertTrain(s, b); train != "" {
+			updated[train] = true
+		}
+	}
+	frozen := make(map[string]bool)
+	for _, t := range s.FrozenTrains {
+		frozen[t] = true
+	}
+	// with -branch_per_target a train has a PR per target
+	prs := make(map[string][]prResult)
+	for _, pr := range s.PullRequests {
+		prs[pr.Train] = append(prs[pr.Train], pr)
+	}
+	failures := make(map[string][]string)
+	style = "success"
+	for _, e := range problems.Entries() {
+		if e.Severity != report.Error {
+			continue
+		}
+		style = "error"
+		failures[alertTrain(s, e.Subject)] = append(failures[alertTrain(s, e.Subject)], fmt.Sprintf("%s: %s", e.Phase, e.Message))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "### GitOps deployment of %s\n\n", s.ReleaseBranch)
+	sb.WriteString("| Release train | Targets | Pull request | Status |\n")
+	sb.WriteString("| --- | --- | --- | --- |\n")
+	for _, train := range trains {
+		pr := "-"
+		var links []string
+		for _, p := range prs[train] {
+			link := "`" + p.Branch + "`"
+			if p.URL != "" {
+				link = fmt.Sprintf("[%s](%s)", p.Branch, p.URL)
+			}
+			links = append(links, link)
+		}
+		if len(links) > 0 {
+			pr = strings.Join(links, "<br>")
+		}
+		status := "no changes"
+		switch {
+		case frozen[train]:
+			status = "frozen"
+		case len(failures[train]) > 0:
+			status = "failed: " + strings.Join(failures[train], "; ")
+		case len(prs[train]) > 0:
+			status = "PR opened"
+		case updated[train]:
+			status = "updated"
+		}
+		fmt.Fprintf(&sb, "| %s | %d | %s | %s |\n", train, len(s.Trains[train]), pr, strings.ReplaceAll(status, "|", `\|`))
+	}
+	if f := failures[""]; len(f) > 0 {
+		sb.WriteString("\n**Errors**\n\n")
+		for _, m := range f {
+			fmt.Fprintf(&sb, "- %s\n", m)
+		}
+	}
+	return sb.String(), style
+}
+
+// annotate publishes the run summary table with buildkite-agent and writes it to -annotation_file
+func annotate(s *runSummary) {
+	if !*buildkiteAnnotate && *annotationFile == "" {
+		return
+	}
+	markdown, style := annotation(s)
+	if *annotationFile != "" {
+		if err := os.WriteFile(*annotationFile, []byte(markdown), 0644); err != nil {
+			problems.Warnf("summary", *annotationFile, "unable to write annotation: %v", err)
+		}
+	}
+	if *buildkiteAnnotate {
+		cmd := oe.Command("buildkite-agent", "annotate", "--style", style, "--context", "rules_gitops-"+s.ReleaseBranch)
+		cmd.Stdin = strings.NewReader(markdown)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			problems.Warnf("summary", "buildkite-agent", "unable to annotate the build: %v: %s", err, out)
+			return
+		}
+		log.Println("annotated the buildkite build")
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+)
+
+// applyTrains renders every release train into its own directory and applies the result
+// to the -apply_to_context cluster with server-side apply instead of committing it to git, in the order of trains.
+// Applied trains and rollout results are recorded in the summary.
+func applyTrains(root string, trains []string, releaseTrains map[string][]string, manifest *resolvedManifest, summary *runSummary) {
+	var targets []string
+	for _, train := range trains {
+		targets = append(targets, releaseTrains[train]...)
+	}
+
+	dirs := make(map[string]string)
+	for i, train := range trains {
+		dir := filepath.Join(root, fmt.Sprintf("train%d", i))
+		if err := renderTrain(train, releaseTrains[train], dir, *gitopsParallelism); err != nil {
+			fatalf("ERROR: %v", err)
+		}
+		dirs[train] = filepath.Join(dir, *gitopsPath)
+	}
+
+	pushImages(manifest, trains, targets)
+
+	for _, train := range trains {
+		dir := dirs[train]
+		if _, err := os.Stat(dir); err != nil {
+			problems.Warnf("apply", train, "no manifests rendered into %s", *gitopsPath)
+			continue
+		}
+		if *namespaceBootstrap {
+			if err := applyNamespaces(dir); err != nil {
+				problems.Error("apply", train, err)
+				continue
+			}
+		}
+		if err := kubectlApply(dir); err != nil {
+			problems.Error("apply", train, err)
+			continue
+		}
+		summary.AppliedTrains = append(summary.AppliedTrains, train)
+		if *applyRolloutTimeout > 0 && !*dryRun {
+			summary.Rollouts = append(summary.Rollouts, verifyRollouts(train, dir)...)
+		}
+	}
+}
+
+// applyNamespaces generates Namespace manifests for namespaces used by manifests in dir
+// and applies them ahead of the other manifests
+func applyNamespaces(dir string) error {
+	objs, err := manifests.DecodeDir(dir)
+	if err != nil {
+		return err
+	}
+	nsDir := filepath.Join(dir, *namespaceDir)
+	written, err := bootstrapNamespaces(objs, nsDir)
+	if err != nil || len(written) == 0 {
+		return err
+	}
+	return kubectlApply(nsDir)
+}
+
+// kubectlApply applies all manifests in dir recursively using server-side apply.
+// With -dry_run the request is only validated by the server.
+func kubectlApply(dir string) error {
+	args := []string{
+		"--context", *applyContext,
+		"apply",
+		"--server-side",
+		"--field-manager", *applyFieldManager,
+		"--recursive",
+		"--filename", dir,
+	}
+	if *applyForceConflicts {
+		args = append(args, "--force-conflicts")
+	}
+	if *dryRun {
+		args = append(args, "--dry-run=server")
+	}
+	if out, err := runner.Run("", nil, *kubectlCmd, args...); err != nil {
+		return fmt.Errorf("kubectl apply to context %s failed: %w: %s", *applyContext, err, strings.TrimSpace(out))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+	"github.com/fasterci/rules_gitops/gitops/provenance"
+)
+
+// writeProvenance writes a SLSA provenance statement for the files changed by the train into -provenance_dir.
+// Returns the repository relative path of the statement or empty string if the train has no changes.
+func writeProvenance(workdir *git.Repo, train string, targets []string, startedOn time.Time) (string, error) {
+	files, err := workdir.ChangedFiles(*gitopsPath)
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", nil
+	}
+	d := provenance.Deployment{
+		ReleaseTrain:  train,
+		ReleaseBranch: *releaseBranch,
+		SourceRepo:    *sourceRepo,
+		SourceBranch:  *branchName,
+		SourceCommit:  *gitCommit,
+		Targets:       targets,
+		Files:         make(map[string]string),
+		BuilderID:     *provenanceBuilderID,
+		ToolVersion:   currentBuild().Version,
+		StartedOn:     startedOn,
+		FinishedOn:    clk.Now(),
+	}
+	if d.SourceRepo == "" {
+		d.SourceRepo = *repo
+	}
+	var paths []string
+	for _, f := range files {
+		path := filepath.Join(workdir.Dir, f)
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		sum := sha256.Sum256(b)
+		d.Files[f] = hex.EncodeToString(sum[:])
+		paths = append(paths, path)
+	}
+	if d.Images, err = manifests.ImagesInFiles(paths); err != nil {
+		return "", err
+	}
+	b, err := provenance.Generate(d).Marshal()
+	if err != nil {
+		return "", err
+	}
+	rel := filepath.Join(*provenanceDir, strings.ReplaceAll(train, "/", "_")+".intoto.json")
+	if err := os.MkdirAll(filepath.Join(workdir.Dir, *provenanceDir), os.ModePerm); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(workdir.Dir, rel), b, 0644); err != nil {
+		return "", err
+	}
+	return rel, nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+// prBatch queues deployment PRs to open them with few git server requests
+type prBatch struct {
+	server  git.BatchServer
+	pending []git.NewPullRequest
+	trains  []string
+}
+
+// add queues pr of train
+func (b *prBatch) add(train string, pr git.NewPullRequest) {
+	b.pending = append(b.pending, pr)
+	b.trains = append(b.trains, train)
+}
+
+// flush opens queued PRs and returns results of the ones opened or already existing
+func (b *prBatch) flush() []prResult {
+	if len(b.pending) == 0 {
+		return nil
+	}
+	urls, errs := b.server.CreatePRs(b.pending)
+	var results []prResult
+	for i, pr := range b.pending {
+		if errs[i] != nil {
+			log.Println("unable to create PR: ", errs[i])
+			problems.Error("pr", pr.Source, fmt.Errorf("unable to create PR into %s: %w", pr.Target, errs[i]))
+			continue
+		}
+		results = append(results, prResult{Train: b.trains[i], Branch: pr.Source, URL: urls[i]})
+	}
+	b.pending, b.trains = nil, nil
+	return results
+}
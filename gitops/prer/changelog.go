@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// imageChangelog compares images used in paths at revision oldRev and newRev.
+// Empty newRev means the working tree. Files missing at a revision have no images.
+func imageChangelog(workdir *git.Repo, oldRev, newRev string, paths []string) ([]manifests.ImageChange, error) {
+	var oldObjs, newObjs []*unstructured.Unstructured
+	for _, p := range paths {
+		if !manifests.IsManifest(p) {
+			continue
+		}
+		o, err := decodeAt(workdir, oldRev, p)
+		if err != nil {
+			return nil, err
+		}
+		oldObjs = append(oldObjs, o...)
+		if newRev == "" {
+			b, err := os.ReadFile(filepath.Join(workdir.Dir, p))
+			if err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+			o, err = manifests.Decode(bytes.NewReader(b))
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse %s: %w", p, err)
+			}
+		} else if o, err = decodeAt(workdir, newRev, p); err != nil {
+			return nil, err
+		}
+		newObjs = append(newObjs, o...)
+	}
+	return manifests.ImageChanges(manifests.Images(oldObjs), manifests.Images(newObjs)), nil
+}
+
+func decodeAt(workdir *git.Repo, rev, path string) ([]*unstructured.Unstructured, error) {
+	b, found, err := workdir.FileAt(rev, path)
+	if err != nil || !found {
+		return nil, err
+	}
+	objs, err := manifests.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s at %s: %w", path, rev, err)
+	}
+	return objs, nil
+}
+
+// formatChangelog renders image changes one per line
+func formatChangelog(changes []manifests.ImageChange, prefix string) string {
+	var sb strings.Builder
+	for _, c := range changes {
+		sb.WriteString(prefix)
+		sb.WriteString(c.String())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// trainChangelog returns image changes of the uncommitted rendering of the train for the commit message
+func trainChangelog(workdir *git.Repo, train string) string {
+	files, err := workdir.ChangedFiles(*gitopsPath)
+	if err != nil {
+		fatalf("unable to list changed files: %v", err)
+	}
+	changes, err := imageChangelog(workdir, "HEAD", "", files)
+	if err != nil {
+		problems.Warnf("changelog", train, "unable to compare images: %v", err)
+		return ""
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+	return "\n" + formatChangelog(changes, "")
+}
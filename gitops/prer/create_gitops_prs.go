@@ -14,27 +14,29 @@ package main
 import (
 	"flag"
 	"fmt"
-	"io"
 	"log"
 	"os"
-	oe "os/exec"
 	"strings"
-	"sync"
+	"time"
 
-	"github.com/fasterci/rules_gitops/gitops/analysis"
 	"github.com/fasterci/rules_gitops/gitops/bazel"
+	"github.com/fasterci/rules_gitops/gitops/clock"
 	"github.com/fasterci/rules_gitops/gitops/commitmsg"
+	"github.com/fasterci/rules_gitops/gitops/discovery"
 	"github.com/fasterci/rules_gitops/gitops/exec"
 	"github.com/fasterci/rules_gitops/gitops/git"
 	"github.com/fasterci/rules_gitops/gitops/git/bitbucket"
 	"github.com/fasterci/rules_gitops/gitops/git/github"
 	"github.com/fasterci/rules_gitops/gitops/git/gitlab"
-	"golang.org/x/sync/errgroup"
-
-	proto "github.com/golang/protobuf/proto"
+	"github.com/fasterci/rules_gitops/gitops/imagepolicy"
+	"github.com/fasterci/rules_gitops/gitops/prbody"
+	"github.com/fasterci/rules_gitops/gitops/profile"
+	"github.com/fasterci/rules_gitops/gitops/report"
+	"github.com/fasterci/rules_gitops/gitops/secretscan"
 )
 
 func init() {
+	flag.Usage = usage
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 }
 
@@ -51,106 +53,346 @@ func (i *SliceFlags) Set(value string) error {
 }
 
 var (
-	releaseBranch          = flag.String("release_branch", "master", "filter gitops targets by release branch")
-	bazelCmd               = flag.String("bazel_cmd", "tools/bazel", "bazel binary to use")
-	workspace              = flag.String("workspace", "", "path to workspace root")
-	repo                   = flag.String("git_repo", "", "git repo location")
-	gitMirror              = flag.String("git_mirror", "", "git mirror location, like /mnt/mirror/bitbucket.tubemogul.info/tm/repo.git for jenkins")
-	gitopsPath             = flag.String("gitops_path", "cloud", "location to store files in repo")
-	gitopsTmpDir           = flag.String("gitops_tmpdir", os.TempDir(), "location to check out git tree with /cloud.")
-	gitopsdir              string
-	target                 = flag.String("target", "//... except //experimental/...", "target to scan. Useful for debugging only")
-	pushParallelism        = flag.Int("push_parallelism", 1, "Number of image pushes to perform concurrently")
-	prInto                 = flag.String("gitops_pr_into", "master", "use this branch as the source branch and target for deployment PR")
-	prBody                 = flag.String("gitops_pr_body", "", "a body message for deployment PR")
-	prTitle                = flag.String("gitops_pr_title", "", "a title for deployment PR")
-	branchName             = flag.String("branch_name", "unknown", "Branch name to use in commit message")
-	gitCommit              = flag.String("git_commit", "unknown", "Git commit to use in commit message")
-	deployBranchPrefix     = flag.String("deploy_branch_prefix", "deploy/", "prefix to add to all deployment branch names")
-	deploymentBranchSuffix = flag.String("deployment_branch_suffix", "", "suffix to add to all deployment branch names")
-	gitHost                = flag.String("git_server", "bitbucket", "the git server api to use. 'bitbucket', 'github' or 'gitlab'")
-	gitopsKind             SliceFlags
-	gitopsRuleName         SliceFlags
-	gitopsRuleAttr         SliceFlags
-	dryRun                 = flag.Bool("dry_run", false, "Do not create PRs, just print what would be done")
-	resolvedPushes         SliceFlags
-	resolvedBinaries       SliceFlags
+	releaseBranch             = flag.String("release_branch", "master", "filter gitops targets by release branch")
+	bazelCmd                  = flag.String("bazel_cmd", "", "bazel binary to use. Default is bazelisk on PATH, tools/bazel of the workspace, $BAZEL_REAL or bazel on PATH")
+	workspace                 = flag.String("workspace", "", "path to workspace root")
+	repo                      = flag.String("git_repo", "", "git repo location")
+	gitRemote                 = flag.String("git_remote", git.DefaultRemote, "name of the -git_repo remote in the gitops checkout")
+	gitPushRemotes            SliceFlags
+	caBundle                  = flag.String("ca_bundle", os.Getenv("GITOPS_CA_BUNDLE"), "PEM file with additional certificate authorities trusted by git and git server API clients")
+	clientCert                = flag.String("client_cert", os.Getenv("GITOPS_CLIENT_CERT"), "PEM client certificate used by git and git server API clients")
+	clientKey                 = flag.String("client_key", os.Getenv("GITOPS_CLIENT_KEY"), "PEM private key of -client_cert")
+	proxyURL                  = flag.String("proxy", os.Getenv("GITOPS_PROXY"), "http, https or socks5 proxy url used by git and git server API clients. Default is to use standard proxy environment variables")
+	httpTimeout               = flag.Duration("http_timeout", 2*time.Minute, "timeout of a single git server API request, 0 disables it")
+	httpDialTimeout           = flag.Duration("http_dial_timeout", 0, "timeout of establishing git server API connections. Default is 30s")
+	httpTLSTimeout            = flag.Duration("http_tls_handshake_timeout", 0, "timeout of git server API TLS handshakes. Default is 10s")
+	httpHeaderTimeout         = flag.Duration("http_response_header_timeout", 0, "time to wait for git server API response headers after sending a request. Default is no limit")
+	httpKeepAlive             = flag.Duration("http_keep_alive", 0, "TCP keep-alive period of git server API connections, negative disables it. Default is 30s")
+	httpIdleConnTimeout       = flag.Duration("http_idle_conn_timeout", 0, "how long idle git server API connections are kept open. Default is 90s")
+	httpMaxIdleConns          = flag.Int("http_max_idle_conns", 0, "maximum number of idle git server API connections. Default is 100")
+	httpMaxIdleConnsPerHost   = flag.Int("http_max_idle_conns_per_host", 0, "maximum number of idle git server API connections per host. Default is 2")
+	httpDisableKeepAlives     = flag.Bool("http_disable_keep_alives", false, "use a new connection for every git server API request")
+	httpDisableHTTP2          = flag.Bool("http_disable_http2", false, "restrict git server API clients to HTTP/1.1")
+	gitQuiet                  = flag.Bool("git_quiet", false, "do not show git clone and fetch transfer progress in the logs")
+	gitFetchMinimal           = flag.Bool("git_fetch_minimal", false, "fetch only -gitops_pr_into and deployment branches (-deploy_branch_prefix) from the gitops repository")
+	gitFetchRefspecs          SliceFlags
+	gitMirror                 = flag.String("git_mirror", "", "git mirror location, like /mnt/mirror/bitbucket.tubemogul.info/tm/repo.git for jenkins")
+	gitCacheDir               = flag.String("git_cache_dir", "", "persistent bare clone location. Created on the first run and fetched on subsequent runs, used instead of -git_mirror")
+	gitopsPath                = flag.String("gitops_path", "cloud", "location to store files in repo")
+	gitopsTmpDir              = flag.String("gitops_tmpdir", os.TempDir(), "location to check out git tree with /cloud.")
+	gitopsdir                 string
+	gitopsdirClean            bool
+	targetPatterns            SliceFlags
+	pushParallelism           = flag.Int("push_parallelism", 1, "Number of image pushes to perform concurrently")
+	gitopsParallelism         = flag.Int("gitops_parallelism", 1, "Number of gitops binaries of the same release train to run concurrently. Failures of all binaries of the train are reported")
+	renderRunUnder            = flag.String("run_under", "", "command prefix gitops binaries are executed with, like \"firejail --net=none --\"")
+	renderCleanEnv            = flag.Bool("render_clean_env", false, "run gitops binaries with only PATH, locale, TZ, TMPDIR and -render_env variables and an empty HOME, hiding credentials of the process")
+	renderEnv                 SliceFlags
+	pushRunUnder              = flag.String("push_run_under", "", "command prefix push binaries are executed with. Passed as --run_under to bazel run for push targets that are not files")
+	prInto                    = flag.String("gitops_pr_into", "master", "use this branch as the source branch and target for deployment PR")
+	prBody                    = flag.String("gitops_pr_body", "", "a body message for deployment PR")
+	prBodyFiles               = flag.Bool("gitops_pr_body_files", true, "append a tree of changed files linked to the diff view of the git server to the deployment PR body")
+	prBodyMaxFiles            = flag.Int("gitops_pr_body_max_files", 200, "maximum number of files listed in the deployment PR body. 0 means no limit")
+	imageChangelogEnabled     = flag.Bool("image_changelog", true, "list image tag and digest changes in deployment commit messages and PR bodies")
+	prTitle                   = flag.String("gitops_pr_title", "", "a title for deployment PR")
+	branchName                = flag.String("branch_name", "unknown", "Branch name to use in commit message")
+	gitCommit                 = flag.String("git_commit", "unknown", "Git commit to use in commit message")
+	deployBranchPrefix        = flag.String("deploy_branch_prefix", "deploy/", "prefix to add to all deployment branch names")
+	branchPerTarget           = flag.Bool("branch_per_target", false, "commit every gitops target to its own deployment branch <prefix><train>--<target path><suffix> and open a PR per target instead of one per release train. The separator is -- rather than / because git can not have branch <prefix><train>/<target path> next to the release train branch; train names containing -- are refused")
+	deploymentBranchSuffix    = flag.String("deployment_branch_suffix", "", "suffix to add to all deployment branch names")
+	gitHost                   = flag.String("git_server", "bitbucket", "the git server api to use. 'bitbucket', 'github' or 'gitlab'")
+	gitopsKind                SliceFlags
+	gitopsRuleName            SliceFlags
+	gitopsRuleAttr            SliceFlags
+	dryRun                    = flag.Bool("dry_run", false, "Do not create PRs, just print what would be done")
+	diffOnly                  = flag.Bool("diff_only", false, "render all release trains and print a unified diff of the changes against their deployment branches to stdout. Nothing is committed, pushed or opened")
+	resolvedPushes            SliceFlags
+	resolvedBinaries          SliceFlags
+	resolvedManifestFile      = flag.String("resolved_manifest", "", "run without bazel using release trains, gitops binaries and push binaries from this JSON file, see -write_resolved_manifest")
+	writeResolvedManifestFile = flag.String("write_resolved_manifest", "", "discover release trains with bazel, write gitops and push binaries to this JSON file and exit")
+	summaryJSON               = flag.String("summary_json", "", "write a JSON summary of the run, including all reported problems, to this file")
+	buildkiteAnnotate         = flag.Bool("buildkite_annotate", os.Getenv("BUILDKITE") == "true", "annotate the Buildkite build with a per release train summary table and PR links using buildkite-agent. Default is true when running under Buildkite")
+	annotationFile            = flag.String("annotation_file", "", "write the per release train summary table and PR links as markdown to this file")
+	propertiesFile            = flag.String("properties_file", "", "write created PR links and the outcome of the run to this Java properties file, like for the Jenkins readProperties step")
+	bazelOutputBase           = flag.String("bazel_output_base", "", "pin the bazel server to this --output_base so all bazel invocations of the run reuse it")
+	bazelStartupOptions       SliceFlags
+	bazelFlags                SliceFlags
+	incremental               = flag.Bool("incremental", false, "skip release trains whose inputs did not change since the last commit to the deployment branch")
+	forceAll                  = flag.Bool("force_all", false, "process all release trains even if -incremental is set")
+	gitUserName               = flag.String("git_user_name", "", "author and committer name for deployment commits. Default is taken from git config")
+	gitUserEmail              = flag.String("git_user_email", "", "author and committer email for deployment commits. Default is taken from git config")
+	staleBase                 = flag.String("stale_base", staleBaseRebase, "what to do when -gitops_pr_into advanced since the clone: rebase deployment branches onto it, fail the run or ignore")
+	signOff                   = flag.Bool("signoff", false, "add a Signed-off-by trailer to deployment commits and verify all pushed commits carry it (DCO)")
+	attestProvenance          = flag.Bool("provenance", false, "commit a SLSA provenance statement describing the source commit, targets and images with every deployment commit")
+	provenanceDir             = flag.String("provenance_dir", "provenance", "repository directory for provenance statements, should be outside of -gitops_path")
+	provenanceBuilderID       = flag.String("provenance_builder_id", "https://github.com/fasterci/rules_gitops/gitops/prer", "builder id recorded in provenance statements")
+	sourceRepo                = flag.String("source_repo", "", "source repository url recorded in provenance statements. Default is -git_repo")
+	secretScan                = flag.Bool("secret_scan", false, "scan rendered manifests for secrets before committing and block release trains with findings")
+	secretScanEntropy         = flag.Float64("secret_scan_entropy", 4.5, "report strings with Shannon entropy (bits per character) at or above this value. 0 disables the entropy check")
+	secretScanOverride        = flag.Bool("secret_scan_override", false, "report secret scan findings as warnings and commit anyway")
+	secretScanPatterns        SliceFlags
+	ignoreServerFields        = flag.Bool("ignore_server_fields", true, "do not commit rendered manifests that only differ from the committed version by server populated fields like creationTimestamp, generation or status, or by formatting")
+	imageRequireDigest        = flag.Bool("image_require_digest", false, "block release trains using images not pinned by digest")
+	imageAllowed              SliceFlags
+	imageDeniedTags           SliceFlags
+	verifyPlatforms           SliceFlags
+	applyContext              = flag.String("apply_to_context", "", "apply rendered manifests of all release trains directly to the cluster of this kubectl context instead of committing them and creating PRs. Intended for dev environments")
+	applyFieldManager         = flag.String("apply_field_manager", "rules_gitops", "field manager name used for server-side apply with -apply_to_context")
+	applyForceConflicts       = flag.Bool("apply_force_conflicts", false, "take ownership of fields managed by other field managers with -apply_to_context")
+	applyRolloutTimeout       = flag.Duration("apply_rollout_timeout", 5*time.Minute, "wait up to this long for Deployments, StatefulSets, DaemonSets and Jobs applied with -apply_to_context to become ready. 0 disables the check")
+	namespaceBootstrap        = flag.Bool("namespace_bootstrap", false, "generate a Namespace manifest for every namespace used by rendered manifests and not defined by them")
+	namespaceDir              = flag.String("namespace_dir", "namespaces", "directory inside -gitops_path for Namespace manifests generated by -namespace_bootstrap")
+	namespaceLabels           SliceFlags
+	namespaceAnnotations      SliceFlags
+	deploymentsIndex          = flag.String("deployments_index", "", "maintain an index of all release trains with their targets, source commit and images in this file of the gitops repository, like DEPLOYMENTS.md or deployments.yaml. Default is no index")
+	kustomizationIndex        = flag.Bool("kustomization_index", false, "maintain a kustomization.yaml listing the manifests of every directory changed by a release train")
+	standardLabels            = flag.Bool("standard_labels", false, "add app.kubernetes.io/managed-by and release train labels and a source commit annotation to every rendered resource")
+	resourceLabels            SliceFlags
+	resourceAnnotations       SliceFlags
+	kubectlCmd                = flag.String("kubectl", "kubectl", "kubectl binary to use with -apply_to_context")
+	cqueryStarlark            = flag.Bool("cquery_starlark", false, "discover gitops targets with cquery --output=starlark instead of parsing proto output, independent of the bazel proto schema")
+	cqueryStarlarkExpr        = flag.String("cquery_starlark_expr", discovery.DefaultStarlarkExpr, "starlark expression used with -cquery_starlark. It has to print the target label, deployment branch and release branch prefix separated by tabs")
+	cqueryStreamed            = flag.Bool("cquery_streamed", false, "parse cquery results incrementally using --output=streamed_proto (requires bazel 7+) to bound memory usage")
+	runID                     = flag.String("run_id", "", "identifier of the run recorded in the marker embedded in deployment PR bodies. Default is a random id")
+	profileName               = flag.String("profile", os.Getenv("GITOPS_PROFILE"), "preset flag defaults for a CI system: "+strings.Join(profile.Names(), ", ")+". Flags set on the command line take precedence")
+	smtpServer                = flag.String("smtp_server", "localhost:25", "host:port of the SMTP server used to send -email_to notifications")
+	smtpUser                  = flag.String("smtp_user", os.Getenv("GITOPS_SMTP_USER"), "SMTP user name, no authentication if empty")
+	smtpPassword              = flag.String("smtp_password", os.Getenv("GITOPS_SMTP_PASSWORD"), "SMTP password of -smtp_user")
+	emailFrom                 = flag.String("email_from", "", "sender address of notification emails")
+	emailTo                   SliceFlags
+	pagerdutyRoutingKey       = flag.String("pagerduty_routing_key", os.Getenv("PAGERDUTY_ROUTING_KEY"), "trigger a PagerDuty incident with this Events API v2 integration key when the run fails in -alert_phase phases")
+	opsgenieAPIKey            = flag.String("opsgenie_api_key", os.Getenv("OPSGENIE_API_KEY"), "create an Opsgenie alert with this API key when the run fails in -alert_phase phases")
+	opsgenieURL               = flag.String("opsgenie_api_url", "https://api.opsgenie.com", "Opsgenie API url, use https://api.eu.opsgenie.com for EU accounts")
+	alertPhases               SliceFlags
+	trainDependencies         SliceFlags
+	deploymentWindows         SliceFlags
+	freezeFile                = flag.String("freeze_file", ".gitops-freeze", "file on -gitops_pr_into listing frozen release trains, one per line, or * for all trains, optionally followed by ': reason'. Frozen trains are skipped")
+	freezeURL                 = flag.String("freeze_url", "", "url returning a list of frozen release trains in the -freeze_file format")
+	deploymentWindowAction    = flag.String("deployment_window_action", "defer", "what to do with the PR of a release train outside of its -deployment_window: 'defer' pushes the branch without opening the PR, 'draft' opens a draft PR (github and gitlab only) that is marked ready for review once the window opens")
+	trainWaitMerged           = flag.Duration("train_wait_merged", 0, "before opening the PR of a release train wait up to this long for open PRs of the trains it depends on (-train_dependency) to be merged or closed. 0 disables waiting")
+	printVersion              = flag.Bool("version", false, "print the version, commit and build time of the tool and exit")
+	preflight                 = flag.Bool("preflight", false, "validate bazel, the discovery query, repository access and the git server token without changing anything, then exit. Same as the doctor command")
+	sarifOutput               = flag.String("sarif_output", "", "write secret scan and image policy findings to this file in the SARIF format for code scanning dashboards")
+	gcMode                    = flag.Bool("gc", false, "remove manifest directories in -gitops_path of -gitops_pr_into that no discovered target renders and that did not change for -gc_min_age with a PR from -gc_branch, then exit. Same as the gc command")
+	gcMinAge                  = flag.Duration("gc_min_age", 30*24*time.Hour, "minimum time since the last change of an orphaned manifest directory removed by -gc")
+	gcBranch                  = flag.String("gc_branch", "gitops-gc", "branch the -gc cleanup PR is opened from")
+	prReconcile               = flag.Bool("gitops_pr_reconcile", false, "recognize deployment PRs created by previous runs by the marker in their body and close PRs of the same release train, release branch and deployment branch prefix and suffix opened from a different branch")
+	recordDynamoDBTable       = flag.String("record_dynamodb_table", "", "record proposed deployments in this DynamoDB table with the string partition key train and sort key id. Credentials are read from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables")
+	recordDynamoDBRegion      = flag.String("record_dynamodb_region", os.Getenv("AWS_REGION"), "AWS region of -record_dynamodb_table")
+	recordDynamoDBURL         = flag.String("record_dynamodb_url", "", "DynamoDB endpoint url, default is the regional endpoint")
+	recordSQLDriver           = flag.String("record_sql_driver", "", "record proposed deployments in a SQL table using this database/sql driver: postgres, or a driver linked into a custom build")
+	recordSQLDSN              = flag.String("record_sql_dsn", os.Getenv("GITOPS_RECORD_SQL_DSN"), "data source name of the -record_sql_driver database")
+	recordSQLTable            = flag.String("record_sql_table", "gitops_deployments", "table deployments are recorded in with -record_sql_driver")
+	recordSQLPlaceholder      = flag.String("record_sql_placeholder", "", "bind parameter style of -record_sql_driver: ? or $, default $ for postgres and ? otherwise")
 )
 
+// problems collects non-fatal errors and warnings reported at the end of the run
+var problems report.Report
+
 func init() {
+	flag.Var(&targetPatterns, "target", "target pattern to scan for gitops targets. Can be specified multiple times, patterns are unioned and patterns starting with - are excluded, like -target //services/... -target -//services/legacy/... Default is //... except //experimental/...")
 	flag.Var(&gitopsKind, "gitops_dependencies_kind", "dependency kind(s) to run during gitops phase. Can be specified multiple times. Default is 'k8s_container_push'")
 	flag.Var(&gitopsRuleName, "gitops_dependencies_name", "dependency name(s) to run during gitops phase. Can be specified multiple times. Default is empty")
 	flag.Var(&gitopsRuleAttr, "gitops_dependencies_attr", "dependency attribute(s) to run during gitops phase. Use attribute=value format. Can be specified multiple times. Default is empty")
 	flag.Var(&resolvedPushes, "resolved_push", "list of resolved push binaries to run. Can be specified multiple times. format is cmd/binary/to/run/command. Default is empty")
 	flag.Var(&resolvedBinaries, "resolved_binary", "list of resolved gitops binaries to run. Can be specified multiple times. format is releasetrain:cmd/binary/to/run/command. Default is empty")
 	flag.StringVar(&gitopsdir, "gitopsdir", "", "do not use temporary directory for gitops, use this directory instead")
+	flag.BoolVar(&gitopsdirClean, "gitopsdir_clean", false, "discard local changes, untracked files and interrupted operations of an existing -gitopsdir checkout instead of failing")
+	flag.Var(&gitFetchRefspecs, "git_fetch_refspec", "refspec or branch name pattern, like release/*, to fetch from the gitops repository. Can be specified multiple times. Default is to fetch all branches")
+	flag.Var(&gitPushRemotes, "git_push_remote", "additional remote to push deployment branches to, like a disaster recovery mirror, in name=url format. Can be specified multiple times. Default is empty")
+	flag.Var(&secretScanPatterns, "secret_scan_pattern", "additional regular expression reported as a secret by -secret_scan. Can be specified multiple times. Default is empty")
+	flag.Var(&imageAllowed, "image_allowed_repository", "registry or repository prefix rendered images must come from, like gcr.io/project. Can be specified multiple times. Default is to allow any")
+	flag.Var(&imageDeniedTags, "image_denied_tag", "image tag rendered manifests must not use, like latest. Can be specified multiple times. Default is empty")
+	flag.Var(&verifyPlatforms, "verify_platform", "platform like linux/arm64 images of updated trains must be available for after the push. Can be specified multiple times. Default is no verification")
+	flag.Var(&namespaceLabels, "namespace_label", "label of Namespace manifests generated by -namespace_bootstrap in key=value format, like istio-injection=enabled. Can be specified multiple times. Default is empty")
+	flag.Var(&namespaceAnnotations, "namespace_annotation", "annotation of Namespace manifests generated by -namespace_bootstrap in key=value format. Can be specified multiple times. Default is empty")
+	flag.Var(&renderEnv, "render_env", "name of an environment variable passed to gitops binaries with -render_clean_env. Can be specified multiple times. Default is empty")
+	flag.Var(&resourceLabels, "resource_label", "label added to every rendered resource in key=value format, like team=payments. Can be specified multiple times. Default is empty")
+	flag.Var(&resourceAnnotations, "resource_annotation", "annotation added to every rendered resource in key=value format. Can be specified multiple times. Default is empty")
+	flag.Var(&bazelStartupOptions, "bazel_startup_option", "bazel startup option used for all bazel invocations. Can be specified multiple times. Default is empty")
+	flag.Var(&emailTo, "email_to", "send a summary of the run with PR links, release trains and problems to this address when PRs were opened or problems were reported. Can be specified multiple times. Default is empty")
+	flag.Var(&alertPhases, "alert_phase", "phase whose errors open PagerDuty or Opsgenie alerts, one per release train. Can be specified multiple times. Default is fatal, push and pr")
+	flag.Var(&trainDependencies, "train_dependency", "release train dependency in train=dependency[,dependency...] format, like services=infra. Trains are rendered and their PRs opened after their dependencies. Can be specified multiple times. Default is empty")
+	flag.Var(&deploymentWindows, "deployment_window", "deployment window of a release train in train=DAYS HH:MM-HH:MM [TIMEZONE] format, like prod=Mon-Fri 09:00-17:00 Europe/Berlin. Several windows are separated by ';'. Can be specified multiple times. Default is no window")
+	flag.Var(&bazelFlags, "bazel_flag", "bazel flag passed to all bazel cquery and run invocations so they share the analysis cache. Can be specified multiple times. Default is empty")
 }
 
-func bazelQuery(query string) *analysis.CqueryResult {
-	log.Println("Executing bazel cquery ", query)
-	cmd := oe.Command(*bazelCmd, "cquery", query, "--output=proto")
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		log.Fatal(err)
-	}
-	go func() {
-		io.Copy(os.Stderr, stderr)
-	}()
-	buildproto, err := cmd.Output()
-	if err != nil {
-		log.Fatal(err)
+// bazelc is used for all bazel invocations of the run
+var bazelc *bazel.Command
+
+// clk and runner are the clock and the command runner of the run.
+// gitRunner runs git commands with the git transport settings and push credentials in their environment.
+var (
+	clk       clock.Clock = clock.Real
+	runner    exec.Runner = exec.Default
+	gitRunner exec.Runner
+)
+
+// detectSource fills -branch_name and -git_commit left at their defaults from CI environment variables
+func detectSource() {
+	branch, commit, ci := profile.DetectSource(os.Getenv)
+	if *branchName == "unknown" && branch != "" {
+		*branchName = branch
+		log.Printf("using branch %s detected from %s environment", branch, ci)
 	}
-	qr := &analysis.CqueryResult{}
-	if err := proto.Unmarshal(buildproto, qr); err != nil {
-		log.Fatal(err)
+	if *gitCommit == "unknown" && commit != "" {
+		*gitCommit = commit
+		log.Printf("using commit %s detected from %s environment", commit, ci)
 	}
-	return qr
 }
 
 func main() {
 	flag.Parse()
+	switch flag.Arg(0) {
+	case "doctor":
+		*preflight = true
+		if err := flag.CommandLine.Parse(flag.Args()[1:]); err != nil {
+			fatalf("%v", err)
+		}
+	case "gc":
+		*gcMode = true
+		if err := flag.CommandLine.Parse(flag.Args()[1:]); err != nil {
+			fatalf("%v", err)
+		}
+	case "completion":
+		printCompletion(flag.Arg(1))
+		return
+	}
+	if *printVersion {
+		fmt.Println(progName(), currentBuild())
+		return
+	}
+	log.Println(progName(), currentBuild())
+	if *profileName == "" {
+		if *profileName = profile.Detect(os.Getenv); *profileName != "" {
+			log.Println("detected CI profile", *profileName)
+		}
+	}
+	if *profileName != "" {
+		if err := profile.Apply(flag.CommandLine, *profileName, os.Getenv); err != nil {
+			fatalf("%v", err)
+		}
+	}
+	if *branchName == "unknown" || *gitCommit == "unknown" {
+		detectSource()
+	}
 	if *workspace != "" {
 		if err := os.Chdir(*workspace); err != nil {
-			log.Fatal(err)
+			fatalf("%v", err)
 		}
 	}
+	if *bazelCmd == "" {
+		bin, reason := bazel.FindDefault(".")
+		*bazelCmd = bin
+		log.Printf("using bazel command %s: %s", bin, reason)
+	} else {
+		log.Printf("using bazel command %s set with -bazel_cmd", *bazelCmd)
+	}
+	bazelc = &bazel.Command{
+		Bin:            *bazelCmd,
+		OutputBase:     *bazelOutputBase,
+		StartupOptions: bazelStartupOptions,
+		Flags:          bazelFlags,
+	}
 	if len(gitopsKind) == 0 {
-		gitopsKind = []string{"k8s_container_push", "push_oci"}
+		gitopsKind = []string{"k8s_container_push", "push_oci", "push_helm_chart"}
+	}
+
+	if *runID == "" {
+		*runID = newRunID()
 	}
 
 	var gitServer git.Server
+	var draftServer git.Server
+	var readyMarker git.ReadyMarker
+	var serverCheck func() error
+	var reconciler git.Reconciler
+	var batchServer git.BatchServer
+	var diffURL func(from, to, path string) string
+	var pushUser, pushPassword string
 	switch *gitHost {
 	case "github":
 		gitServer = git.ServerFunc(github.CreatePR)
+		draftServer = git.ServerFunc(github.CreateDraftPR)
+		readyMarker = git.ReadyFunc(github.MarkReady)
+		serverCheck = github.Check
+		reconciler = git.ReconcilerFuncs{List: github.OpenPRs, Close: github.ClosePR}
+		if github.GraphQLEnabled() {
+			batchServer = git.BatchFuncs{Create: github.CreatePRs, Close: github.ClosePRs}
+			reconciler = git.ReconcilerFuncs{List: github.QueryOpenPRs, Close: github.ClosePR}
+		}
+		diffURL = github.DiffURL
+		pushUser, pushPassword = github.PushCredentials()
 	case "gitlab":
 		gitServer = git.ServerFunc(gitlab.CreatePR)
+		draftServer = git.ServerFunc(gitlab.CreateDraftPR)
+		readyMarker = git.ReadyFunc(gitlab.MarkReady)
+		serverCheck = gitlab.Check
+		reconciler = git.ReconcilerFuncs{List: gitlab.OpenPRs, Close: gitlab.ClosePR}
+		diffURL = gitlab.DiffURL
+		pushUser, pushPassword = gitlab.PushCredentials()
 	case "bitbucket":
 		gitServer = git.ServerFunc(bitbucket.CreatePR)
+		serverCheck = bitbucket.Check
+		reconciler = git.ReconcilerFuncs{List: bitbucket.OpenPRs, Close: bitbucket.ClosePR}
+		diffURL = bitbucket.DiffURL
+		pushUser, pushPassword = bitbucket.PushCredentials()
+	default:
+		fatalf("unknown vcs host: %s", *gitHost)
+	}
+	gitRunner = configureTransport(pushUser, pushPassword)
+	configureSandboxes()
+	stores := recordStores()
+	switch *staleBase {
+	case staleBaseRebase, staleBaseFail, staleBaseIgnore:
 	default:
-		log.Fatalf("unknown vcs host: %s", *gitHost)
+		fatalf("invalid -stale_base %q, expected rebase, fail or ignore", *staleBase)
+	}
+	extraRemotes := parsePushRemotes()
+	started := clk.Now()
+	windows := parseDeploymentWindows()
+	if *preflight {
+		if !runPreflight(serverCheck) {
+			os.Exit(1)
+		}
+		return
 	}
 
 	releaseTrains := make(map[string][]string)
-	if len(resolvedBinaries) > 0 {
+	summary := &runSummary{
+		Tool:          currentBuild(),
+		ReleaseBranch: *releaseBranch,
+		Trains:        releaseTrains,
+	}
+	defer finish(summary)
+	activeSummary = summary
+	exec.Fatalf = fatalf
+	var manifest *resolvedManifest
+	if *resolvedManifestFile != "" {
+		var err error
+		manifest, err = loadResolvedManifest(*resolvedManifestFile)
+		if err != nil {
+			fatalf("invalid -resolved_manifest: %v", err)
+		}
+		for train, rt := range manifest.Trains {
+			// the binaries are executed, the targets are recorded in commit messages
+			releaseTrains[train] = rt.Targets
+			for i, t := range rt.Targets {
+				resolvedExecutables[t] = rt.Binaries[i]
+			}
+		}
+	} else if len(resolvedBinaries) > 0 {
 		for _, rb := range resolvedBinaries {
 			releaseTrain, bin, found := strings.Cut(rb, ":")
 			if !found {
-				log.Fatalf("resolved_binaries: invalid resolved_binary format: %s", rb)
+				fatalf("resolved_binaries: invalid resolved_binary format: %s", rb)
 			}
 			releaseTrains[releaseTrain] = append(releaseTrains[releaseTrain], bin)
 		}
 	} else {
-
-		q := fmt.Sprintf("attr(deployment_branch, \".+\", attr(release_branch_prefix, \"%s\", kind(gitops, %s)))", *releaseBranch, *target)
-		qr := bazelQuery(q)
-		for _, t := range qr.Results {
-			var releaseTrain string
-			for _, a := range t.Target.GetRule().GetAttribute() {
-				if a.GetName() == "deployment_branch" {
-					releaseTrain = a.GetStringValue()
-				}
-			}
-			releaseTrains[releaseTrain] = append(releaseTrains[releaseTrain], t.Target.Rule.GetName())
+		logBazelVersion()
+		for train, targets := range discoverTrains() {
+			releaseTrains[train] = targets
 		}
 		if (len(releaseTrains)) == 0 {
 			log.Println("No matching targets found")
@@ -158,10 +400,21 @@ func main() {
 		}
 	}
 
-	for train, targets := range releaseTrains {
-		fmt.Println(train)
-		for _, t := range targets {
-			fmt.Println(" ", t)
+	if *writeResolvedManifestFile != "" {
+		if err := writeResolvedManifest(*writeResolvedManifestFile, releaseTrains); err != nil {
+			fatalf("unable to write resolved manifest: %v", err)
+		}
+		return
+	}
+
+	trainOrder := orderTrains(releaseTrains)
+	// stdout is reserved for the diff with -diff_only
+	if !*diffOnly {
+		for _, train := range trainOrder {
+			fmt.Println(train)
+			for _, t := range releaseTrains[train] {
+				fmt.Println(" ", t)
+			}
 		}
 	}
 
@@ -169,124 +422,256 @@ func main() {
 		var err error
 		gitopsdir, err = os.MkdirTemp(*gitopsTmpDir, "gitops")
 		if err != nil {
-			log.Fatalf("Unable to create tempdir in %s: %v", *gitopsTmpDir, err)
+			fatalf("Unable to create tempdir in %s: %v", *gitopsTmpDir, err)
 		}
 		defer os.RemoveAll(gitopsdir)
 	}
-	workdir, err := git.CloneOrCheckout(*repo, gitopsdir, *gitMirror, *prInto, *gitopsPath, *deployBranchPrefix)
+	if *applyContext != "" {
+		applyTrains(gitopsdir, trainOrder, releaseTrains, manifest, summary)
+		return
+	}
+	mirror := *gitMirror
+	if *gitCacheDir != "" {
+		if err := git.UpdateCache(gitRunner, *repo, *gitCacheDir); err != nil {
+			fatalf("Unable to update git cache: %v", err)
+		}
+		mirror = *gitCacheDir
+	}
+	cloneOpts := git.CloneOptions{
+		Remote:   *gitRemote,
+		Progress: !*gitQuiet,
+		Quiet:    *gitQuiet,
+		Fetch:    gitFetchRefspecs,
+		Clean:    gitopsdirClean,
+		Runner:   gitRunner,
+	}
+	if *gitFetchMinimal {
+		cloneOpts.Fetch = append(cloneOpts.Fetch, *prInto, *deployBranchPrefix+"*")
+	}
+	workdir, err := git.CloneOrCheckoutOptions(*repo, gitopsdir, mirror, *prInto, *gitopsPath, *deployBranchPrefix, cloneOpts)
+	if err != nil {
+		fatalf("Unable to clone repo: %v", err)
+	}
+	workdir.SetIdentity(*gitUserName, *gitUserEmail)
+	workdir.SignOff = *signOff
+	if *incremental {
+		fetchInputsNotes(workdir)
+	}
+	cloneBase, err := workdir.Rev("HEAD")
 	if err != nil {
-		log.Fatalf("Unable to clone repo: %v", err)
+		fatalf("Unable to resolve %s: %v", *prInto, err)
 	}
 
+	if *gcMode {
+		runGC(workdir, trainOrder, releaseTrains, gitServer, summary)
+		return
+	}
+
+	var scanner *secretscan.Scanner
+	if *secretScan {
+		scanner = newSecretScanner()
+	}
+
+	policy := &imagepolicy.Policy{
+		AllowedRepositories: imageAllowed,
+		DeniedTags:          imageDeniedTags,
+		RequireDigest:       *imageRequireDigest,
+	}
+	platformVerifier := newPlatformVerifier()
+	branchImages := make(map[string][]string)
+
 	var updatedGitopsTargets []string
 	var updatedGitopsBranches []string
+	var updatedGitopsTrains []string
+	// existing branches of windowed trains without changes whose PR was deferred by an earlier run
+	var deferredBranches []string
+	branchTrains := make(map[string]string)
+	branchTargets := make(map[string]string)
+	inputsNoted := false
 
-	for train, targets := range releaseTrains {
-		log.Println("train", train)
-		branch := fmt.Sprintf("%s%s%s", *deployBranchPrefix, train, *deploymentBranchSuffix)
+	var diff strings.Builder
+	frozen := loadFreeze(workdir)
+	for _, unit := range deployUnits(trainOrder, releaseTrains) {
+		train, targets, branch := unit.Train, unit.Targets, unit.Branch
+		log.Println("train", train, "branch", branch)
+		if reason, ok := frozen.Frozen(train); ok {
+			if reason == "" {
+				reason = "no reason given"
+			}
+			log.Println("train", train, "is frozen, skipping")
+			// per-target branches of a train are consecutive, the train is reported once
+			if n := len(summary.FrozenTrains); n == 0 || summary.FrozenTrains[n-1] != train {
+				problems.Warnf("freeze", train, "release train is frozen, no changes are deployed: %s", reason)
+				summary.FrozenTrains = append(summary.FrozenTrains, train)
+			}
+			continue
+		}
 		newBranch := workdir.SwitchToBranch(branch, *prInto)
+		var lastMsg string
+		recreated := false
 		if !newBranch {
 			// Find if we need to recreate the branch because target was deleted
-			msg := workdir.GetLastCommitMessage()
-			targetset := make(map[string]bool)
-			for _, t := range targets {
-				targetset[t] = true
+			lastMsg = workdir.GetLastCommitMessage()
+			if removed := commitmsg.RemovedTargets(lastMsg, targets); len(removed) > 0 {
+				workdir.RecreateBranch(branch, *prInto)
+				lastMsg = ""
+				recreated = true
 			}
-			oldtargets := commitmsg.ExtractTargets(msg)
-			for _, t := range oldtargets {
-				if !targetset[t] {
-					// target t is not present in a new list
-					workdir.RecreateBranch(branch, *prInto)
-					break
+		}
+		var inputsHash string
+		if *incremental {
+			var err error
+			inputsHash, err = trainInputsHash(targets)
+			if err != nil {
+				problems.Warnf("render", train, "incremental mode disabled for the train: %v", err)
+			} else if !*forceAll && !recreated && inputsHash == lastInputsHash(workdir, branch, lastMsg) {
+				log.Println("train", train, "inputs did not change, skipping")
+				if deferredPR(workdir, windows, train, branch) {
+					deferredBranches = append(deferredBranches, branch)
+					branchTrains[branch] = train
+					branchTargets[branch] = unit.Target
 				}
+				continue
+			}
+		}
+		renderStart := clk.Now()
+		var beforeRender map[string]time.Time
+		if *deploymentsIndex != "" {
+			beforeRender = manifestModTimes(workdir)
+		}
+		if err := renderTrain(train, targets, gitopsdir, *gitopsParallelism); err != nil {
+			fatalf("ERROR: %v", err)
+		}
+		if *namespaceBootstrap {
+			files, err := workdir.ChangedFiles(*gitopsPath)
+			if err != nil {
+				fatalf("unable to list changed files: %v", err)
+			}
+			if err := bootstrapTrainNamespaces(workdir.Dir, files); err != nil {
+				fatalf("unable to generate namespaces for train %s: %v", train, err)
+			}
+		}
+		stampTrain(workdir, train)
+		if *ignoreServerFields {
+			restoreCosmeticChanges(workdir, train)
+		}
+		if *kustomizationIndex {
+			indexTrain(workdir, train)
+		}
+		if scanner != nil && !scanTrain(scanner, workdir, train, targets) {
+			log.Println("train", train, "is blocked by secret scan")
+			workdir.Discard(*gitopsPath)
+			continue
+		}
+		if policy.Enabled() && !checkImagePolicy(policy, workdir, train, targets) {
+			log.Println("train", train, "is blocked by image policy")
+			workdir.Discard(*gitopsPath)
+			continue
+		}
+		if *diffOnly {
+			diff.WriteString(trainDiff(workdir, train))
+			continue
+		}
+		msg := commitmsg.GenerateSubject(*releaseBranch, *branchName, *gitCommit)
+		if *imageChangelogEnabled {
+			msg += trainChangelog(workdir, train)
+		}
+		msg += commitmsg.Generate(targets)
+		if inputsHash != "" {
+			msg += commitmsg.GenerateInputsHash(inputsHash)
+		}
+		msg += commitmsg.GenerateToolVersion(currentBuild().String())
+		var extraPaths []string
+		if *deploymentsIndex != "" {
+			if p := updateDeploymentsIndex(workdir, train, branch, targets, beforeRender); p != "" {
+				extraPaths = append(extraPaths, p)
 			}
 		}
-		for _, target := range targets {
-			log.Println("train", train, "target", target)
-			bin := bazel.TargetToExecutable(target)
-			exec.Mustex("", bin, "--nopush", "--deployment_root", gitopsdir)
+		if *attestProvenance {
+			p, err := writeProvenance(workdir, train, targets, renderStart)
+			if err != nil {
+				fatalf("unable to generate provenance for train %s: %v", train, err)
+			}
+			if p != "" {
+				extraPaths = append(extraPaths, p)
+			}
 		}
-		if workdir.Commit(fmt.Sprintf("GitOps for release branch %s from %s commit %s\n%s", *releaseBranch, *branchName, *gitCommit, commitmsg.Generate(targets)), *gitopsPath) {
+		var images []string
+		if platformVerifier != nil || len(stores) > 0 {
+			images = trainImages(workdir)
+		}
+		if workdir.Commit(msg, *gitopsPath, extraPaths...) {
 			log.Println("branch", branch, "has changes, push is required")
 			updatedGitopsTargets = append(updatedGitopsTargets, targets...)
 			updatedGitopsBranches = append(updatedGitopsBranches, branch)
+			if n := len(updatedGitopsTrains); n == 0 || updatedGitopsTrains[n-1] != train {
+				updatedGitopsTrains = append(updatedGitopsTrains, train)
+			}
+			branchTrains[branch] = train
+			branchTargets[branch] = unit.Target
+			if images != nil {
+				branchImages[branch] = images
+			}
+		} else {
+			if inputsHash != "" && !recreated && noteInputsHash(workdir, branch, inputsHash) {
+				// nothing changed, the hash is kept for the next run in a note as there is no commit to record it
+				inputsNoted = true
+			}
+			if !newBranch && !recreated && deferredPR(workdir, windows, train, branch) {
+				deferredBranches = append(deferredBranches, branch)
+				branchTrains[branch] = train
+				branchTargets[branch] = unit.Target
+			}
 		}
 	}
-	if len(updatedGitopsTargets) == 0 {
+	if inputsNoted {
+		pushInputsNotes(workdir)
+	}
+	if *diffOnly {
+		fmt.Print(diff.String())
+		return
+	}
+	summary.UpdatedBranches = updatedGitopsBranches
+	deferredBranches = withoutOpenPRs(reconciler, deferredBranches)
+	markDraftsReady(reconciler, readyMarker, windows)
+	if len(updatedGitopsTargets) == 0 && len(deferredBranches) == 0 {
 		log.Println("No gitops changes to push")
 		return
 	}
 
-	// Push images
-	if len(resolvedPushes) > 0 {
-		var eg errgroup.Group
-		eg.SetLimit(*pushParallelism)
-		for _, rp := range resolvedPushes {
-			cmd := rp
-			eg.Go(func() error {
-				exec.Mustex("", cmd)
-				return nil
-			})
-		}
-		eg.Wait()
-	} else {
+	if len(updatedGitopsTargets) > 0 {
+		pushImages(manifest, updatedGitopsTrains, updatedGitopsTargets)
+	}
 
-		// Create space separated set('//a' '//b' ... '//z') of targets.
-		// Target names need to be quoted to protect from + and other special characters
-		depsList := "set('" + strings.Join(updatedGitopsTargets, "' '") + "')"
-		var qv []string
-		for _, kind := range gitopsKind {
-			q := fmt.Sprintf("kind(%s, deps(%s))", kind, depsList)
-			qv = append(qv, q)
-		}
-		for _, name := range gitopsRuleName {
-			q := fmt.Sprintf("filter(%s, deps(%s))", name, depsList)
-			qv = append(qv, q)
-		}
-		for _, attr := range gitopsRuleAttr {
-			name, value, found := strings.Cut(attr, "=")
-			if !found {
-				value = ".*"
-			}
-			q := fmt.Sprintf("attr(%s, %s, deps(%s))", name, value, depsList)
-			qv = append(qv, q)
-		}
-
-		query := strings.Join(qv, " union ")
-		qr := bazelQuery(query)
-		targetsCh := make(chan string)
-		var wg sync.WaitGroup
-		wg.Add(*pushParallelism)
-		for i := 0; i < *pushParallelism; i++ {
-			go func() {
-				defer wg.Done()
-				for target := range targetsCh {
-					bin := bazel.TargetToExecutable(target)
-					fi, err := os.Stat(bin)
-					if err == nil && fi.Mode().IsRegular() {
-						exec.Mustex("", bin)
-					} else {
-						log.Println("target", target, "is not a file, running as a command")
-						exec.Mustex("", *bazelCmd, "run", target)
-					}
-				}
-			}()
-		}
-		for _, t := range qr.Results {
-			targetsCh <- t.Target.Rule.GetName()
-		}
-		close(targetsCh)
-		wg.Wait()
+	if platformVerifier != nil {
+		updatedGitopsBranches = verifyImagePlatforms(platformVerifier, updatedGitopsBranches, branchImages)
+		summary.UpdatedBranches = updatedGitopsBranches
+	}
+
+	updatedGitopsBranches = checkStaleBase(workdir, cloneBase, updatedGitopsBranches)
+	summary.UpdatedBranches = updatedGitopsBranches
+
+	if *signOff {
+		updatedGitopsBranches = verifySignOff(workdir, *prInto, updatedGitopsBranches)
+		summary.UpdatedBranches = updatedGitopsBranches
 	}
 
 	if *dryRun {
 		log.Println("dry-run: updated gitops branches: ", updatedGitopsBranches)
 		log.Println("dry-run: skipping push")
-	} else {
-		workdir.Push(updatedGitopsBranches)
+	} else if len(updatedGitopsBranches) > 0 {
+		summary.Pushes = pushRemotes(workdir, extraRemotes, updatedGitopsBranches)
 	}
 
-	for _, branch := range updatedGitopsBranches {
+	var prs *prReconciler
+	if *prReconcile {
+		prs = &prReconciler{server: reconciler, batch: batchServer}
+	}
+	var batch *prBatch
+	if batchServer != nil {
+		batch = &prBatch{server: batchServer}
+	}
+	for _, branch := range append(updatedGitopsBranches, deferredBranches...) {
 		if *dryRun {
 			log.Println("dry-run: skipping PR creation: branch", branch, "into", *prInto)
 			continue
@@ -301,9 +686,60 @@ func main() {
 		if body == "" {
 			body = branch
 		}
+		if *prBodyFiles || *imageChangelogEnabled {
+			body = withDetails(workdir, branch, body, diffURL)
+		}
+		train := branchTrains[branch]
+		body = prbody.WithMarker(body, pipelineMarker(train, branchTargets[branch]))
 
-		if err := gitServer.CreatePR(branch, *prInto, title, body); err != nil {
-			log.Fatal("unable to create PR: ", err)
+		if batch != nil && *trainWaitMerged > 0 {
+			// PRs of the trains this one depends on opened by this run have to exist before waiting for them
+			summary.PullRequests = append(summary.PullRequests, batch.flush()...)
 		}
+		if !waitForDependencies(reconciler, train, branch) {
+			continue
+		}
+
+		server := gitServer
+		draft := false
+		if outside, why := outsideWindow(windows, train, clk.Now()); outside {
+			if *deploymentWindowAction == "draft" && draftServer != nil {
+				log.Printf("train %s is %s, opening a draft PR", train, why)
+				server = draftServer
+				draft = true
+			} else {
+				log.Printf("train %s is %s, PR is deferred", train, why)
+				problems.Warnf("pr", branch, "PR is deferred: %s", why)
+				continue
+			}
+		}
+
+		if prs != nil {
+			if url, ok := prs.reconcile(train, branchTargets[branch], branch); ok {
+				log.Println("reusing existing PR from branch", branch)
+				summary.PullRequests = append(summary.PullRequests, prResult{Train: train, Branch: branch, URL: url})
+				continue
+			}
+		}
+
+		if batch != nil {
+			batch.add(train, git.NewPullRequest{Source: branch, Target: *prInto, Title: title, Body: body, Draft: draft})
+			continue
+		}
+
+		if err := server.CreatePR(branch, *prInto, title, body); err != nil {
+			log.Println("unable to create PR: ", err)
+			problems.Error("pr", branch, fmt.Errorf("unable to create PR into %s: %w", *prInto, err))
+			continue
+		}
+		summary.PullRequests = append(summary.PullRequests, prResult{Train: train, Branch: branch})
+	}
+	if batch != nil {
+		summary.PullRequests = append(summary.PullRequests, batch.flush()...)
+	}
+	if prs != nil {
+		prs.flush()
 	}
+	resolvePRURLs(reconciler, summary.PullRequests)
+	recordDeployments(stores, summary.PullRequests, releaseTrains, branchTargets, branchImages, started)
 }
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fasterci/rules_gitops/gitops/deployindex"
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+)
+
+// manifestModTimes returns modification times of manifests in the -gitops_path of the checkout
+func manifestModTimes(workdir *git.Repo) map[string]time.Time {
+	times := make(map[string]time.Time)
+	err := filepath.WalkDir(filepath.Join(workdir.Dir, *gitopsPath), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !manifests.IsManifest(path) {
+			return err
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		times[path] = fi.ModTime()
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		fatalf("unable to list manifests: %v", err)
+	}
+	return times
+}
+
+// updateDeploymentsIndex records the train in the -deployments_index file of the checkout.
+// Images are read from manifests written since beforeRender was taken with manifestModTimes.
+// Returns the index path to commit with the train, or an empty string if the train has no changes to deploy.
+func updateDeploymentsIndex(workdir *git.Repo, train, branch string, targets []string, beforeRender map[string]time.Time) string {
+	if !filepath.IsLocal(*deploymentsIndex) {
+		fatalf("invalid -deployments_index %s: expected a path relative to the repository root", *deploymentsIndex)
+	}
+	changed, err := workdir.ChangedFiles(*gitopsPath)
+	if err != nil {
+		fatalf("unable to list changed files: %v", err)
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+	// gitops binaries rewrite all files of the train, unchanged ones included
+	var rendered []string
+	for path, t := range manifestModTimes(workdir) {
+		if prev, ok := beforeRender[path]; !ok || !t.Equal(prev) {
+			rendered = append(rendered, path)
+		}
+	}
+	images, err := manifests.ImagesInFiles(rendered)
+	if err != nil {
+		fatalf("unable to read images of train %s: %v", train, err)
+	}
+
+	path := filepath.Join(workdir.Dir, *deploymentsIndex)
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		fatalf("unable to read %s: %v", *deploymentsIndex, err)
+	}
+	content, err = deployindex.Update(*deploymentsIndex, content, deployindex.Entry{
+		Train:        train,
+		Branch:       branch,
+		SourceBranch: *branchName,
+		SourceCommit: *gitCommit,
+		Targets:      targets,
+		Images:       images,
+	})
+	if err != nil {
+		fatalf("unable to update %s: %v", *deploymentsIndex, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		fatalf("unable to create directory of %s: %v", *deploymentsIndex, err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		fatalf("unable to write %s: %v", *deploymentsIndex, err)
+	}
+	return *deploymentsIndex
+}
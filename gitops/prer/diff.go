@@ -0,0 +1,23 @@
+package main
+
+import (
+	"log"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+// trainDiff returns the rendered changes of the train as a unified diff against its deployment branch
+// and discards them, so the next train starts from a clean checkout
+func trainDiff(workdir *git.Repo, train string) string {
+	diff, err := workdir.StagedDiff(*gitopsPath)
+	if err != nil {
+		fatalf("unable to diff train %s: %v", train, err)
+	}
+	workdir.Discard(*gitopsPath)
+	if diff == "" {
+		log.Println("train", train, "has no changes")
+	} else {
+		log.Println("train", train, "has changes")
+	}
+	return diff
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/bazel"
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+// preflightCheck is a single read-only validation of the configuration
+type preflightCheck struct {
+	name string
+	// run returns a description of the success or an error
+	run func() (string, error)
+	// hint tells how to fix a failure
+	hint string
+}
+
+// runPreflight validates the configuration without changing anything and prints the result of every check.
+// It returns false if any check failed.
+func runPreflight(serverCheck func() error) bool {
+	var checks []preflightCheck
+	if *resolvedManifestFile == "" && len(resolvedBinaries) == 0 {
+		checks = append(checks,
+			preflightCheck{
+				name: "bazel",
+				run: func() (string, error) {
+					out, err := bazelc.Cmd("version").CombinedOutput()
+					if err != nil {
+						return "", fmt.Errorf("%s version: %w\n%s", *bazelCmd, err, out)
+					}
+					for _, line := range strings.Split(string(out), "\n") {
+						if strings.HasPrefix(line, "Build label:") {
+							return *bazelCmd + " " + line, nil
+						}
+					}
+					return *bazelCmd + " runnable", nil
+				},
+				hint: "set -bazel_cmd to a bazel or bazelisk binary and -workspace to the workspace root",
+			},
+			preflightCheck{
+				name: "query",
+				run: func() (string, error) {
+					out, err := bazelc.Cmd("cquery", discoveryQuery(), "--output=label").Output()
+					if err != nil {
+						return "", fmt.Errorf("cquery %s: %w", discoveryQuery(), err)
+					}
+					n := 0
+					for _, line := range strings.Split(string(out), "\n") {
+						if strings.TrimSpace(line) != "" {
+							n++
+						}
+					}
+					if n == 0 {
+						return "", fmt.Errorf("no gitops targets with release_branch_prefix %q in %s", *releaseBranch, bazel.TargetPatterns(targetPatterns))
+					}
+					return fmt.Sprintf("%d gitops target(s) found", n), nil
+				},
+				hint: "check -target and -release_branch, and that k8s_deploy targets set deployment_branch and release_branch_prefix",
+			},
+		)
+	}
+	checks = append(checks,
+		preflightCheck{
+			name: "repository",
+			run: func() (string, error) {
+				if *repo == "" {
+					return "", fmt.Errorf("-git_repo is not set")
+				}
+				exists, err := git.RemoteBranchExists(gitRunner, *repo, *prInto)
+				if err != nil {
+					return "", err
+				}
+				if !exists {
+					return "", fmt.Errorf("branch %s does not exist in %s", *prInto, *repo)
+				}
+				return fmt.Sprintf("%s has branch %s", *repo, *prInto), nil
+			},
+			hint: "check -git_repo, -gitops_pr_into and the git credentials",
+		},
+		preflightCheck{
+			name: "push",
+			run: func() (string, error) {
+				if err := git.CheckPushAccess(gitRunner, *repo, *deployBranchPrefix+"preflight-"+*runID); err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%s accepts pushes to %s*", *repo, *deployBranchPrefix), nil
+			},
+			hint: "the push credentials need write access to the repository and deployment branches must not be protected",
+		},
+		preflightCheck{
+			name: *gitHost + " api",
+			run: func() (string, error) {
+				if err := serverCheck(); err != nil {
+					return "", err
+				}
+				return "token is valid", nil
+			},
+			hint: "check the -" + *gitHost + "_* flags and the access token",
+		},
+	)
+	ok := true
+	for _, c := range checks {
+		msg, err := c.run()
+		if err != nil {
+			ok = false
+			fmt.Fprintf(os.Stdout, "[FAIL] %s: %v\n       %s\n", c.name, err, c.hint)
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "[ OK ] %s: %s\n", c.name, msg)
+	}
+	return ok
+}
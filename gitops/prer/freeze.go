@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+
+	"github.com/fasterci/rules_gitops/gitops/freeze"
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+// loadFreeze reads the freeze list from -freeze_file on -gitops_pr_into and from -freeze_url.
+// The run is stopped if a configured list can't be read, so an unavailable list never lets frozen trains through.
+func loadFreeze(workdir *git.Repo) freeze.List {
+	list := freeze.List{}
+	if *freezeFile != "" {
+		content, found, err := workdir.FileAt(*gitRemote+"/"+*prInto, *freezeFile)
+		if err != nil {
+			fatalf("unable to read freeze file %s: %v", *freezeFile, err)
+		}
+		if found {
+			l, err := freeze.Parse(bytes.NewReader(content))
+			if err != nil {
+				fatalf("unable to parse freeze file %s: %v", *freezeFile, err)
+			}
+			list.Merge(l)
+		}
+	}
+	if *freezeURL != "" {
+		l, err := freeze.Fetch(*freezeURL)
+		if err != nil {
+			fatalf("unable to read freeze list from %s: %v", *freezeURL, err)
+		}
+		list.Merge(l)
+	}
+	return list
+}
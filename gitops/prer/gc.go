@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fasterci/rules_gitops/gitops/gc"
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+)
+
+// orphan is a manifest directory no discovered target renders
+type orphan struct {
+	dir string
+	age time.Duration
+}
+
+// runGC renders all release trains into an empty deployment root to find manifest directories of -gitops_pr_into
+// none of them produces, and opens a cleanup PR from -gc_branch removing the ones unchanged for -gc_min_age.
+// Files generated by the tool itself, like Namespace manifests, are never collected, and neither are directories
+// written by deployment commits of other release branches sharing -gitops_path.
+func runGC(workdir *git.Repo, trainOrder []string, releaseTrains map[string][]string, server git.Server, summary *runSummary) {
+	if len(targetPatterns) > 0 || len(resolvedBinaries) > 0 {
+		// manifests of the targets left out would look orphaned
+		fatalf("gc renders all discovered targets of the release branch, refusing to run with -target or -resolved_binary")
+	}
+	renderRoot, err := os.MkdirTemp(*gitopsTmpDir, "gitops-gc-")
+	if err != nil {
+		fatalf("unable to create gc render directory: %v", err)
+	}
+	defer os.RemoveAll(renderRoot)
+	for _, train := range trainOrder {
+		if err := renderTrain(train, releaseTrains[train], renderRoot, *gitopsParallelism); err != nil {
+			fatalf("ERROR: %v", err)
+		}
+	}
+	existing := gc.Unowned(listManifests(filepath.Join(workdir.Dir, *gitopsPath)), toolOwnedPaths())
+	produced := listManifests(filepath.Join(renderRoot, *gitopsPath))
+	if len(produced) == 0 {
+		// every directory would look orphaned
+		fatalf("no manifests were rendered in %s, refusing to collect garbage", *gitopsPath)
+	}
+
+	candidates := gc.Orphans(existing, produced)
+	owned, err := gc.Owned(candidates, *releaseBranch, func(dir string) ([]string, error) {
+		return workdir.CommitMessages("HEAD", filepath.Join(*gitopsPath, filepath.FromSlash(dir)))
+	})
+	if err != nil {
+		fatalf("unable to find the release branches of orphaned manifests: %v", err)
+	}
+	if skipped := len(candidates) - len(owned); skipped > 0 {
+		log.Printf("%d directories not rendered by release branch %s were written by other release branches or by hand, keeping them", skipped, *releaseBranch)
+	}
+
+	var orphans []orphan
+	for _, dir := range owned {
+		path := filepath.Join(*gitopsPath, filepath.FromSlash(dir))
+		changed, err := workdir.LastChange("HEAD", path)
+		if err != nil {
+			fatalf("unable to find the last change of %s: %v", path, err)
+		}
+		age := clk.Now().Sub(changed)
+		if age < *gcMinAge {
+			log.Printf("%s is not rendered by any target but changed %v ago, keeping it", path, age.Round(time.Hour))
+			continue
+		}
+		orphans = append(orphans, orphan{dir: path, age: age})
+	}
+	if len(orphans) == 0 {
+		log.Println("No orphaned manifest directories")
+		return
+	}
+
+	branch := *gcBranch
+	workdir.RecreateBranch(branch, *prInto)
+	var body strings.Builder
+	fmt.Fprintf(&body, "Manifest directories not rendered by any gitops target of release branch %s:\n\n", *releaseBranch)
+	for _, o := range orphans {
+		log.Printf("removing %s unchanged for %v", o.dir, o.age.Round(time.Hour))
+		if err := os.RemoveAll(filepath.Join(workdir.Dir, o.dir)); err != nil {
+			fatalf("unable to remove %s: %v", o.dir, err)
+		}
+		fmt.Fprintf(&body, "- `%s`, unchanged for %d days\n", o.dir, int(o.age.Hours()/24))
+	}
+	msg := fmt.Sprintf("Remove orphaned manifests of release branch %s from %s commit %s\n", *releaseBranch, *branchName, *gitCommit)
+	if !workdir.Commit(msg, *gitopsPath) {
+		log.Println("No orphaned manifest directories")
+		return
+	}
+	summary.UpdatedBranches = []string{branch}
+	if *dryRun {
+		log.Println("dry-run: skipping push and PR creation: branch", branch, "into", *prInto)
+		return
+	}
+	if err := workdir.Push([]string{branch}); err != nil {
+		problems.Error("push", branch, err)
+		return
+	}
+	if err := server.CreatePR(branch, *prInto, "GitOps cleanup of orphaned manifests", body.String()); err != nil {
+		problems.Error("pr", branch, fmt.Errorf("unable to create PR into %s: %w", *prInto, err))
+		return
+	}
+	summary.PullRequests = append(summary.PullRequests, prResult{Branch: branch})
+}
+
+// listManifests returns slash separated paths of manifests below root relative to it
+func listManifests(root string) []string {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !manifests.IsManifest(path) {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		fatalf("unable to list manifests of %s: %v", root, err)
+	}
+	return files
+}
+
+// toolOwnedPaths returns paths inside -gitops_path written by the tool rather than by gitops targets,
+// relative to -gitops_path
+func toolOwnedPaths() []string {
+	owned := []string{filepath.ToSlash(*namespaceDir)}
+	for _, p := range []string{*deploymentsIndex, *provenanceDir} {
+		if p == "" {
+			continue
+		}
+		rel, err := filepath.Rel(*gitopsPath, p)
+		if err != nil || !filepath.IsLocal(rel) {
+			continue
+		}
+		owned = append(owned, filepath.ToSlash(rel))
+	}
+	return owned
+}
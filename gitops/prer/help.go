@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/cli"
+)
+
+// subcommands accepted as the first argument
+var subcommands = []string{"doctor", "gc", "completion"}
+
+// flagGroups orders flags by the phase of the run they affect in -help output
+var flagGroups = []cli.Group{
+	{Title: "General", Flags: []string{"profile", "workspace", "dry_run", "diff_only", "preflight", "summary_json", "sarif_output", "properties_file", "annotation_file", "buildkite_annotate", "run_id"}},
+	{Title: "Discovery", Flags: []string{"release_branch", "target", "bazel_*", "cquery_*", "resolved_*", "write_resolved_manifest", "incremental", "force_all"}},
+	{Title: "Rendering", Flags: []string{"gitops_dependencies_*", "gitops_parallelism", "run_under", "render_*", "gitops_path", "gitops_tmpdir", "gitopsdir*", "ignore_server_fields", "kustomization_index", "deployments_index", "namespace_*", "standard_labels", "resource_label", "resource_annotation", "secret_scan*", "image_allowed_repository", "image_denied_tag", "image_require_digest"}},
+	{Title: "Image pushes", Flags: []string{"push_parallelism", "push_run_under", "verify_platform"}},
+	{Title: "Git", Flags: []string{"git_repo", "git_remote", "git_mirror", "git_cache_dir", "git_quiet", "git_fetch_*", "git_push_remote", "git_user_*", "git_commit", "branch_name", "deploy_branch_prefix", "deployment_branch_suffix", "branch_per_target", "signoff", "stale_base", "provenance*", "source_repo", "image_changelog", "ca_bundle", "client_cert", "client_key", "proxy", "http_*"}},
+	{Title: "Pull requests", Flags: []string{"git_server", "gitops_pr_*", "train_*", "deployment_window*", "freeze_*"}},
+	{Title: "GitHub", Flags: []string{"github_*"}},
+	{Title: "GitLab", Flags: []string{"gitlab_*"}},
+	{Title: "Bitbucket", Flags: []string{"bitbucket_*"}},
+	{Title: "Notifications", Flags: []string{"smtp_*", "email_*", "pagerduty_*", "opsgenie_*", "alert_*"}},
+	{Title: "Deployment records", Flags: []string{"record_*"}},
+	{Title: "Garbage collection", Flags: []string{"gc*"}},
+	{Title: "Direct apply", Flags: []string{"apply_*", "kubectl"}},
+}
+
+func progName() string {
+	return filepath.Base(os.Args[0])
+}
+
+// usage prints flags grouped by flagGroups
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintf(w, "Usage: %s [flags]\n", progName())
+	fmt.Fprintf(w, "       %s doctor [flags]\n", progName())
+	fmt.Fprintf(w, "       %s gc [flags]\n", progName())
+	fmt.Fprintf(w, "       %s completion %s\n\n", progName(), strings.Join(cli.Shells, "|"))
+	fmt.Fprintf(w, "doctor validates the configuration without changing anything, see -preflight.\n")
+	fmt.Fprintf(w, "gc opens a PR removing manifest directories no target renders anymore, see -gc.\n")
+	fmt.Fprintf(w, "completion prints the shell completion script.\n\n")
+	cli.PrintUsage(w, flag.CommandLine, flagGroups)
+}
+
+// printCompletion writes the completion script for shell to stdout
+func printCompletion(shell string) {
+	if err := cli.Completion(os.Stdout, shell, progName(), flag.CommandLine, subcommands); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"log"
+
+	"github.com/fasterci/rules_gitops/gitops/commitmsg"
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+// inputsNotesRef holds inputs hashes of -incremental renders that changed nothing, so no commit recorded them
+const inputsNotesRef = "refs/notes/gitops-inputs"
+
+// fetchInputsNotes fetches inputs hashes recorded by previous runs. Without them the affected trains are rendered again.
+func fetchInputsNotes(workdir *git.Repo) {
+	if err := workdir.FetchRef(inputsNotesRef); err != nil {
+		log.Printf("no inputs hashes in %s: %v", inputsNotesRef, err)
+	}
+}
+
+// lastInputsHash returns the inputs hash of the last render of the checked out branch: the one noted on its head
+// by a render without changes, otherwise the one recorded in lastMsg, the message of the head commit
+func lastInputsHash(workdir *git.Repo, branch, lastMsg string) string {
+	note, err := workdir.Note(inputsNotesRef, "HEAD")
+	if err != nil {
+		problems.Warnf("render", branch, "unable to read inputs hash note: %v", err)
+	}
+	if h := commitmsg.NoteInputsHash(note, branch); h != "" {
+		return h
+	}
+	return commitmsg.ExtractInputsHash(lastMsg)
+}
+
+// noteInputsHash records hash on the head of the checked out branch, returns false if the note was not changed
+func noteInputsHash(workdir *git.Repo, branch, hash string) bool {
+	note, err := workdir.Note(inputsNotesRef, "HEAD")
+	if err != nil {
+		problems.Warnf("render", branch, "unable to read inputs hash note: %v", err)
+		return false
+	}
+	if commitmsg.NoteInputsHash(note, branch) == hash {
+		return false
+	}
+	if err := workdir.SetNote(inputsNotesRef, "HEAD", commitmsg.WithNoteInputsHash(note, branch, hash)); err != nil {
+		problems.Warnf("render", branch, "unable to record inputs hash: %v", err)
+		return false
+	}
+	return true
+}
+
+// pushInputsNotes publishes inputs hashes noted by the run. A failure, like a concurrent update, only causes
+// the trains to be rendered again by the next run.
+func pushInputsNotes(workdir *git.Repo) {
+	if *dryRun {
+		log.Println("dry-run: skipping push of", inputsNotesRef)
+		return
+	}
+	if err := workdir.PushRef(inputsNotesRef); err != nil {
+		problems.Warnf("push", inputsNotesRef, "unable to push inputs hashes, unchanged trains will be rendered again: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/fasterci/rules_gitops/gitops/fsys"
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+)
+
+// indexTrain updates kustomization.yaml of every directory with manifests added, modified or deleted by the train
+func indexTrain(workdir *git.Repo, train string) {
+	changed, err := workdir.ChangedFiles(*gitopsPath)
+	if err != nil {
+		fatalf("unable to list changed files: %v", err)
+	}
+	deleted, err := workdir.DeletedFiles(*gitopsPath)
+	if err != nil {
+		fatalf("unable to list deleted files: %v", err)
+	}
+	dirs := make(map[string]bool)
+	for _, f := range append(changed, deleted...) {
+		if manifests.IsManifest(f) && filepath.Base(f) != manifests.KustomizationFile {
+			dirs[filepath.Dir(f)] = true
+		}
+	}
+	sorted := make([]string, 0, len(dirs))
+	for d := range dirs {
+		sorted = append(sorted, d)
+	}
+	sort.Strings(sorted)
+	for _, d := range sorted {
+		ok, err := manifests.WriteKustomizationFS(fsys.OS(workdir.Dir), filepath.ToSlash(d))
+		if err != nil {
+			fatalf("unable to write kustomization of %s: %v", d, err)
+		}
+		if !ok {
+			problems.Warnf("render", train, "%s has a kustomization not generated by rules_gitops, it is not updated", d)
+		}
+	}
+}
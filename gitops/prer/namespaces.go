@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// parseKeyValues converts repeatable key=value flag values to a map
+func parseKeyValues(name string, values []string) (map[string]string, error) {
+	m := make(map[string]string)
+	for _, v := range values {
+		k, val, ok := strings.Cut(v, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid -%s %q, expected key=value", name, v)
+		}
+		m[k] = val
+	}
+	return m, nil
+}
+
+// bootstrapNamespaces writes a Namespace manifest into outDir for every namespace used by objs
+// and not defined by a Namespace object in objs. Returns paths of written files.
+func bootstrapNamespaces(objs []*unstructured.Unstructured, outDir string) ([]string, error) {
+	labels, err := parseKeyValues("namespace_label", namespaceLabels)
+	if err != nil {
+		return nil, err
+	}
+	annotations, err := parseKeyValues("namespace_annotation", namespaceAnnotations)
+	if err != nil {
+		return nil, err
+	}
+	var written []string
+	for _, ns := range manifests.Namespaces(objs) {
+		b, err := yaml.Marshal(manifests.NewNamespace(ns, labels, annotations).Object)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return nil, err
+		}
+		path := filepath.Join(outDir, ns+".yaml")
+		if err := os.WriteFile(path, b, 0644); err != nil {
+			return nil, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// bootstrapTrainNamespaces adds Namespace manifests for namespaces used by files changed by the train
+func bootstrapTrainNamespaces(workdir string, files []string) error {
+	var objs []*unstructured.Unstructured
+	for _, f := range files {
+		if !manifests.IsManifest(f) {
+			continue
+		}
+		o, err := manifests.DecodeFile(filepath.Join(workdir, f))
+		if err != nil {
+			return fmt.Errorf("unable to parse %s: %w", f, err)
+		}
+		objs = append(objs, o...)
+	}
+	_, err := bootstrapNamespaces(objs, filepath.Join(workdir, *gitopsPath, *namespaceDir))
+	return err
+}
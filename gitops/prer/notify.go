@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/notify"
+	"github.com/fasterci/rules_gitops/gitops/report"
+)
+
+// summaryText formats the run summary for people reading notifications
+func summaryText(s *runSummary) (subject, body string) {
+	var trains []string
+	for train := range s.Trains {
+		trains = append(trains, train)
+	}
+	sort.Strings(trains)
+
+	subject = fmt.Sprintf("GitOps deployment %s: %d PR(s)", s.ReleaseBranch, len(s.PullRequests))
+	if problems.HasErrors() {
+		subject += ", failed"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Release branch: %s\n", s.ReleaseBranch)
+	fmt.Fprintf(&sb, "Source: %s commit %s\n", *branchName, *gitCommit)
+	fmt.Fprintf(&sb, "Release trains: %s\n", strings.Join(trains, ", "))
+	if len(s.PullRequests) > 0 {
+		sb.WriteString("\nPull requests:\n")
+		for _, pr := range s.PullRequests {
+			link := pr.URL
+			if link == "" {
+				link = pr.Branch + " into " + *prInto
+			}
+			fmt.Fprintf(&sb, "  %s: %s\n", pr.Train, link)
+		}
+	}
+	if len(problems.Entries()) > 0 {
+		sb.WriteString("\n")
+		problems.Print(&sb)
+	}
+	fmt.Fprintf(&sb, "\n%s %s\n", progName(), s.Tool)
+	return subject, sb.String()
+}
+
+// sendNotifications sends the run summary to configured recipients if the run opened PRs or reported problems
+func sendNotifications(s *runSummary) {
+	if len(emailTo) == 0 || *dryRun {
+		return
+	}
+	if len(s.PullRequests) == 0 && len(problems.Entries()) == 0 {
+		return
+	}
+	subject, body := summaryText(s)
+	mail := notify.SMTP{
+		Addr:     *smtpServer,
+		User:     *smtpUser,
+		Password: *smtpPassword,
+		From:     *emailFrom,
+		To:       emailTo,
+	}
+	if err := mail.Send(subject, body); err != nil {
+		problems.Warnf("notify", strings.Join(emailTo, ", "), "unable to send email: %v", err)
+		return
+	}
+	log.Println("sent run summary to", strings.Join(emailTo, ", "))
+}
+
+// alertTrain returns the release train subject of a report entry refers to: a train or its deployment branch.
+// Returns empty string for other subjects, like remotes.
+func alertTrain(s *runSummary, subject string) string {
+	if _, ok := s.Trains[subject]; ok {
+		return subject
+	}
+	for train := range s.Trains {
+		if isTrainBranch(subject, train) {
+			return train
+		}
+	}
+	return ""
+}
+
+// sendAlerts opens an incident per release train with errors in -alert_phase phases
+func sendAlerts(s *runSummary) {
+	var alerters []notify.Alerter
+	if *pagerdutyRoutingKey != "" {
+		alerters = append(alerters, notify.PagerDuty{RoutingKey: *pagerdutyRoutingKey})
+	}
+	if *opsgenieAPIKey != "" {
+		alerters = append(alerters, notify.Opsgenie{APIKey: *opsgenieAPIKey, URL: *opsgenieURL})
+	}
+	if len(alerters) == 0 || *dryRun {
+		return
+	}
+	phases := alertPhases
+	if len(phases) == 0 {
+		phases = []string{"fatal", "push", "pr"}
+	}
+	alerted := make(map[string]bool)
+	for _, p := range phases {
+		alerted[p] = true
+	}
+	// errors grouped by train, errors not related to a train are grouped under the empty name
+	byTrain := make(map[string]map[string]string)
+	for _, e := range problems.Entries() {
+		if e.Severity != report.Error || !alerted[e.Phase] {
+			continue
+		}
+		train := alertTrain(s, e.Subject)
+		if byTrain[train] == nil {
+			byTrain[train] = make(map[string]string)
+		}
+		key := e.Phase
+		if e.Subject != "" {
+			key += " " + e.Subject
+		}
+		byTrain[train][key] = e.Message
+	}
+	for train, details := range byTrain {
+		a := notify.Alert{
+			DedupKey: fmt.Sprintf("rules_gitops:%s:%s", *repo, s.ReleaseBranch),
+			Summary:  fmt.Sprintf("GitOps deployment of %s failed", s.ReleaseBranch),
+			Source:   progName(),
+			Details:  details,
+		}
+		if train != "" {
+			a.DedupKey += ":" + train
+			a.Summary += " for release train " + train
+		}
+		for _, alerter := range alerters {
+			if err := alerter.Alert(a); err != nil {
+				problems.Warnf("notify", a.DedupKey, "unable to send alert: %v", err)
+				continue
+			}
+			log.Println("sent alert", a.DedupKey)
+		}
+	}
+}
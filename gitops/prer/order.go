@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/trains"
+)
+
+// dependencyPollInterval is the delay between checks of dependency PRs with -train_wait_merged
+const dependencyPollInterval = 30 * time.Second
+
+// orderTrains returns release trains ordered by -train_dependency
+func orderTrains(releaseTrains map[string][]string) []string {
+	deps, err := trains.ParseDependencies(trainDependencies)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	var names []string
+	for train := range releaseTrains {
+		names = append(names, train)
+	}
+	order, err := trains.Order(names, deps)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	return order
+}
+
+// waitForDependencies waits up to -train_wait_merged for open PRs of the trains train depends on to be merged or closed.
+// It returns false and reports an error for branch if they are still open.
+func waitForDependencies(server git.Reconciler, train, branch string) bool {
+	if *trainWaitMerged <= 0 {
+		return true
+	}
+	deps, _ := trains.ParseDependencies(trainDependencies)
+	if len(deps[train]) == 0 {
+		return true
+	}
+	deadline := clk.Now().Add(*trainWaitMerged)
+	for {
+		open, err := server.OpenPRs(*prInto)
+		if err != nil {
+			problems.Error("pr", branch, fmt.Errorf("unable to check PRs of release trains %v: %w", deps[train], err))
+			return false
+		}
+		var pending []string
+		for _, pr := range open {
+			for _, dep := range deps[train] {
+				if isTrainBranch(pr.Source, dep) {
+					pending = append(pending, fmt.Sprintf("%s (%s)", dep, pr.URL))
+				}
+			}
+		}
+		if len(pending) == 0 {
+			return true
+		}
+		if clk.Now().After(deadline) {
+			problems.Error("pr", branch, fmt.Errorf("PRs of release trains %v are still open after %v, PR is not created", pending, *trainWaitMerged))
+			return false
+		}
+		log.Printf("train %s waits for PRs of %v to be merged", train, pending)
+		clk.Sleep(dependencyPollInterval)
+	}
+}
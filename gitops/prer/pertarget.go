@@ -0,0 +1,25 @@
+package main
+
+import "github.com/fasterci/rules_gitops/gitops/trains"
+
+// deploymentBranches names deployment branches using -deploy_branch_prefix, -deployment_branch_suffix
+// and -branch_per_target
+func deploymentBranches() trains.Branches {
+	return trains.Branches{Prefix: *deployBranchPrefix, Suffix: *deploymentBranchSuffix, PerTarget: *branchPerTarget}
+}
+
+// deployUnits returns deployment branches of release trains in order: one per train,
+// or one per gitops target named <prefix><train>--<target path><suffix> with -branch_per_target
+func deployUnits(trainOrder []string, releaseTrains map[string][]string) []trains.Unit {
+	units, err := deploymentBranches().Units(trainOrder, releaseTrains)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	return units
+}
+
+// isTrainBranch reports whether branch is the deployment branch of train or, with -branch_per_target,
+// one of the per-target branches of the train
+func isTrainBranch(branch, train string) bool {
+	return deploymentBranches().IsTrainBranch(branch, train)
+}
@@ -0,0 +1,153 @@
+package prer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/bazel"
+)
+
+// ErrEmptyPlan is returned by Apply for a plan without release trains
+var ErrEmptyPlan = errors.New("plan has no release trains")
+
+// ApplyOptions configure Apply
+type ApplyOptions struct {
+	// Bazel builds the planned targets. bazel.FindDefault of Dir is used if nil
+	Bazel *bazel.Command
+	// Dir is the bazel workspace the plan was made in. Default is the current directory
+	Dir string
+	// Binary is the create_gitops_prs executable
+	Binary string
+	// Args are additional create_gitops_prs flags, like -git_repo and -git_server
+	Args []string
+	// Stdout and Stderr receive the output of bazel and create_gitops_prs. Default is to discard it
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// manifest is the create_gitops_prs -resolved_manifest format
+type manifest struct {
+	Trains map[string]manifestTrain `json:"trains"`
+}
+
+type manifestTrain struct {
+	Targets  []string `json:"targets"`
+	Binaries []string `json:"binaries"`
+	Pushes   []string `json:"pushes,omitempty"`
+}
+
+// Apply builds the planned gitops and push targets and runs create_gitops_prs for exactly the planned release trains,
+// so a plan that was reviewed is the one that gets deployed.
+func Apply(ctx context.Context, plan *ReleasePlan, opts ApplyOptions) error {
+	if len(plan.Trains) == 0 {
+		return ErrEmptyPlan
+	}
+	if opts.Binary == "" {
+		return errors.New("create_gitops_prs binary is not set")
+	}
+	dir := opts.Dir
+	if dir == "" {
+		dir = "."
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if opts.Bazel == nil {
+		bin, _ := bazel.FindDefault(dir)
+		opts.Bazel = &bazel.Command{Bin: bin}
+	}
+
+	var targets []string
+	for _, t := range plan.Trains {
+		targets = append(targets, t.Targets...)
+	}
+	build := opts.Bazel.CmdContext(ctx, "build", append(targets, plan.Pushes()...)...)
+	build.Dir = dir
+	build.Stdout, build.Stderr = opts.Stdout, opts.Stderr
+	if err := build.Run(); err != nil {
+		return fmt.Errorf("unable to build planned targets: %w", err)
+	}
+
+	tmp, err := os.MkdirTemp("", "prer")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "manifest.json")
+	if err := writeManifest(path, dir, plan); err != nil {
+		return err
+	}
+	args := []string{
+		"-resolved_manifest", path,
+		"-release_branch", plan.ReleaseBranch,
+		"-gitops_pr_into", plan.PRInto,
+		"-deploy_branch_prefix", plan.BranchPrefix,
+		"-deployment_branch_suffix", plan.BranchSuffix,
+	}
+	if plan.BranchPerTarget {
+		args = append(args, "-branch_per_target")
+	}
+	for _, train := range sortedKeys(plan.Dependencies) {
+		args = append(args, "-train_dependency", train+"="+strings.Join(plan.Dependencies[train], ","))
+	}
+	args = append(args, opts.Args...)
+	cmd := exec.CommandContext(ctx, opts.Binary, args...)
+	cmd.Dir = dir
+	cmd.Stdout, cmd.Stderr = opts.Stdout, opts.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("create_gitops_prs failed: %w", err)
+	}
+	return nil
+}
+
+// writeManifest writes the plan as a resolved manifest with binaries built in the workspace dir.
+// Target labels are written too, so commit messages do not depend on the workspace location.
+func writeManifest(path, dir string, plan *ReleasePlan) error {
+	m := manifest{Trains: make(map[string]manifestTrain)}
+	for _, t := range plan.Trains {
+		// per-target entries of a train are merged
+		mt := m.Trains[t.Name]
+		mt.Targets = append(mt.Targets, t.Targets...)
+		for _, target := range t.Targets {
+			mt.Binaries = append(mt.Binaries, filepath.Join(dir, bazel.TargetToExecutable(target)))
+		}
+		for _, target := range t.Pushes {
+			if p := filepath.Join(dir, bazel.TargetToExecutable(target)); !contains(mt.Pushes, p) {
+				mt.Pushes = append(mt.Pushes, p)
+			}
+		}
+		m.Trains[t.Name] = mt
+	}
+	b, err := json.MarshalIndent(&m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(deps map[string][]string) []string {
+	keys := make([]string, 0, len(deps))
+	for k := range deps {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
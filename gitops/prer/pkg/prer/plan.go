@@ -0,0 +1,190 @@
+// Package prer plans gitops deployments the way create_gitops_prs does without changing anything,
+// and applies a plan by running create_gitops_prs with the planned release trains.
+package prer
+
+import (
+	"context"
+	"sort"
+
+	"github.com/fasterci/rules_gitops/gitops/bazel"
+	"github.com/fasterci/rules_gitops/gitops/discovery"
+	"github.com/fasterci/rules_gitops/gitops/trains"
+)
+
+// Options configure Plan. Zero values use the create_gitops_prs flag defaults.
+type Options struct {
+	// Bazel runs cquery. bazel.FindDefault of Dir is used if nil
+	Bazel *bazel.Command
+	// Dir is the bazel workspace. Default is the current directory
+	Dir string
+	// ReleaseBranch filters gitops targets by release branch, like -release_branch
+	ReleaseBranch string
+	// Targets are target patterns to scan, like -target. Patterns starting with - are excluded
+	Targets []string
+	// Streamed, Starlark and StarlarkExpr select how cquery results are read,
+	// like -cquery_streamed, -cquery_starlark and -cquery_starlark_expr
+	Streamed     bool
+	Starlark     bool
+	StarlarkExpr string
+	// PushKinds, PushNames and PushAttrs select image push dependencies, like -gitops_dependencies_kind,
+	// -gitops_dependencies_name and -gitops_dependencies_attr
+	PushKinds []string
+	PushNames []string
+	PushAttrs []string
+	// BranchPrefix and BranchSuffix surround release train names in deployment branch names,
+	// like -deploy_branch_prefix and -deployment_branch_suffix
+	BranchPrefix string
+	BranchSuffix string
+	// BranchPerTarget plans a deployment branch for every gitops target, like -branch_per_target
+	BranchPerTarget bool
+	// PRInto is the branch deployment PRs are opened into, like -gitops_pr_into
+	PRInto string
+	// Dependencies order release trains, like -train_dependency
+	Dependencies trains.Dependencies
+}
+
+func (o *Options) setDefaults() {
+	if o.Bazel == nil {
+		dir := o.Dir
+		if dir == "" {
+			dir = "."
+		}
+		bin, _ := bazel.FindDefault(dir)
+		o.Bazel = &bazel.Command{Bin: bin}
+	}
+	if o.ReleaseBranch == "" {
+		o.ReleaseBranch = "master"
+	}
+	if o.StarlarkExpr == "" {
+		o.StarlarkExpr = discovery.DefaultStarlarkExpr
+	}
+	if len(o.PushKinds) == 0 {
+		o.PushKinds = []string{"k8s_container_push", "push_oci", "push_helm_chart"}
+	}
+	if o.BranchPrefix == "" {
+		o.BranchPrefix = "deploy/"
+	}
+	if o.PRInto == "" {
+		o.PRInto = "master"
+	}
+}
+
+// ReleasePlan is what a create_gitops_prs run with the same options would deploy
+type ReleasePlan struct {
+	ReleaseBranch string `json:"release_branch"`
+	PRInto        string `json:"pr_into"`
+	BranchPrefix  string `json:"branch_prefix"`
+	BranchSuffix  string `json:"branch_suffix,omitempty"`
+	// BranchPerTarget is set if every gitops target has its own deployment branch
+	BranchPerTarget bool `json:"branch_per_target,omitempty"`
+	// Dependencies are the release train dependencies the trains are ordered by
+	Dependencies trains.Dependencies `json:"dependencies,omitempty"`
+	// Trains are in the order they are rendered and their PRs opened
+	Trains []Train `json:"trains"`
+	// Warnings are problems found during discovery that did not stop planning
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Train is a planned release train, or a gitops target of the train with a per-target deployment branch
+type Train struct {
+	Name string `json:"name"`
+	// Target is the gitops target of a per-target deployment branch
+	Target string `json:"target,omitempty"`
+	// Targets are gitops targets rendering the train manifests
+	Targets []string `json:"targets"`
+	// Branch is the deployment branch the targets are committed to
+	Branch string `json:"branch"`
+	// Pushes are image push targets the train depends on
+	Pushes []string `json:"pushes,omitempty"`
+}
+
+// Branches returns deployment branches of the plan
+func (p *ReleasePlan) Branches() []string {
+	var branches []string
+	for _, t := range p.Trains {
+		branches = append(branches, t.Branch)
+	}
+	return branches
+}
+
+// Pushes returns unique image push targets of the plan
+func (p *ReleasePlan) Pushes() []string {
+	seen := make(map[string]bool)
+	var pushes []string
+	for _, t := range p.Trains {
+		for _, push := range t.Pushes {
+			if !seen[push] {
+				seen[push] = true
+				pushes = append(pushes, push)
+			}
+		}
+	}
+	return pushes
+}
+
+// Plan discovers gitops targets of the release branch, groups them into release trains and resolves the image pushes
+// every train depends on. Discovery is the one create_gitops_prs runs. It only runs bazel cquery: nothing is built,
+// rendered, pushed or cloned.
+func Plan(ctx context.Context, opts Options) (*ReleasePlan, error) {
+	opts.setDefaults()
+	plan := &ReleasePlan{
+		ReleaseBranch:   opts.ReleaseBranch,
+		PRInto:          opts.PRInto,
+		BranchPrefix:    opts.BranchPrefix,
+		BranchSuffix:    opts.BranchSuffix,
+		BranchPerTarget: opts.BranchPerTarget,
+		Dependencies:    opts.Dependencies,
+	}
+	d := &discovery.Options{
+		Bazel:        opts.Bazel,
+		Dir:          opts.Dir,
+		Streamed:     opts.Streamed,
+		Starlark:     opts.Starlark,
+		StarlarkExpr: opts.StarlarkExpr,
+	}
+	releaseTrains, warnings, err := d.Trains(ctx, opts.ReleaseBranch, opts.Targets)
+	plan.addWarnings(warnings)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(releaseTrains))
+	for train, targets := range releaseTrains {
+		names = append(names, train)
+		sort.Strings(targets)
+	}
+	order, err := trains.Order(names, opts.Dependencies)
+	if err != nil {
+		return nil, err
+	}
+	branches := trains.Branches{Prefix: opts.BranchPrefix, Suffix: opts.BranchSuffix, PerTarget: opts.BranchPerTarget}
+	units, err := branches.Units(order, releaseTrains)
+	if err != nil {
+		return nil, err
+	}
+	pushQuery := discovery.PushQuery{Kinds: opts.PushKinds, Names: opts.PushNames, Attrs: opts.PushAttrs}
+	for _, unit := range units {
+		pushes, warnings, err := d.Cquery(ctx, pushQuery.Query(unit.Targets))
+		plan.addWarnings(warnings)
+		if err != nil {
+			return nil, err
+		}
+		t := Train{
+			Name:    unit.Train,
+			Target:  unit.Target,
+			Targets: unit.Targets,
+			Branch:  unit.Branch,
+		}
+		for _, p := range pushes {
+			t.Pushes = append(t.Pushes, p.Name)
+		}
+		sort.Strings(t.Pushes)
+		plan.Trains = append(plan.Trains, t)
+	}
+	return plan, nil
+}
+
+func (p *ReleasePlan) addWarnings(warnings []discovery.Warning) {
+	for _, w := range warnings {
+		p.Warnings = append(p.Warnings, w.String())
+	}
+}
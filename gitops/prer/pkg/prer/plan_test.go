@@ -0,0 +1,162 @@
+package prer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fasterci/rules_gitops/gitops/analysis"
+	"github.com/fasterci/rules_gitops/gitops/bazel"
+	"github.com/fasterci/rules_gitops/gitops/blaze_query"
+	"github.com/fasterci/rules_gitops/gitops/trains"
+	"google.golang.org/protobuf/proto"
+)
+
+func cqueryResult(t *testing.T, path string, rules map[string]string) {
+	t.Helper()
+	qr := &analysis.CqueryResult{}
+	for name, train := range rules {
+		rule := &blaze_query.Rule{Name: proto.String(name), RuleClass: proto.String("gitops")}
+		if train != "" {
+			rule.Attribute = []*blaze_query.Attribute{
+				{Name: proto.String("deployment_branch"), Type: blaze_query.Attribute_STRING.Enum(), StringValue: proto.String(train)},
+			}
+		}
+		qr.Results = append(qr.Results, &analysis.ConfiguredTarget{
+			Target: &blaze_query.Target{Type: blaze_query.Target_RULE.Enum(), Rule: rule},
+		})
+	}
+	b, err := proto.Marshal(qr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fakeBazel answers the discovery cquery with discovery.pb, push queries of a train with pushes-<target>.pb
+// and logs build commands to build.log
+func fakeBazel(t *testing.T, dir string) *bazel.Command {
+	t.Helper()
+	script := `#!/bin/sh
+case "$1" in
+build) echo "$@" >> "` + dir + `/build.log" ;;
+cquery)
+  case "$2" in
+  *"kind(gitops"*) cat "` + dir + `/discovery.pb" ;;
+  *app/dev*) cat "` + dir + `/pushes-dev.pb" ;;
+  *) cat "` + dir + `/pushes-prod.pb" ;;
+  esac ;;
+*) exit 2 ;;
+esac
+`
+	bin := filepath.Join(dir, "bazel")
+	if err := os.WriteFile(bin, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return &bazel.Command{Bin: bin}
+}
+
+func TestPlanAndApply(t *testing.T) {
+	dir := t.TempDir()
+	cqueryResult(t, filepath.Join(dir, "discovery.pb"), map[string]string{
+		"//app/prod:b.gitops":  "prod",
+		"//app/prod:a.gitops":  "prod",
+		"//app/dev:dev.gitops": "dev",
+		"//app:broken.gitops":  "",
+	})
+	cqueryResult(t, filepath.Join(dir, "pushes-dev.pb"), map[string]string{"//app:image.push": ""})
+	cqueryResult(t, filepath.Join(dir, "pushes-prod.pb"), map[string]string{"//app:image.push": "", "//app:sidecar.push": ""})
+	bzl := fakeBazel(t, dir)
+
+	plan, err := Plan(context.Background(), Options{
+		Bazel:        bzl,
+		Dir:          dir,
+		Dependencies: trains.Dependencies{"dev": {"prod"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Train{
+		{Name: "prod", Targets: []string{"//app/prod:a.gitops", "//app/prod:b.gitops"}, Branch: "deploy/prod", Pushes: []string{"//app:image.push", "//app:sidecar.push"}},
+		{Name: "dev", Targets: []string{"//app/dev:dev.gitops"}, Branch: "deploy/dev", Pushes: []string{"//app:image.push"}},
+	}
+	if !reflect.DeepEqual(plan.Trains, expected) {
+		t.Errorf("unexpected trains %+v", plan.Trains)
+	}
+	if len(plan.Warnings) != 1 || !strings.Contains(plan.Warnings[0], "//app:broken.gitops") {
+		t.Errorf("unexpected warnings %v", plan.Warnings)
+	}
+	if b := plan.Branches(); !reflect.DeepEqual(b, []string{"deploy/prod", "deploy/dev"}) {
+		t.Errorf("unexpected branches %v", b)
+	}
+	if p := plan.Pushes(); !reflect.DeepEqual(p, []string{"//app:image.push", "//app:sidecar.push"}) {
+		t.Errorf("unexpected pushes %v", p)
+	}
+
+	// the fake create_gitops_prs records its arguments and the manifest it was given
+	bin := filepath.Join(dir, "create_gitops_prs")
+	script := "#!/bin/sh\necho \"$@\" > " + dir + "/args\ncat \"$2\" > " + dir + "/manifest.json\n"
+	if err := os.WriteFile(bin, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(context.Background(), plan, ApplyOptions{Bazel: bzl, Dir: dir, Binary: bin, Args: []string{"-dry_run"}}); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := os.ReadFile(filepath.Join(dir, "build.log"))
+	if s := strings.TrimSpace(string(b)); s != "build //app/prod:a.gitops //app/prod:b.gitops //app/dev:dev.gitops //app:image.push //app:sidecar.push" {
+		t.Errorf("unexpected build %q", s)
+	}
+	b, _ = os.ReadFile(filepath.Join(dir, "args"))
+	if s := strings.TrimSpace(string(b)); !strings.HasSuffix(s, "-deploy_branch_prefix deploy/ -deployment_branch_suffix  -train_dependency dev=prod -dry_run") {
+		t.Errorf("unexpected args %q", s)
+	}
+	var m manifest
+	b, _ = os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if dev := m.Trains["dev"]; len(dev.Binaries) != 1 || dev.Binaries[0] != filepath.Join(dir, bazel.TargetToExecutable("//app/dev:dev.gitops")) {
+		t.Errorf("unexpected manifest %s", b)
+	}
+	// commit messages refer to labels, not to the workspace location
+	if dev := m.Trains["dev"]; !reflect.DeepEqual(dev.Targets, []string{"//app/dev:dev.gitops"}) {
+		t.Errorf("unexpected manifest targets %s", b)
+	}
+
+	// per-target branches are planned the way create_gitops_prs names them
+	plan, err = Plan(context.Background(), Options{Bazel: bzl, Dir: dir, BranchPerTarget: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := plan.Branches(); !reflect.DeepEqual(b, []string{"deploy/dev--app/dev/dev", "deploy/prod--app/prod/a", "deploy/prod--app/prod/b"}) {
+		t.Errorf("unexpected per-target branches %v", b)
+	}
+	if tr := plan.Trains[1]; tr.Name != "prod" || tr.Target != "//app/prod:a.gitops" || !reflect.DeepEqual(tr.Targets, []string{"//app/prod:a.gitops"}) {
+		t.Errorf("unexpected per-target train %+v", tr)
+	}
+	if err := Apply(context.Background(), plan, ApplyOptions{Bazel: bzl, Dir: dir, Binary: bin}); err != nil {
+		t.Fatal(err)
+	}
+	b, _ = os.ReadFile(filepath.Join(dir, "args"))
+	if s := strings.TrimSpace(string(b)); !strings.HasSuffix(s, "-deployment_branch_suffix  -branch_per_target") {
+		t.Errorf("unexpected per-target args %q", s)
+	}
+	m = manifest{}
+	b, _ = os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if prod := m.Trains["prod"]; len(prod.Targets) != 2 || len(prod.Binaries) != 2 || len(prod.Pushes) != 2 {
+		t.Errorf("expected per-target entries of prod merged in the manifest, got %s", b)
+	}
+
+	if err := Apply(context.Background(), &ReleasePlan{}, ApplyOptions{Binary: bin}); err != ErrEmptyPlan {
+		t.Errorf("expected ErrEmptyPlan, got %v", err)
+	}
+}
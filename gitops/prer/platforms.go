@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+	"github.com/fasterci/rules_gitops/gitops/platforms"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// newPlatformVerifier creates a verifier from -verify_platform flags. Returns nil if verification is disabled.
+func newPlatformVerifier() *platforms.Verifier {
+	if len(verifyPlatforms) == 0 {
+		return nil
+	}
+	ps, err := platforms.Parse(verifyPlatforms)
+	if err != nil {
+		fatalf("invalid -verify_platform: %v", err)
+	}
+	return &platforms.Verifier{
+		Platforms: ps,
+		// registries are reached with the default transport, -ca_bundle, -client_cert and -proxy are for git servers
+		Options: []remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain)},
+	}
+}
+
+// trainImages returns images used in files changed by the train
+func trainImages(workdir *git.Repo) []string {
+	files, err := workdir.ChangedFiles(*gitopsPath)
+	if err != nil {
+		fatalf("unable to list changed files: %v", err)
+	}
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, filepath.Join(workdir.Dir, f))
+	}
+	images, err := manifests.ImagesInFiles(paths)
+	if err != nil {
+		fatalf("unable to read images of changed files: %v", err)
+	}
+	return images
+}
+
+// verifyImagePlatforms checks pushed images of every branch are available for all expected platforms.
+// Returns branches whose images passed verification, the rest are not pushed.
+func verifyImagePlatforms(v *platforms.Verifier, branches []string, images map[string][]string) []string {
+	results := make(map[string]error)
+	var verified []string
+	for _, branch := range branches {
+		ok := true
+		for _, image := range images[branch] {
+			err, done := results[image]
+			if !done {
+				log.Println("verifying platforms of", image)
+				err = v.Verify(image)
+				results[image] = err
+			}
+			if err != nil {
+				problems.Error("platforms", branch, fmt.Errorf("image %s: %w", image, err))
+				ok = false
+			}
+		}
+		if ok {
+			verified = append(verified, branch)
+		}
+	}
+	return verified
+}
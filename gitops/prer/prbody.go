@@ -0,0 +1,41 @@
+package main
+
+import (
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/prbody"
+)
+
+// withDetails appends image changes and a tree of files changed on branch, linked to the diff view of the git server,
+// to the PR body
+func withDetails(workdir *git.Repo, branch, body string, diffURL func(from, to, path string) string) string {
+	base := *gitRemote + "/" + *prInto
+	changes, err := workdir.BranchChanges(base, branch, *gitopsPath)
+	if err != nil {
+		problems.Warnf("pr", branch, "unable to list changed files for the PR body: %v", err)
+		return body
+	}
+	if *imageChangelogEnabled {
+		var paths []string
+		for _, c := range changes {
+			paths = append(paths, c.Path)
+			if c.OldPath != "" {
+				paths = append(paths, c.OldPath)
+			}
+		}
+		images, err := imageChangelog(workdir, base, branch, paths)
+		if err != nil {
+			problems.Warnf("pr", branch, "unable to compare images for the PR body: %v", err)
+		} else if len(images) > 0 {
+			body += "\n\n**Image changes**\n\n" + formatChangelog(images, "- ")
+		}
+	}
+	if *prBodyFiles {
+		tree := prbody.FileTree(changes, func(path string) string {
+			return diffURL(branch, *prInto, path)
+		}, *prBodyMaxFiles)
+		if tree != "" {
+			body += "\n\n**Changed files**\n\n" + tree
+		}
+	}
+	return body
+}
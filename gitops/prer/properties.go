@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var nonPropertyChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// propertyValue escapes v for a Java properties file
+func propertyValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`).Replace(v)
+}
+
+// writeProperties writes created PR links and the outcome of the run to -properties_file as Java properties,
+// so downstream Jenkins steps can read them with readProperties
+func writeProperties(s *runSummary) error {
+	var urls, branches []string
+	props := map[string]string{}
+	for _, pr := range s.PullRequests {
+		urls = append(urls, pr.URL)
+		branches = append(branches, pr.Branch)
+		key := "GITOPS_PR_URL_" + nonPropertyChars.ReplaceAllString(strings.ToUpper(pr.Train), "_")
+		props[key] = pr.URL
+	}
+	props["GITOPS_PR_COUNT"] = fmt.Sprint(len(s.PullRequests))
+	props["GITOPS_PR_URLS"] = strings.Join(urls, ",")
+	props["GITOPS_PR_BRANCHES"] = strings.Join(branches, ",")
+	props["GITOPS_UPDATED_BRANCHES"] = strings.Join(s.UpdatedBranches, ",")
+	props["GITOPS_RELEASE_BRANCH"] = s.ReleaseBranch
+	props["GITOPS_FAILED"] = fmt.Sprint(problems.HasErrors())
+
+	var keys []string
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "%s=%s\n", k, propertyValue(props[k]))
+	}
+	return os.WriteFile(*propertiesFile, []byte(sb.String()), 0644)
+}
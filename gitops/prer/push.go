@@ -0,0 +1,64 @@
+package main
+
+import (
+	"log"
+	"os"
+	"sync"
+
+	"github.com/fasterci/rules_gitops/gitops/bazel"
+	"golang.org/x/sync/errgroup"
+)
+
+// pushImages pushes images used by gitops targets of the trains.
+// Push binaries are taken from the resolved manifest or -resolved_push if provided, otherwise discovered with bazel.
+// Binaries are executed in pushSandbox.
+func pushImages(manifest *resolvedManifest, trains, targets []string) {
+	if manifest != nil {
+		resolvedPushes = manifest.pushes(trains)
+	}
+	if len(resolvedPushes) > 0 || manifest != nil {
+		var eg errgroup.Group
+		eg.SetLimit(*pushParallelism)
+		for _, rp := range resolvedPushes {
+			cmd := rp
+			eg.Go(func() error {
+				mustPush(cmd)
+				return nil
+			})
+		}
+		eg.Wait()
+		return
+	}
+
+	pushTargets := bazelQuery(pushDepsQuery(targets))
+	targetsCh := make(chan string)
+	var wg sync.WaitGroup
+	wg.Add(*pushParallelism)
+	for i := 0; i < *pushParallelism; i++ {
+		go func() {
+			defer wg.Done()
+			for target := range targetsCh {
+				bin := bazel.TargetToExecutable(target)
+				fi, err := os.Stat(bin)
+				if err == nil && fi.Mode().IsRegular() {
+					mustPush(bin)
+				} else {
+					log.Println("target", target, "is not a file, running as a command")
+					problems.Warnf("push", target, "%s is not a file, running as a command", bin)
+					args := []string{target}
+					if *pushRunUnder != "" {
+						args = append([]string{"--run_under=" + *pushRunUnder}, args...)
+					}
+					if _, err := runner.Run("", nil, *bazelCmd, bazelc.Args("run", args...)...); err != nil {
+						fatalf("ERROR: %s", err)
+					}
+				}
+			}
+		}()
+	}
+	for _, t := range pushTargets {
+		targetsCh <- t.Name
+	}
+	close(targetsCh)
+	wg.Wait()
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/bazel"
+	"github.com/fasterci/rules_gitops/gitops/discovery"
+)
+
+// logBazelVersion logs the version of the bazel command and reports a mismatch with .bazelversion of the workspace
+func logBazelVersion() {
+	v, err := bazelc.Version()
+	if err != nil {
+		problems.Warnf("discovery", *bazelCmd, "unable to get bazel version: %v", err)
+		return
+	}
+	log.Printf("bazel command %s version: %s", *bazelCmd, v)
+	// only exact versions are compared, .bazelversion can also contain values like 7.x or latest
+	pinned := bazel.PinnedVersion(".")
+	if pinned != "" && !strings.ContainsAny(pinned, "x*") && !strings.HasPrefix(pinned, "latest") && !strings.HasSuffix(v, " "+pinned) {
+		problems.Warnf("discovery", *bazelCmd, "%s does not match .bazelversion %s, use bazelisk to honor it", v, pinned)
+	}
+}
+
+// discoveryOptions runs cquery with -bazel_cmd according to -cquery_streamed, -cquery_starlark and -cquery_starlark_expr
+func discoveryOptions() *discovery.Options {
+	return &discovery.Options{
+		Bazel:        bazelc,
+		Streamed:     *cqueryStreamed,
+		Starlark:     *cqueryStarlark,
+		StarlarkExpr: *cqueryStarlarkExpr,
+		Stderr:       os.Stderr,
+	}
+}
+
+// discoveryQuery returns the cquery expression matching gitops targets of -release_branch in -target
+func discoveryQuery() string {
+	return discovery.Query(*releaseBranch, targetPatterns)
+}
+
+// reportDiscoveryWarnings adds warnings of discovery queries to the problem report
+func reportDiscoveryWarnings(warnings []discovery.Warning) {
+	for _, w := range warnings {
+		problems.Warnf("discovery", w.Target, "%s", w.Message)
+	}
+}
+
+// discoverTrains returns gitops targets of -release_branch in -target grouped into release trains
+func discoverTrains() map[string][]string {
+	releaseTrains, warnings, err := discoveryOptions().Trains(context.Background(), *releaseBranch, targetPatterns)
+	reportDiscoveryWarnings(warnings)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	return releaseTrains
+}
+
+// bazelQuery executes cquery and returns matching targets with the string values of attrs
+func bazelQuery(query string, attrs ...string) []discovery.Target {
+	targets, warnings, err := discoveryOptions().Cquery(context.Background(), query, attrs...)
+	reportDiscoveryWarnings(warnings)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	return targets
+}
+
+// pushDepsQuery returns a query for push targets the gitops targets depend on
+func pushDepsQuery(targets []string) string {
+	return discovery.PushQuery{Kinds: gitopsKind, Names: gitopsRuleName, Attrs: gitopsRuleAttr}.Query(targets)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/prbody"
+)
+
+// newRunID returns a random identifier of the run used when -run_id is not set
+func newRunID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		fatalf("unable to generate run id: %v", err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// pipelineMarker returns the PR body marker of the deployment branch of train, or of its target with -branch_per_target
+func pipelineMarker(train, target string) prbody.Marker {
+	return prbody.Marker{
+		Train:         train,
+		Target:        target,
+		ReleaseBranch: *releaseBranch,
+		BranchPrefix:  *deployBranchPrefix,
+		BranchSuffix:  *deploymentBranchSuffix,
+		SourceCommit:  *gitCommit,
+		RunID:         *runID,
+		ToolVersion:   currentBuild().String(),
+	}
+}
+
+// prReconciler matches deployment PRs created by previous runs to release trains using markers in PR bodies
+type prReconciler struct {
+	server git.Reconciler
+	// batch closes superseded PRs together on flush if set
+	batch git.BatchServer
+	// open PRs into -gitops_pr_into, listed on first use
+	open   []git.PullRequest
+	listed bool
+	// superseded PRs and their comments queued for batch
+	closing  []git.PullRequest
+	comments []string
+	// closed are numbers of PRs closed or queued to be closed
+	closed map[int]bool
+}
+
+// reconcile closes open PRs of train opened from a branch other than branch by the same pipeline, with the same
+// release branch and deployment branch prefix and suffix, like the release train branch after switching to
+// -branch_per_target. With a per-target branch of target, PRs of other targets of the train are kept
+// and PRs of the release train branch are closed, and the other way around.
+// It returns true and the link of the PR if a PR from branch already exists.
+func (r *prReconciler) reconcile(train, target, branch string) (url string, exists bool) {
+	if !r.listed {
+		r.listed = true
+		var err error
+		r.open, err = r.server.OpenPRs(*prInto)
+		if err != nil {
+			problems.Warnf("pr", *prInto, "unable to list open PRs, skipping reconciliation: %v", err)
+			return "", false
+		}
+	}
+	current := pipelineMarker(train, target)
+	for _, pr := range r.open {
+		m, ok := prbody.ParseMarker(pr.Body)
+		if !ok || !m.SamePipeline(current) || r.closed[pr.ID] {
+			continue
+		}
+		if m.Target != target && m.Target != "" && target != "" {
+			// another per-target branch of the train
+			continue
+		}
+		if pr.Source == branch {
+			log.Printf("found PR %d of train %s created by run %s", pr.ID, train, m.RunID)
+			url, exists = pr.URL, true
+			continue
+		}
+		log.Printf("closing PR %d of train %s from branch %s superseded by %s", pr.ID, train, pr.Source, branch)
+		comment := fmt.Sprintf("Superseded by the deployment branch %s", branch)
+		if r.closed == nil {
+			r.closed = make(map[int]bool)
+		}
+		r.closed[pr.ID] = true
+		if r.batch != nil {
+			r.closing = append(r.closing, pr)
+			r.comments = append(r.comments, comment)
+			continue
+		}
+		if err := r.server.ClosePR(pr, comment); err != nil {
+			problems.Warnf("pr", pr.Source, "unable to close superseded PR %d: %v", pr.ID, err)
+		}
+	}
+	return url, exists
+}
+
+// flush closes superseded PRs queued for batch
+func (r *prReconciler) flush() {
+	if len(r.closing) == 0 {
+		return
+	}
+	for i, err := range r.batch.ClosePRs(r.closing, r.comments) {
+		if err != nil {
+			problems.Warnf("pr", r.closing[i].Source, "unable to close superseded PR %d: %v", r.closing[i].ID, err)
+		}
+	}
+	r.closing, r.comments = nil, nil
+}
+
+// prResult is a deployment PR opened or reused by the run
+type prResult struct {
+	Train  string `json:"train"`
+	Branch string `json:"branch"`
+	URL    string `json:"url,omitempty"`
+}
+
+// resolvePRURLs looks up web links of results without one among open PRs into -gitops_pr_into
+func resolvePRURLs(server git.Reconciler, results []prResult) {
+	missing := false
+	for _, r := range results {
+		if r.URL == "" {
+			missing = true
+		}
+	}
+	if !missing {
+		return
+	}
+	open, err := server.OpenPRs(*prInto)
+	if err != nil {
+		problems.Warnf("pr", *prInto, "unable to list open PRs to find their links: %v", err)
+		return
+	}
+	urls := make(map[string]string)
+	for _, pr := range open {
+		urls[pr.Source] = pr.URL
+	}
+	for i := range results {
+		if results[i].URL == "" {
+			results[i].URL = urls[results[i].Branch]
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/fasterci/rules_gitops/gitops/records"
+	// the reference -record_sql_driver
+	_ "github.com/lib/pq"
+)
+
+// recordStores returns deployment record stores configured by -record_* flags
+func recordStores() []records.Store {
+	var stores []records.Store
+	if *recordDynamoDBTable != "" {
+		if *recordDynamoDBRegion == "" && *recordDynamoDBURL == "" {
+			fatalf("-record_dynamodb_table requires -record_dynamodb_region or AWS_REGION")
+		}
+		stores = append(stores, records.DynamoDB{
+			Table:       *recordDynamoDBTable,
+			Region:      *recordDynamoDBRegion,
+			URL:         *recordDynamoDBURL,
+			Credentials: records.EnvCredentials(),
+			Clock:       clk,
+		})
+	}
+	if *recordSQLDriver != "" {
+		db, err := sql.Open(*recordSQLDriver, *recordSQLDSN)
+		if err != nil {
+			fatalf("invalid -record_sql_driver: %v", err)
+		}
+		s := records.SQL{DB: db, Table: *recordSQLTable}
+		placeholder := *recordSQLPlaceholder
+		if placeholder == "" {
+			placeholder = "?"
+			if *recordSQLDriver == "postgres" {
+				placeholder = "$"
+			}
+		}
+		switch placeholder {
+		case "?":
+		case "$":
+			s.Placeholder = records.Dollar
+		default:
+			fatalf("invalid -record_sql_placeholder %q, expected ? or $", *recordSQLPlaceholder)
+		}
+		stores = append(stores, s)
+	}
+	return stores
+}
+
+// recordDeployments records opened deployment PRs with images of their branches in every store.
+// PRs of per-target branches are recorded with the target of the branch only.
+func recordDeployments(stores []records.Store, prs []prResult, releaseTrains map[string][]string, branchTargets map[string]string, branchImages map[string][]string, started time.Time) {
+	if len(stores) == 0 || len(prs) == 0 {
+		return
+	}
+	now := clk.Now()
+	var recs []records.Record
+	for _, pr := range prs {
+		targets := releaseTrains[pr.Train]
+		if t := branchTargets[pr.Branch]; t != "" {
+			targets = []string{t}
+		}
+		recs = append(recs, records.Record{
+			RunID:         *runID,
+			Train:         pr.Train,
+			Branch:        pr.Branch,
+			Targets:       targets,
+			Images:        branchImages[pr.Branch],
+			PRURL:         pr.URL,
+			ReleaseBranch: *releaseBranch,
+			SourceBranch:  *branchName,
+			SourceCommit:  *gitCommit,
+			StartedAt:     started,
+			RecordedAt:    now,
+		})
+	}
+	for _, store := range stores {
+		if err := store.Put(context.Background(), recs); err != nil {
+			problems.Warnf("record", "", "unable to record deployments: %v", err)
+			continue
+		}
+		log.Println("recorded", len(recs), "deployment(s)")
+	}
+}
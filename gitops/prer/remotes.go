@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+// pushResult is the outcome of pushing deployment branches to a single remote
+type pushResult struct {
+	Remote   string   `json:"remote"`
+	Branches []string `json:"branches"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// pushRemote is an additional remote deployment branches are pushed to
+type pushRemote struct {
+	name, url string
+}
+
+// parsePushRemotes validates -git_push_remote flags before anything is cloned or pushed
+func parsePushRemotes() []pushRemote {
+	var remotes []pushRemote
+	seen := map[string]bool{*gitRemote: true}
+	for _, pr := range gitPushRemotes {
+		name, url, found := strings.Cut(pr, "=")
+		if !found || name == "" || url == "" {
+			fatalf("invalid -git_push_remote %q, expected name=url", pr)
+		}
+		if seen[name] {
+			fatalf("invalid -git_push_remote %q, remote %s is already used", pr, name)
+		}
+		seen[name] = true
+		remotes = append(remotes, pushRemote{name: name, url: url})
+	}
+	return remotes
+}
+
+// pushRemotes pushes branches to the primary remote and then to all -git_push_remote remotes.
+// Failure to push to the primary remote is fatal, failures of additional remotes are reported as errors.
+func pushRemotes(workdir *git.Repo, remotes []pushRemote, branches []string) []pushResult {
+	var results []pushResult
+	if err := workdir.Push(branches); err != nil {
+		if errors.Is(err, git.ErrAuth) {
+			fatalf("unable to push deployment branches, check git credentials: %v", err)
+		}
+		fatalf("unable to push deployment branches: %v", err)
+	}
+	results = append(results, pushResult{Remote: *gitRemote, Branches: branches})
+	for _, remote := range remotes {
+		r := pushResult{Remote: remote.name, Branches: branches}
+		err := workdir.SetRemote(remote.name, remote.url)
+		if err == nil {
+			err = workdir.PushTo(remote.name, branches)
+		}
+		if err != nil {
+			r.Error = err.Error()
+			problems.Error("push", remote.name, fmt.Errorf("unable to push deployment branches: %w", err))
+		}
+		results = append(results, r)
+	}
+	return results
+}
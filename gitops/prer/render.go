@@ -0,0 +1,85 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/fasterci/rules_gitops/gitops/bazel"
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+	"github.com/fasterci/rules_gitops/gitops/trains"
+)
+
+// renderTrain runs gitops binaries for all targets of the train in renderSandbox using up to parallelism
+// concurrent processes. Errors of all failed targets are returned.
+func renderTrain(train string, targets []string, deploymentRoot string, parallelism int) error {
+	r := trains.Renderer{Sandbox: renderSandbox, Parallelism: parallelism, Executable: executable}
+	return r.Render(train, targets, deploymentRoot)
+}
+
+// trainInputsHash returns a hash of everything the gitops binaries of the train can read while rendering and of the
+// flags and tool version changing what is committed. The stamp info file of the binaries is one of their runfiles.
+// The -git_commit annotation of -standard_labels is left out like in restoreCosmeticChanges, a new source commit
+// alone doesn't change the manifests.
+func trainInputsHash(targets []string) (string, error) {
+	b := currentBuild()
+	settings := trains.Settings{
+		GitopsPath:           *gitopsPath,
+		StandardLabels:       *standardLabels,
+		ResourceLabels:       resourceLabels,
+		ResourceAnnotations:  resourceAnnotations,
+		NamespaceBootstrap:   *namespaceBootstrap,
+		NamespaceDir:         *namespaceDir,
+		NamespaceLabels:      namespaceLabels,
+		NamespaceAnnotations: namespaceAnnotations,
+		KustomizationIndex:   *kustomizationIndex,
+		IgnoreServerFields:   *ignoreServerFields,
+		ToolVersion:          b.Version + " " + b.Commit,
+	}
+	return trains.InputsHash(targets, settings, func(target string) (string, error) {
+		return bazel.RunfilesDigest(executable(target))
+	})
+}
+
+// restoreCosmeticChanges reverts rendered manifests equivalent to the committed version once server populated fields
+// like creationTimestamp or status are stripped, so renderers emitting them don't produce spurious commits.
+// The source commit stamped by -standard_labels is ignored as well.
+func restoreCosmeticChanges(workdir *git.Repo, train string) {
+	files, err := workdir.ChangedFiles(*gitopsPath)
+	if err != nil {
+		fatalf("unable to list changed files: %v", err)
+	}
+	var cosmetic []string
+	for _, f := range files {
+		if !manifests.IsManifest(f) {
+			continue
+		}
+		old, found, err := workdir.FileAt("HEAD", f)
+		if err != nil {
+			fatalf("unable to read committed %s: %v", f, err)
+		}
+		if !found {
+			continue
+		}
+		rendered, err := os.ReadFile(filepath.Join(workdir.Dir, f))
+		if err != nil {
+			fatalf("unable to read rendered %s: %v", f, err)
+		}
+		eq, err := manifests.EquivalentManifests(old, rendered, stampIgnoredFields()...)
+		if err != nil {
+			problems.Warnf("render", train, "unable to compare %s with the committed version: %v", f, err)
+			continue
+		}
+		if eq {
+			cosmetic = append(cosmetic, f)
+		}
+	}
+	if len(cosmetic) == 0 {
+		return
+	}
+	log.Println("train", train, "ignoring server populated field changes in", cosmetic)
+	if err := workdir.Restore(cosmetic...); err != nil {
+		fatalf("unable to restore %v: %v", cosmetic, err)
+	}
+}
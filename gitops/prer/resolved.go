@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/fasterci/rules_gitops/gitops/bazel"
+)
+
+// resolvedManifest lists release trains with prebuilt gitops and push binaries.
+// It allows running the tool without bazel and a workspace checkout.
+type resolvedManifest struct {
+	Trains map[string]resolvedTrain `json:"trains"`
+}
+
+type resolvedTrain struct {
+	// Targets name the gitops binaries in commit messages and deployment branches, usually their bazel labels.
+	// Binary paths as written in the manifest are used if not set.
+	Targets []string `json:"targets,omitempty"`
+	// Binaries are gitops binaries rendering the release train manifests
+	Binaries []string `json:"binaries"`
+	// Pushes are binaries pushing images the release train depends on
+	Pushes []string `json:"pushes,omitempty"`
+}
+
+// resolvedExecutables maps targets of release trains loaded from a resolved manifest to their binaries
+var resolvedExecutables = make(map[string]string)
+
+// executable returns the binary of the gitops target
+func executable(target string) string {
+	if bin, ok := resolvedExecutables[target]; ok {
+		return bin
+	}
+	return bazel.TargetToExecutable(target)
+}
+
+// loadResolvedManifest reads and validates a manifest. Relative binary paths are resolved against the manifest directory,
+// targets default to the binary paths as written, so they do not depend on where the manifest is.
+func loadResolvedManifest(path string) (*resolvedManifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m resolvedManifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	base, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	for train, rt := range m.Trains {
+		if len(rt.Targets) == 0 {
+			rt.Targets = append([]string(nil), rt.Binaries...)
+		}
+		for i, bin := range rt.Binaries {
+			rt.Binaries[i] = resolvePath(base, bin)
+		}
+		for i, bin := range rt.Pushes {
+			rt.Pushes[i] = resolvePath(base, bin)
+		}
+		m.Trains[train] = rt
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &m, nil
+}
+
+func resolvePath(base, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(base, filepath.FromSlash(path))
+}
+
+// validate checks that every train has binaries and all binaries are executable files
+func (m *resolvedManifest) validate() error {
+	if len(m.Trains) == 0 {
+		return errors.New("no release trains defined")
+	}
+	var errs []error
+	for train, rt := range m.Trains {
+		if train == "" {
+			errs = append(errs, errors.New("release train name is empty"))
+		}
+		if len(rt.Binaries) == 0 {
+			errs = append(errs, fmt.Errorf("release train %s has no binaries", train))
+		}
+		if len(rt.Targets) != len(rt.Binaries) {
+			errs = append(errs, fmt.Errorf("release train %s has %d targets for %d binaries", train, len(rt.Targets), len(rt.Binaries)))
+		}
+		for _, bin := range append(append([]string(nil), rt.Binaries...), rt.Pushes...) {
+			if err := checkExecutable(bin); err != nil {
+				errs = append(errs, fmt.Errorf("release train %s: %w", train, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func checkExecutable(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !fi.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a regular file", path)
+	}
+	if runtime.GOOS != "windows" && fi.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("%s is not executable", path)
+	}
+	return nil
+}
+
+// pushes returns unique push binaries of the trains
+func (m *resolvedManifest) pushes(trains []string) []string {
+	seen := make(map[string]bool)
+	var pushes []string
+	for _, train := range trains {
+		for _, p := range m.Trains[train].Pushes {
+			if !seen[p] {
+				seen[p] = true
+				pushes = append(pushes, p)
+			}
+		}
+	}
+	return pushes
+}
+
+// writeResolvedManifest resolves push dependencies of every train with bazel and writes the manifest.
+// Binary paths are stored relative to the manifest directory, along with the target labels.
+// All gitops and push targets must be built before the manifest is used.
+func writeResolvedManifest(path string, releaseTrains map[string][]string) error {
+	base, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	rel := func(target string) (string, error) {
+		abs, err := filepath.Abs(bazel.TargetToExecutable(target))
+		if err != nil {
+			return "", err
+		}
+		r, err := filepath.Rel(base, abs)
+		return filepath.ToSlash(r), err
+	}
+	m := resolvedManifest{Trains: make(map[string]resolvedTrain)}
+	trains := make([]string, 0, len(releaseTrains))
+	for train := range releaseTrains {
+		trains = append(trains, train)
+	}
+	sort.Strings(trains)
+	for _, train := range trains {
+		targets := releaseTrains[train]
+		rt := resolvedTrain{Targets: targets}
+		for _, t := range targets {
+			bin, err := rel(t)
+			if err != nil {
+				return err
+			}
+			rt.Binaries = append(rt.Binaries, bin)
+		}
+		for _, t := range bazelQuery(pushDepsQuery(targets)) {
+			bin, err := rel(t.Name)
+			if err != nil {
+				return err
+			}
+			rt.Pushes = append(rt.Pushes, bin)
+		}
+		m.Trains[train] = rt
+	}
+	b, err := json.MarshalIndent(&m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+)
+
+// rolloutResult is the outcome of a rollout status check of a single workload
+type rolloutResult struct {
+	Train     string `json:"train"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Status is one of "ready", "failed" or "timeout"
+	Status   string  `json:"status"`
+	Message  string  `json:"message,omitempty"`
+	Duration float64 `json:"duration_seconds"`
+}
+
+// verifyRollouts waits for Deployments, StatefulSets, DaemonSets and Jobs applied from dir to become ready.
+// All workloads share the -apply_rollout_timeout deadline. Failed checks are reported as errors.
+func verifyRollouts(train, dir string) []rolloutResult {
+	objs, err := manifests.DecodeDir(dir)
+	if err != nil {
+		problems.Error("rollout", train, err)
+		return nil
+	}
+	deadline := clk.Now().Add(*applyRolloutTimeout)
+	var results []rolloutResult
+	for _, obj := range objs {
+		kind := obj.GetKind()
+		switch kind {
+		case "Deployment", "StatefulSet", "DaemonSet", "Job":
+		default:
+			continue
+		}
+		r := rolloutResult{Train: train, Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		start := clk.Now()
+		timeout := deadline.Sub(clk.Now())
+		if timeout <= 0 {
+			r.Status = "timeout"
+			r.Message = "rollout timeout exceeded before the check started"
+		} else {
+			args := []string{"--context", *applyContext}
+			if r.Namespace != "" {
+				args = append(args, "--namespace", r.Namespace)
+			}
+			ref := strings.ToLower(kind) + "/" + r.Name
+			if kind == "Job" {
+				args = append(args, "wait", "--for=condition=complete", ref, "--timeout", timeout.String())
+			} else {
+				args = append(args, "rollout", "status", ref, "--watch", "--timeout", timeout.String())
+			}
+			out, err := runner.Run("", nil, *kubectlCmd, args...)
+			switch {
+			case err == nil:
+				r.Status = "ready"
+			case clk.Now().After(deadline):
+				r.Status = "timeout"
+				r.Message = strings.TrimSpace(out)
+			default:
+				r.Status = "failed"
+				r.Message = strings.TrimSpace(out)
+			}
+		}
+		r.Duration = clk.Now().Sub(start).Seconds()
+		log.Printf("rollout %s %s/%s: %s", train, kind, r.Name, r.Status)
+		if r.Status != "ready" {
+			problems.Error("rollout", train, fmt.Errorf("%s %s/%s is not ready (%s): %s", kind, r.Namespace, r.Name, r.Status, r.Message))
+		}
+		results = append(results, r)
+	}
+	return results
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+
+	"github.com/fasterci/rules_gitops/gitops/exec"
+)
+
+// renderSandbox runs gitops binaries and pushSandbox runs push binaries
+var renderSandbox, pushSandbox *exec.Sandbox
+
+// configureSandboxes sets up execution of gitops and push binaries from -run_under, -push_run_under,
+// -render_clean_env and -render_env flags
+func configureSandboxes() {
+	runUnder, err := exec.ParseRunUnder(*renderRunUnder)
+	if err != nil {
+		fatalf("invalid -run_under: %v", err)
+	}
+	renderSandbox = &exec.Sandbox{RunUnder: runUnder, CleanEnv: *renderCleanEnv, AllowEnv: renderEnv, Runner: runner}
+	if *renderCleanEnv {
+		if renderSandbox.Home, err = os.MkdirTemp(*gitopsTmpDir, "gitops-home-"); err != nil {
+			fatalf("unable to create HOME for gitops binaries: %v", err)
+		}
+	}
+	runUnder, err = exec.ParseRunUnder(*pushRunUnder)
+	if err != nil {
+		fatalf("invalid -push_run_under: %v", err)
+	}
+	pushSandbox = &exec.Sandbox{RunUnder: runUnder, Runner: runner}
+}
+
+// mustPush runs a push binary under pushSandbox and exits if it fails
+func mustPush(bin string) {
+	if _, err := pushSandbox.Ex("", bin); err != nil {
+		fatalf("ERROR: %s", err)
+	}
+}
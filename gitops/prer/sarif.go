@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/fasterci/rules_gitops/gitops/sarif"
+)
+
+// findings collects results of the secret scan and the image policy written to -sarif_output
+var findings = sarif.Log{
+	URI:   "https://github.com/fasterci/rules_gitops",
+	Rules: map[string]string{"image-policy": "Rendered images must satisfy the image policy"},
+}
+
+// addFinding records a result of train for -sarif_output
+func addFinding(train string, targets []string, r sarif.Result) {
+	if *sarifOutput == "" {
+		return
+	}
+	r.Targets = targets
+	r.Properties = map[string]string{"train": train}
+	findings.Add(r)
+}
+
+// writeSARIF writes findings to -sarif_output, an empty report if there are none
+func writeSARIF() {
+	findings.Tool = progName()
+	findings.Version = currentBuild().Version
+	if err := findings.WriteFile(*sarifOutput); err != nil {
+		problems.Warnf("summary", *sarifOutput, "unable to write SARIF report: %v", err)
+	}
+}
+
+// lineOf returns the first line of the file containing text, 0 if there is none
+func lineOf(path, text string) int {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		if strings.Contains(s.Text(), text) {
+			return n
+		}
+	}
+	return 0
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/imagepolicy"
+	"github.com/fasterci/rules_gitops/gitops/sarif"
+	"github.com/fasterci/rules_gitops/gitops/secretscan"
+)
+
+// newSecretScanner creates a scanner from command line flags
+func newSecretScanner() *secretscan.Scanner {
+	s := &secretscan.Scanner{
+		Rules:            append([]secretscan.Rule(nil), secretscan.DefaultRules...),
+		EntropyThreshold: *secretScanEntropy,
+		EntropyMinLength: 20,
+	}
+	for i, p := range secretScanPatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			fatalf("invalid -secret_scan_pattern %q: %v", p, err)
+		}
+		s.Rules = append(s.Rules, secretscan.Rule{Name: fmt.Sprintf("custom-%d", i+1), Pattern: re})
+	}
+	return s
+}
+
+// scanTrain scans files changed by the train for secrets. All findings are reported.
+// Returns false if the train has to be blocked.
+func scanTrain(scanner *secretscan.Scanner, workdir *git.Repo, train string, targets []string) bool {
+	files, err := workdir.ChangedFiles(*gitopsPath)
+	if err != nil {
+		fatalf("unable to list changed files: %v", err)
+	}
+	var findings []secretscan.Finding
+	for _, f := range files {
+		ff, err := scanner.ScanFile(filepath.Join(workdir.Dir, f))
+		if err != nil {
+			fatalf("unable to scan %s for secrets: %v", f, err)
+		}
+		for i := range ff {
+			ff[i].File = f
+		}
+		findings = append(findings, ff...)
+	}
+	for _, f := range findings {
+		level := sarif.Error
+		if *secretScanOverride {
+			level = sarif.Warning
+			problems.Warnf("secret-scan", train, "%s (ignored by -secret_scan_override)", f)
+		} else {
+			problems.Error("secret-scan", train, fmt.Errorf("possible secret: %s", f))
+		}
+		addSecretFinding(train, targets, f, level)
+	}
+	return len(findings) == 0 || *secretScanOverride
+}
+
+// checkImagePolicy validates images used in files changed by the train. All violations are reported.
+// Returns false if the train has to be blocked.
+func checkImagePolicy(policy *imagepolicy.Policy, workdir *git.Repo, train string, targets []string) bool {
+	files, err := workdir.ChangedFiles(*gitopsPath)
+	if err != nil {
+		fatalf("unable to list changed files: %v", err)
+	}
+	ok := true
+	for _, f := range files {
+		violations, err := policy.CheckFile(filepath.Join(workdir.Dir, f))
+		if err != nil {
+			fatalf("unable to check image policy of %s: %v", f, err)
+		}
+		for _, v := range violations {
+			v.File = f
+			problems.Error("image-policy", train, fmt.Errorf("%s", v))
+			addFinding(train, targets, sarif.Result{
+				RuleID:  "image-policy",
+				Level:   sarif.Error,
+				Message: fmt.Sprintf("image %s: %s", v.Image, v.Reason),
+				File:    filepath.ToSlash(f),
+				Line:    lineOf(filepath.Join(workdir.Dir, f), v.Image),
+			})
+			ok = false
+		}
+	}
+	return ok
+}
+
+// addSecretFinding records a secret scan finding of train for -sarif_output
+func addSecretFinding(train string, targets []string, f secretscan.Finding, level string) {
+	rule := "secret-scan/" + f.Rule
+	findings.Rules[rule] = "Rendered manifests must not contain secrets: " + f.Rule
+	addFinding(train, targets, sarif.Result{
+		RuleID:  rule,
+		Level:   level,
+		Message: fmt.Sprintf("possible secret: %s (%s)", f.Rule, f.Match),
+		File:    filepath.ToSlash(f.File),
+		Line:    f.Line,
+	})
+}
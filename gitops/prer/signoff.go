@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+// verifySignOff checks that every commit the branches would push, the ones neither in base nor on the remote,
+// carries the Signed-off-by trailer of the configured identity. Returns branches that pass the check, failed branches are reported.
+func verifySignOff(workdir *git.Repo, base string, branches []string) []string {
+	trailer, err := workdir.SignOffTrailer()
+	if err != nil {
+		fatalf("unable to verify sign-off: %v", err)
+	}
+	var verified []string
+	for _, branch := range branches {
+		missing, err := workdir.CommitsWithoutTrailer(base, branch, trailer)
+		if err != nil {
+			problems.Error("push", branch, err)
+			continue
+		}
+		if len(missing) > 0 {
+			problems.Error("push", branch, fmt.Errorf("commits %v are missing %q, branch is not pushed", missing, trailer))
+			continue
+		}
+		verified = append(verified, branch)
+	}
+	return verified
+}
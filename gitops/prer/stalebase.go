@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+// -stale_base policies
+const (
+	staleBaseRebase = "rebase"
+	staleBaseFail   = "fail"
+	staleBaseIgnore = "ignore"
+)
+
+// checkStaleBase re-fetches the -gitops_pr_into branch and handles branches according to -stale_base
+// if it advanced past cloneBase since the clone. Returns branches that are safe to push, failed rebases are reported.
+func checkStaleBase(workdir *git.Repo, cloneBase string, branches []string) []string {
+	if *staleBase == staleBaseIgnore || len(branches) == 0 {
+		return branches
+	}
+	tracking, err := workdir.FetchBranch(*prInto)
+	if err != nil {
+		fatalf("unable to fetch %s: %v", *prInto, err)
+	}
+	current, err := workdir.Rev(tracking)
+	if err != nil {
+		fatalf("unable to resolve %s: %v", tracking, err)
+	}
+	if current == cloneBase {
+		return branches
+	}
+	if *staleBase == staleBaseFail {
+		fatalf("base moved: %s advanced from %s to %s since the clone, rerun to render against the new base", *prInto, shortSHA(cloneBase), shortSHA(current))
+	}
+	log.Printf("base moved: %s advanced from %s to %s since the clone, rebasing %d branch(es)", *prInto, shortSHA(cloneBase), shortSHA(current), len(branches))
+	var rebased []string
+	for _, branch := range branches {
+		if err := workdir.Rebase(branch, tracking); err != nil {
+			problems.Error("push", branch, fmt.Errorf("base moved and rebase onto %s failed, branch is not pushed: %w", *prInto, err))
+			continue
+		}
+		rebased = append(rebased, branch)
+	}
+	// later diffs and checks compare against the local base branch
+	if err := workdir.UpdateBranch(*prInto, current); err != nil {
+		fatalf("unable to update %s: %v", *prInto, err)
+	}
+	return rebased
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+)
+
+// labels and annotations added to every rendered resource with -standard_labels
+const (
+	managedByLabel         = "app.kubernetes.io/managed-by"
+	trainLabel             = "gitops.fasterci.com/release-train"
+	sourceCommitAnnotation = "gitops.fasterci.com/source-commit"
+)
+
+// resourceStamps returns labels and annotations of the train resources, or nils if stamping is disabled
+func resourceStamps(train string) (labels, annotations map[string]string) {
+	labels, err := parseKeyValues("resource_label", resourceLabels)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	annotations, err = parseKeyValues("resource_annotation", resourceAnnotations)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	if *standardLabels {
+		labels[managedByLabel] = "rules_gitops"
+		labels[trainLabel] = manifests.LabelValue(train)
+		if *gitCommit != "" {
+			annotations[sourceCommitAnnotation] = *gitCommit
+		}
+	}
+	return labels, annotations
+}
+
+// stampTrain adds labels and annotations to resources of manifests changed by the train
+func stampTrain(workdir *git.Repo, train string) {
+	labels, annotations := resourceStamps(train)
+	if len(labels) == 0 && len(annotations) == 0 {
+		return
+	}
+	files, err := workdir.ChangedFiles(*gitopsPath)
+	if err != nil {
+		fatalf("unable to list changed files: %v", err)
+	}
+	for _, f := range files {
+		if !manifests.IsManifest(f) {
+			continue
+		}
+		if err := manifests.StampFile(filepath.Join(workdir.Dir, f), labels, annotations); err != nil {
+			fatalf("unable to add labels to %s: %v", f, err)
+		}
+	}
+}
+
+// stampIgnoredFields are stamped fields that change with every run and alone don't justify a commit
+func stampIgnoredFields() [][]string {
+	if !*standardLabels {
+		return nil
+	}
+	return [][]string{{"metadata", "annotations", sourceCommitAnnotation}}
+}
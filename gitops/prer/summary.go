@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/fasterci/rules_gitops/gitops/report"
+)
+
+// runSummary is the machine readable outcome of the run written to -summary_json
+type runSummary struct {
+	Tool            buildInfo           `json:"tool"`
+	ReleaseBranch   string              `json:"release_branch"`
+	Trains          map[string][]string `json:"trains"`
+	UpdatedBranches []string            `json:"updated_branches"`
+	FrozenTrains    []string            `json:"frozen_trains,omitempty"`
+	Pushes          []pushResult        `json:"pushes,omitempty"`
+	PullRequests    []prResult          `json:"pull_requests,omitempty"`
+	AppliedTrains   []string            `json:"applied_trains,omitempty"`
+	Rollouts        []rolloutResult     `json:"rollouts,omitempty"`
+	Problems        []report.Entry      `json:"problems"`
+}
+
+// activeSummary is the summary finished by fatalf, set once the run has started
+var activeSummary *runSummary
+
+// fatalf logs a fatal error and terminates the process.
+// Once the run has started the error is reported and the run is finished first, so alerts, notifications,
+// the properties file and the JSON summary are not lost.
+func fatalf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	s := activeSummary
+	if s == nil {
+		log.Fatal(msg)
+	}
+	// a failure while finishing terminates right away
+	activeSummary = nil
+	log.Output(2, msg)
+	problems.Error("fatal", "", errors.New(msg))
+	finish(s)
+	os.Exit(1)
+}
+
+// finish sends notifications and alerts, annotates the build, prints the aggregated problem report and writes
+// the properties file and the JSON summary if requested.
+// It terminates the process with a non-zero exit code if any errors were reported.
+func finish(s *runSummary) {
+	sendNotifications(s)
+	sendAlerts(s)
+	annotate(s)
+	if *propertiesFile != "" {
+		if err := writeProperties(s); err != nil {
+			problems.Warnf("summary", *propertiesFile, "unable to write properties file: %v", err)
+		}
+	}
+	if *sarifOutput != "" {
+		writeSARIF()
+	}
+	problems.Print(os.Stderr)
+	if *summaryJSON != "" {
+		s.Problems = problems.Entries()
+		if s.Problems == nil {
+			s.Problems = []report.Entry{}
+		}
+		b, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			fatalf("unable to marshal run summary: %v", err)
+		}
+		if err := os.WriteFile(*summaryJSON, b, 0644); err != nil {
+			fatalf("unable to write run summary to %s: %v", *summaryJSON, err)
+		}
+	}
+	if problems.HasErrors() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"github.com/fasterci/rules_gitops/gitops/exec"
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/git/bitbucket"
+	"github.com/fasterci/rules_gitops/gitops/git/github"
+	"github.com/fasterci/rules_gitops/gitops/git/gitlab"
+	"github.com/fasterci/rules_gitops/gitops/transport"
+)
+
+// environment variables the git credential helper reads push credentials from
+const (
+	pushUserEnv     = "GITOPS_GIT_PUSH_USER"
+	pushPasswordEnv = "GITOPS_GIT_PUSH_PASSWORD"
+)
+
+// configureTransport gives the git server API clients an HTTP client of their own with CA, client certificate and proxy
+// settings, connection tuning and the request timeout, and returns the runner of git commands passing the git settings
+// in their environment only, so gitops renderers and push binaries do not inherit them. Other HTTP clients of the
+// process, like alerts, deployment records and registry checks, keep the defaults.
+// If pushUser is not empty git authenticates with pushUser and pushPassword instead of the configured credential helpers,
+// while git server API clients keep using their own credentials.
+func configureTransport(pushUser, pushPassword string) exec.Runner {
+	c := transport.Config{
+		CAFile:   *caBundle,
+		CertFile: *clientCert,
+		KeyFile:  *clientKey,
+		Proxy:    *proxyURL,
+
+		DialTimeout:           *httpDialTimeout,
+		TLSHandshakeTimeout:   *httpTLSTimeout,
+		ResponseHeaderTimeout: *httpHeaderTimeout,
+		IdleConnTimeout:       *httpIdleConnTimeout,
+		KeepAlive:             *httpKeepAlive,
+		MaxIdleConns:          *httpMaxIdleConns,
+		MaxIdleConnsPerHost:   *httpMaxIdleConnsPerHost,
+		DisableKeepAlives:     *httpDisableKeepAlives,
+		DisableHTTP2:          *httpDisableHTTP2,
+	}
+	env := c.GitEnv()
+	config := c.GitConfig()
+	if pushUser != "" {
+		env = append(env, pushUserEnv+"="+pushUser, pushPasswordEnv+"="+pushPassword)
+		config = append(config, git.CredentialHelper(pushUserEnv, pushPasswordEnv)...)
+	}
+	client, err := c.NewClient(*httpTimeout)
+	if err != nil {
+		fatalf("invalid transport configuration: %v", err)
+	}
+	bitbucket.HTTPClient = client
+	github.HTTPClient = client
+	gitlab.HTTPClient = client
+	return exec.EnvRunner{Runner: runner, Env: append(env, git.ConfigEnv(config)...)}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Set by bazel stamping, see x_defs of the create_gitops_prs binary
+var (
+	toolVersion   = "unknown"
+	toolCommit    = "unknown"
+	toolBuildTime = ""
+)
+
+// buildInfo identifies the build of the tool
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time,omitempty"`
+}
+
+// stamped returns v unless it is empty or an unresolved stamping placeholder like {STABLE_GIT_COMMIT}
+func stamped(v string) (string, bool) {
+	if v == "" || v == "unknown" || strings.HasPrefix(v, "{") {
+		return "", false
+	}
+	return v, true
+}
+
+// currentBuild returns the stamped build information, falling back to the information recorded by the go toolchain
+func currentBuild() buildInfo {
+	b := buildInfo{Version: "unknown", Commit: "unknown"}
+	if v, ok := stamped(toolVersion); ok {
+		b.Version = v
+	}
+	if v, ok := stamped(toolCommit); ok {
+		b.Commit = v
+	}
+	if v, ok := stamped(toolBuildTime); ok {
+		// BUILD_TIMESTAMP is in seconds since the epoch
+		if sec, err := strconv.ParseInt(v, 10, 64); err == nil {
+			v = time.Unix(sec, 0).UTC().Format(time.RFC3339)
+		}
+		b.BuildTime = v
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if b.Version == "unknown" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			b.Version = info.Main.Version
+		}
+		for _, s := range info.Settings {
+			switch {
+			case s.Key == "vcs.revision" && b.Commit == "unknown":
+				b.Commit = s.Value
+			case s.Key == "vcs.time" && b.BuildTime == "":
+				b.BuildTime = s.Value
+			}
+		}
+	}
+	return b
+}
+
+func (b buildInfo) String() string {
+	s := fmt.Sprintf("%s (commit %s", b.Version, b.Commit)
+	if b.BuildTime != "" {
+		s += ", built " + b.BuildTime
+	}
+	return s + ")"
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+	"github.com/fasterci/rules_gitops/gitops/window"
+)
+
+// parseDeploymentWindows parses -deployment_window flags into schedules by release train
+func parseDeploymentWindows() map[string]*window.Schedule {
+	windows := make(map[string]*window.Schedule)
+	for _, w := range deploymentWindows {
+		train, spec, ok := strings.Cut(w, "=")
+		if !ok {
+			fatalf("invalid deployment window %q, expected train=window", w)
+		}
+		s, err := window.Parse(spec)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		windows[train] = s
+	}
+	switch *deploymentWindowAction {
+	case "defer", "draft":
+	default:
+		fatalf("invalid -deployment_window_action %q, expected defer or draft", *deploymentWindowAction)
+	}
+	return windows
+}
+
+// outsideWindow returns true and a description if the PR of train can't be opened now because of its deployment window
+func outsideWindow(windows map[string]*window.Schedule, train string, now time.Time) (bool, string) {
+	s, ok := windows[train]
+	if !ok || s.Contains(now) {
+		return false, ""
+	}
+	return true, fmt.Sprintf("outside of the deployment window %q, next window opens at %s", s, s.Next(now).Format(time.RFC1123))
+}
+
+// deferredPR returns true if the existing deployment branch of a train with a deployment window has changes pushed
+// by an earlier run outside of the window that are not in -gitops_pr_into yet, and the window is open now.
+// Such branches have no new commits, the PR deferred by the earlier run is opened for them.
+func deferredPR(workdir *git.Repo, windows map[string]*window.Schedule, train, branch string) bool {
+	if _, ok := windows[train]; !ok {
+		return false
+	}
+	if outside, _ := outsideWindow(windows, train, clk.Now()); outside {
+		return false
+	}
+	pending, err := workdir.HasUnmergedChanges(*prInto, branch, *gitopsPath)
+	if err != nil {
+		problems.Warnf("pr", branch, "unable to check the branch for a deferred PR: %v", err)
+		return false
+	}
+	return pending
+}
+
+// withoutOpenPRs drops branches with an open PR into -gitops_pr_into.
+// Without a reconciler all branches are kept, creating a PR that already exists is not an error.
+func withoutOpenPRs(reconciler git.Reconciler, branches []string) []string {
+	if reconciler == nil || len(branches) == 0 {
+		return branches
+	}
+	open, err := reconciler.OpenPRs(*prInto)
+	if err != nil {
+		problems.Warnf("pr", *prInto, "unable to list open PRs: %v", err)
+		return branches
+	}
+	hasPR := make(map[string]bool)
+	for _, pr := range open {
+		hasPR[pr.Source] = true
+	}
+	var kept []string
+	for _, b := range branches {
+		if hasPR[b] {
+			continue
+		}
+		log.Println("branch", b, "has a deferred PR, opening it")
+		kept = append(kept, b)
+	}
+	return kept
+}
+
+// markDraftsReady marks open draft PRs of trains whose deployment window is open now ready for review.
+// The drafts were opened by earlier runs outside of the window with -deployment_window_action=draft.
+func markDraftsReady(reconciler git.Reconciler, marker git.ReadyMarker, windows map[string]*window.Schedule) {
+	if reconciler == nil || marker == nil || len(windows) == 0 || *deploymentWindowAction != "draft" {
+		return
+	}
+	open, err := reconciler.OpenPRs(*prInto)
+	if err != nil {
+		problems.Warnf("pr", *prInto, "unable to list open PRs: %v", err)
+		return
+	}
+	for _, pr := range open {
+		if !pr.Draft {
+			continue
+		}
+		for train := range windows {
+			if !isTrainBranch(pr.Source, train) {
+				continue
+			}
+			if outside, _ := outsideWindow(windows, train, clk.Now()); outside {
+				break
+			}
+			if *dryRun {
+				log.Println("dry-run: skipping marking the draft PR from branch", pr.Source, "ready for review")
+				break
+			}
+			if err := marker.MarkReady(pr); err != nil {
+				problems.Error("pr", pr.Source, fmt.Errorf("unable to mark the draft PR ready for review: %w", err))
+				break
+			}
+			log.Printf("deployment window of train %s is open, marked the draft PR from branch %s ready for review", train, pr.Source)
+			break
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Package profile provides flag defaults for common CI systems
+package profile
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Getenv returns the value of an environment variable, like os.Getenv
+type Getenv func(key string) string
+
+// profiles map profile names to functions returning flag defaults derived from the CI environment.
+// Empty values are not applied.
+var profiles = map[string]func(env Getenv) map[string]string{
+	// none disables detection of the CI system
+	"none": func(env Getenv) map[string]string {
+		return nil
+	},
+	"github-actions": func(env Getenv) map[string]string {
+		owner, repo := splitRepository(env("GITHUB_REPOSITORY"))
+		d := map[string]string{
+			"git_server":        "github",
+			"github_repo_owner": owner,
+			"github_repo":       repo,
+			"branch_name":       env("GITHUB_REF_NAME"),
+			"git_commit":        env("GITHUB_SHA"),
+			"workspace":         env("GITHUB_WORKSPACE"),
+			"gitops_tmpdir":     env("RUNNER_TEMP"),
+		}
+		if server := env("GITHUB_SERVER_URL"); server != "" && env("GITHUB_REPOSITORY") != "" {
+			d["git_repo"] = server + "/" + env("GITHUB_REPOSITORY") + ".git"
+			if host := strings.TrimPrefix(server, "https://"); host != "github.com" {
+				d["github_enterprise_host"] = host
+			}
+		}
+		return d
+	},
+	"gitlab-ci": func(env Getenv) map[string]string {
+		d := map[string]string{
+			"git_server":  "gitlab",
+			"gitlab_host": env("CI_SERVER_URL"),
+			"gitlab_repo": env("CI_PROJECT_PATH"),
+			"branch_name": env("CI_COMMIT_REF_NAME"),
+			"git_commit":  env("CI_COMMIT_SHA"),
+			"workspace":   env("CI_PROJECT_DIR"),
+		}
+		if server := env("CI_SERVER_URL"); server != "" && env("CI_PROJECT_PATH") != "" {
+			d["git_repo"] = server + "/" + env("CI_PROJECT_PATH") + ".git"
+		}
+		return d
+	},
+	"jenkins": func(env Getenv) map[string]string {
+		d := map[string]string{
+			"git_repo":      env("GIT_URL"),
+			"branch_name":   strings.TrimPrefix(env("GIT_BRANCH"), "origin/"),
+			"git_commit":    env("GIT_COMMIT"),
+			"workspace":     env("WORKSPACE"),
+			"gitops_tmpdir": env("WORKSPACE_TMP"),
+		}
+		if ws := env("WORKSPACE"); ws != "" {
+			// the checkout of the job is used as the reference repository for the gitops clone
+			d["git_mirror"] = ws + "/.git"
+			d["properties_file"] = ws + "/gitops.properties"
+		}
+		return d
+	},
+}
+
+func splitRepository(s string) (owner, repo string) {
+	if i := strings.Index(s, "/"); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return "", s
+}
+
+// Detect returns the name of the profile matching the CI system the process runs in or empty string
+func Detect(env Getenv) string {
+	if env("JENKINS_URL") != "" {
+		return "jenkins"
+	}
+	return ""
+}
+
+// Names returns the names of all profiles
+func Names() []string {
+	var names []string
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Defaults returns flag defaults of the profile name, skipping empty values
+func Defaults(name string, env Getenv) (map[string]string, error) {
+	p, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q, available profiles: %s", name, strings.Join(Names(), ", "))
+	}
+	d := make(map[string]string)
+	for k, v := range p(env) {
+		if v != "" {
+			d[k] = v
+		}
+	}
+	return d, nil
+}
+
+// Apply sets flags of fs to the defaults of the profile name.
+// Flags set explicitly on the command line and flags not defined in fs are left unchanged.
+func Apply(fs *flag.FlagSet, name string, env Getenv) error {
+	defaults, err := Defaults(name, env)
+	if err != nil {
+		return err
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for k, v := range defaults {
+		if set[k] || fs.Lookup(k) == nil {
+			continue
+		}
+		if err := fs.Set(k, v); err != nil {
+			return fmt.Errorf("profile %s: invalid value %q for -%s: %w", name, v, k, err)
+		}
+	}
+	return nil
+}
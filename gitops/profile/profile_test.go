@@ -0,0 +1,90 @@
+package profile
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+)
+
+func env(vars map[string]string) Getenv {
+	return func(key string) string { return vars[key] }
+}
+
+func TestDefaultsGithubActions(t *testing.T) {
+	d, err := Defaults("github-actions", env(map[string]string{
+		"GITHUB_REPOSITORY": "fasterci/rules_gitops",
+		"GITHUB_SERVER_URL": "https://github.com",
+		"GITHUB_SHA":        "0123abcd",
+		"GITHUB_REF_NAME":   "main",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"git_server":        "github",
+		"github_repo_owner": "fasterci",
+		"github_repo":       "rules_gitops",
+		"git_repo":          "https://github.com/fasterci/rules_gitops.git",
+		"branch_name":       "main",
+		"git_commit":        "0123abcd",
+	}
+	if !reflect.DeepEqual(d, expected) {
+		t.Errorf("unexpected defaults %v", d)
+	}
+}
+
+func TestDefaultsUnknown(t *testing.T) {
+	if _, err := Defaults("travis", env(nil)); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+}
+
+func TestApplyKeepsExplicitFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	gitServer := fs.String("git_server", "bitbucket", "")
+	branchName := fs.String("branch_name", "unknown", "")
+	gitCommit := fs.String("git_commit", "unknown", "")
+	if err := fs.Parse([]string{"-branch_name=release"}); err != nil {
+		t.Fatal(err)
+	}
+	err := Apply(fs, "gitlab-ci", env(map[string]string{
+		"CI_COMMIT_REF_NAME": "main",
+		"CI_COMMIT_SHA":      "0123abcd",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *gitServer != "gitlab" || *branchName != "release" || *gitCommit != "0123abcd" {
+		t.Errorf("unexpected flags git_server=%s branch_name=%s git_commit=%s", *gitServer, *branchName, *gitCommit)
+	}
+}
+
+func TestDetectJenkins(t *testing.T) {
+	vars := map[string]string{
+		"JENKINS_URL": "https://jenkins.example.com/",
+		"WORKSPACE":   "/var/jenkins/workspace/app",
+		"GIT_BRANCH":  "origin/master",
+		"GIT_COMMIT":  "0123abcd",
+	}
+	name := Detect(env(vars))
+	if name != "jenkins" {
+		t.Fatalf("Detect() = %q", name)
+	}
+	d, err := Defaults(name, env(vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"branch_name":     "master",
+		"git_commit":      "0123abcd",
+		"workspace":       "/var/jenkins/workspace/app",
+		"git_mirror":      "/var/jenkins/workspace/app/.git",
+		"properties_file": "/var/jenkins/workspace/app/gitops.properties",
+	}
+	if !reflect.DeepEqual(d, expected) {
+		t.Errorf("unexpected defaults %v", d)
+	}
+	if name := Detect(env(nil)); name != "" {
+		t.Errorf("Detect() = %q outside of CI", name)
+	}
+}
@@ -0,0 +1,47 @@
+package profile
+
+import "strings"
+
+// sourceVars lists environment variables of CI systems holding the source branch and commit, in detection order
+var sourceVars = []struct {
+	ci, branch, commit string
+}{
+	// GITHUB_HEAD_REF is only set for pull request events, GITHUB_REF_NAME is the merge ref then
+	{"github-actions", "GITHUB_HEAD_REF", "GITHUB_SHA"},
+	{"github-actions", "GITHUB_REF_NAME", "GITHUB_SHA"},
+	{"gitlab-ci", "CI_COMMIT_REF_NAME", "CI_COMMIT_SHA"},
+	{"buildkite", "BUILDKITE_BRANCH", "BUILDKITE_COMMIT"},
+	{"circleci", "CIRCLE_BRANCH", "CIRCLE_SHA1"},
+	{"azure-pipelines", "BUILD_SOURCEBRANCHNAME", "BUILD_SOURCEVERSION"},
+	{"bitbucket-pipelines", "BITBUCKET_BRANCH", "BITBUCKET_COMMIT"},
+	{"travis", "TRAVIS_BRANCH", "TRAVIS_COMMIT"},
+	{"drone", "DRONE_BRANCH", "DRONE_COMMIT_SHA"},
+	{"jenkins", "GIT_BRANCH", "GIT_COMMIT"},
+}
+
+// DetectSource returns the source branch and commit of the build from environment variables of well-known CI systems.
+// Values that can't be detected are returned empty, ci is the name of the CI system the values come from.
+func DetectSource(env Getenv) (branch, commit, ci string) {
+	for _, v := range sourceVars {
+		b, c := env(v.branch), env(v.commit)
+		if b == "" && c == "" {
+			continue
+		}
+		if v.ci == "jenkins" {
+			b = strings.TrimPrefix(b, "origin/")
+		}
+		if branch == "" {
+			branch = b
+		}
+		if commit == "" {
+			commit = c
+		}
+		if ci == "" {
+			ci = v.ci
+		}
+		if branch != "" && commit != "" {
+			break
+		}
+	}
+	return branch, commit, ci
+}
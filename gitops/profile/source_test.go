@@ -0,0 +1,23 @@
+package profile
+
+import "testing"
+
+func TestDetectSource(t *testing.T) {
+	for _, tc := range []struct {
+		vars               map[string]string
+		branch, commit, ci string
+	}{
+		{nil, "", "", ""},
+		{map[string]string{"GITHUB_REF_NAME": "main", "GITHUB_SHA": "abc"}, "main", "abc", "github-actions"},
+		{map[string]string{"GITHUB_HEAD_REF": "feature", "GITHUB_REF_NAME": "12/merge", "GITHUB_SHA": "abc"}, "feature", "abc", "github-actions"},
+		{map[string]string{"CI_COMMIT_REF_NAME": "main", "CI_COMMIT_SHA": "abc"}, "main", "abc", "gitlab-ci"},
+		{map[string]string{"BUILDKITE_BRANCH": "main", "BUILDKITE_COMMIT": "abc"}, "main", "abc", "buildkite"},
+		{map[string]string{"GIT_BRANCH": "origin/release/1", "GIT_COMMIT": "abc"}, "release/1", "abc", "jenkins"},
+		{map[string]string{"CIRCLE_SHA1": "abc"}, "", "abc", "circleci"},
+	} {
+		branch, commit, ci := DetectSource(env(tc.vars))
+		if branch != tc.branch || commit != tc.commit || ci != tc.ci {
+			t.Errorf("DetectSource(%v) = %q, %q, %q", tc.vars, branch, commit, ci)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package provenance
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fasterci/rules_gitops/gitops/manifests"
+)
+
+const (
+	// StatementType is the in-toto statement type
+	StatementType = "https://in-toto.io/Statement/v1"
+	// PredicateType is the SLSA provenance predicate type
+	PredicateType = "https://slsa.dev/provenance/v1"
+	// BuildType identifies how deployment manifests were produced
+	BuildType = "https://github.com/fasterci/rules_gitops/create_gitops_prs@v1"
+)
+
+// ResourceDescriptor is an in-toto resource descriptor
+type ResourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// Statement is an in-toto statement with SLSA provenance predicate
+type Statement struct {
+	Type          string               `json:"_type"`
+	Subject       []ResourceDescriptor `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     Predicate            `json:"predicate"`
+}
+
+// Predicate is the SLSA v1 provenance predicate
+type Predicate struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   ExternalParameters   `json:"externalParameters"`
+	ResolvedDependencies []ResourceDescriptor `json:"resolvedDependencies"`
+}
+
+type ExternalParameters struct {
+	ReleaseBranch string   `json:"releaseBranch"`
+	ReleaseTrain  string   `json:"releaseTrain"`
+	SourceBranch  string   `json:"sourceBranch"`
+	Targets       []string `json:"targets"`
+}
+
+type RunDetails struct {
+	Builder  Builder  `json:"builder"`
+	Metadata Metadata `json:"metadata"`
+}
+
+type Builder struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+type Metadata struct {
+	InvocationID string    `json:"invocationId,omitempty"`
+	StartedOn    time.Time `json:"startedOn"`
+	FinishedOn   time.Time `json:"finishedOn"`
+}
+
+// Deployment describes how the manifests of a deployment commit were produced
+type Deployment struct {
+	ReleaseTrain  string
+	ReleaseBranch string
+	SourceRepo    string
+	SourceBranch  string
+	SourceCommit  string
+	Targets       []string
+	// Images are image references used by the manifests
+	Images []string
+	// Files maps manifest file paths to their sha256 hex digest
+	Files        map[string]string
+	BuilderID    string
+	ToolVersion  string
+	InvocationID string
+	StartedOn    time.Time
+	FinishedOn   time.Time
+}
+
+// Generate creates a SLSA provenance statement for the deployment
+func Generate(d Deployment) *Statement {
+	st := &Statement{
+		Type:          StatementType,
+		PredicateType: PredicateType,
+		Subject:       []ResourceDescriptor{},
+	}
+	var files []string
+	for f := range d.Files {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		st.Subject = append(st.Subject, ResourceDescriptor{Name: f, Digest: map[string]string{"sha256": d.Files[f]}})
+	}
+	deps := []ResourceDescriptor{{
+		URI:    "git+" + d.SourceRepo + "@" + d.SourceBranch,
+		Digest: map[string]string{"gitCommit": d.SourceCommit},
+	}}
+	for _, img := range d.Images {
+		rd := ResourceDescriptor{URI: img}
+		if _, _, digest := manifests.SplitImage(img); digest != "" {
+			if alg, hex, found := strings.Cut(digest, ":"); found {
+				rd.Digest = map[string]string{alg: hex}
+			}
+		}
+		deps = append(deps, rd)
+	}
+	st.Predicate = Predicate{
+		BuildDefinition: BuildDefinition{
+			BuildType: BuildType,
+			ExternalParameters: ExternalParameters{
+				ReleaseBranch: d.ReleaseBranch,
+				ReleaseTrain:  d.ReleaseTrain,
+				SourceBranch:  d.SourceBranch,
+				Targets:       d.Targets,
+			},
+			ResolvedDependencies: deps,
+		},
+		RunDetails: RunDetails{
+			Builder: Builder{
+				ID:      d.BuilderID,
+				Version: map[string]string{"create_gitops_prs": d.ToolVersion},
+			},
+			Metadata: Metadata{
+				InvocationID: d.InvocationID,
+				StartedOn:    d.StartedOn.UTC(),
+				FinishedOn:   d.FinishedOn.UTC(),
+			},
+		},
+	}
+	return st
+}
+
+// Marshal serializes the statement as indented json
+func (st *Statement) Marshal() ([]byte, error) {
+	b, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
@@ -0,0 +1,49 @@
+package provenance
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	st := Generate(Deployment{
+		ReleaseTrain:  "prod",
+		ReleaseBranch: "master",
+		SourceRepo:    "https://github.com/example/repo.git",
+		SourceBranch:  "master",
+		SourceCommit:  "0123456789abcdef",
+		Targets:       []string{"//app:prod.gitops"},
+		Images:        []string{"gcr.io/p/app@sha256:abcd", "busybox:1.36"},
+		Files:         map[string]string{"cloud/prod/app.yaml": "ff00"},
+		BuilderID:     "https://ci.example.com",
+		ToolVersion:   "v1.0.0",
+		StartedOn:     start,
+		FinishedOn:    start.Add(time.Minute),
+	})
+	b, err := st.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Statement
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Subject[0].Name != "cloud/prod/app.yaml" || decoded.Subject[0].Digest["sha256"] != "ff00" {
+		t.Error("unexpected subject", decoded.Subject)
+	}
+	deps := decoded.Predicate.BuildDefinition.ResolvedDependencies
+	if len(deps) != 3 {
+		t.Fatal("unexpected dependencies", deps)
+	}
+	if deps[0].URI != "git+https://github.com/example/repo.git@master" || deps[0].Digest["gitCommit"] != "0123456789abcdef" {
+		t.Error("unexpected source dependency", deps[0])
+	}
+	if deps[1].Digest["sha256"] != "abcd" {
+		t.Error("unexpected image digest", deps[1])
+	}
+	if deps[2].Digest != nil {
+		t.Error("unexpected digest of image without digest", deps[2])
+	}
+}
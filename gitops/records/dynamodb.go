@@ -0,0 +1,204 @@
+package records
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fasterci/rules_gitops/gitops/clock"
+)
+
+// Credentials are AWS access keys
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// EnvCredentials returns credentials from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+// environment variables
+func EnvCredentials() Credentials {
+	return Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// DynamoDB records deployments as items of a DynamoDB table with the string partition key train and
+// the string sort key id. The id is the RFC 3339 time the deployment was recorded, the run id and the deployment
+// branch separated by #, so items of a train are ordered by time and per-target branches of a run do not collide.
+type DynamoDB struct {
+	Table  string
+	Region string
+	// URL of the endpoint, default is https://dynamodb.<region>.amazonaws.com
+	URL         string
+	Credentials Credentials
+	// Clock signs requests, default is clock.Real
+	Clock clock.Clock
+}
+
+type attributeValue struct {
+	S *string          `json:"S,omitempty"`
+	L []attributeValue `json:"L,omitempty"`
+}
+
+func str(s string) attributeValue {
+	return attributeValue{S: &s}
+}
+
+func list(values []string) attributeValue {
+	l := make([]attributeValue, len(values))
+	for i, v := range values {
+		l[i] = str(v)
+	}
+	return attributeValue{L: l}
+}
+
+// item returns attributes of the record. Empty attributes are omitted.
+func item(r Record) map[string]attributeValue {
+	recorded := r.RecordedAt.UTC().Format(time.RFC3339Nano)
+	it := map[string]attributeValue{
+		"train":       str(r.Train),
+		"id":          str(recorded + "#" + r.RunID + "#" + r.Branch),
+		"run_id":      str(r.RunID),
+		"recorded_at": str(recorded),
+		"started_at":  str(r.StartedAt.UTC().Format(time.RFC3339Nano)),
+	}
+	for name, v := range map[string]string{
+		"branch":         r.Branch,
+		"pr_url":         r.PRURL,
+		"release_branch": r.ReleaseBranch,
+		"source_branch":  r.SourceBranch,
+		"source_commit":  r.SourceCommit,
+	} {
+		if v != "" {
+			it[name] = str(v)
+		}
+	}
+	if len(r.Targets) > 0 {
+		it["targets"] = list(r.Targets)
+	}
+	if len(r.Images) > 0 {
+		it["images"] = list(r.Images)
+	}
+	return it
+}
+
+// Put writes every record with a PutItem request
+func (d DynamoDB) Put(ctx context.Context, records []Record) error {
+	for _, r := range records {
+		req := map[string]interface{}{
+			"TableName": d.Table,
+			"Item":      item(r),
+		}
+		if err := d.call(ctx, "PutItem", req); err != nil {
+			return fmt.Errorf("unable to record branch %s: %w", r.Branch, err)
+		}
+	}
+	return nil
+}
+
+func (d DynamoDB) call(ctx context.Context, action string, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	url := d.URL
+	if url == "" {
+		url = fmt.Sprintf("https://dynamodb.%s.amazonaws.com", d.Region)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", "DynamoDB_20120810."+action)
+	clk := d.Clock
+	if clk == nil {
+		clk = clock.Real
+	}
+	sign(req, body, d.Credentials, d.Region, "dynamodb", clk.Now())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("dynamodb %s: %s: %s", action, resp.Status, b)
+	}
+	return nil
+}
+
+// sign adds AWS Signature Version 4 headers to a request without query parameters
+func sign(req *http.Request, body []byte, creds Credentials, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hexSHA256(body),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hexSHA256([]byte(canonicalRequest))
+	signature := hex.EncodeToString(hmacSHA256(signingKey(creds.SecretAccessKey, date, region, service), stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func signingKey(secret, date, region, service string) []byte {
+	k := hmacSHA256([]byte("AWS4"+secret), date)
+	k = hmacSHA256(k, region)
+	k = hmacSHA256(k, service)
+	return hmacSHA256(k, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hexSHA256(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,85 @@
+package records
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fasterci/rules_gitops/gitops/clock"
+)
+
+func TestSigningKey(t *testing.T) {
+	// example from the AWS Signature Version 4 documentation
+	k := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	if s := hex.EncodeToString(k); s != "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d" {
+		t.Errorf("unexpected signing key %s", s)
+	}
+}
+
+func TestDynamoDB(t *testing.T) {
+	var target, auth, token, date string
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = r.Header.Get("X-Amz-Target")
+		auth = r.Header.Get("Authorization")
+		token = r.Header.Get("X-Amz-Security-Token")
+		date = r.Header.Get("X-Amz-Date")
+		b, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(b, &body); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer ts.Close()
+
+	d := DynamoDB{
+		Table:       "deployments",
+		Region:      "us-east-1",
+		URL:         ts.URL,
+		Credentials: Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "token"},
+		Clock:       clock.NewFake(time.Date(2024, 3, 1, 10, 6, 0, 0, time.UTC)),
+	}
+	if err := d.Put(context.Background(), []Record{testRecord}); err != nil {
+		t.Fatal(err)
+	}
+	if target != "DynamoDB_20120810.PutItem" || token != "token" || date != "20240301T100600Z" {
+		t.Errorf("unexpected headers %q %q %q", target, token, date)
+	}
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/20240301/us-east-1/dynamodb/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target, Signature=") {
+		t.Errorf("unexpected authorization %s", auth)
+	}
+	s := func(v string) interface{} { return map[string]interface{}{"S": v} }
+	expected := map[string]interface{}{
+		"TableName": "deployments",
+		"Item": map[string]interface{}{
+			"train":          s("prod"),
+			"id":             s("2024-03-01T10:05:00Z#r1#deploy/prod"),
+			"run_id":         s("r1"),
+			"recorded_at":    s("2024-03-01T10:05:00Z"),
+			"started_at":     s("2024-03-01T10:00:00Z"),
+			"branch":         s("deploy/prod"),
+			"pr_url":         s("https://github.com/org/repo/pull/7"),
+			"release_branch": s("master"),
+			"source_branch":  s("master"),
+			"source_commit":  s("abc"),
+			"targets":        map[string]interface{}{"L": []interface{}{s("//app:prod.gitops"), s("//app:worker.gitops")}},
+			"images":         map[string]interface{}{"L": []interface{}{s("registry/app@sha256:0123")}},
+		},
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("unexpected request %v", body)
+	}
+
+	ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"__type":"ResourceNotFoundException"}`, http.StatusBadRequest)
+	})
+	if err := d.Put(context.Background(), []Record{testRecord}); err == nil || !strings.Contains(err.Error(), "ResourceNotFoundException") {
+		t.Errorf("expected error, got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+// Package records stores deployments proposed by create_gitops_prs runs in an external store for audit queries,
+// like when an image was first proposed to a release train.
+package records
+
+import (
+	"context"
+	"time"
+)
+
+// Record is a deployment of a release train proposed by a run
+type Record struct {
+	// RunID identifies the run, see -run_id
+	RunID string
+	Train string
+	// Branch is the deployment branch the train was committed to
+	Branch string
+	// Targets are gitops targets of the train
+	Targets []string
+	// Images are images referenced by the changed manifests, with digests when the manifests are resolved
+	Images []string
+	// PRURL is the web link of the deployment PR, empty if it is unknown
+	PRURL         string
+	ReleaseBranch string
+	SourceBranch  string
+	SourceCommit  string
+	// StartedAt is when the run started, RecordedAt when the deployment PR was opened
+	StartedAt  time.Time
+	RecordedAt time.Time
+}
+
+// Store records deployments
+type Store interface {
+	Put(ctx context.Context, records []Record) error
+}
@@ -0,0 +1,123 @@
+package records
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// columns of the SQL table in the order of insert arguments
+var columns = []string{
+	"run_id",
+	"train",
+	"branch",
+	"targets",
+	"images",
+	"pr_url",
+	"release_branch",
+	"source_branch",
+	"source_commit",
+	"started_at",
+	"recorded_at",
+}
+
+// SQL records deployments as rows of a database/sql table, one row per deployment branch and run.
+// Targets and images are stored newline separated, so
+//
+//	SELECT MIN(recorded_at) FROM gitops_deployments WHERE train = 'prod' AND images LIKE '%registry/app@sha256:...%'
+//
+// tells when an image was first proposed to prod.
+type SQL struct {
+	DB *sql.DB
+	// Table name, default is gitops_deployments
+	Table string
+	// Placeholder returns the bind parameter for the n-th argument, starting with 1.
+	// Default is ?, use Dollar for PostgreSQL.
+	Placeholder func(n int) string
+}
+
+// Dollar is the PostgreSQL placeholder style: $1, $2...
+func Dollar(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+func (s SQL) table() string {
+	if s.Table == "" {
+		return "gitops_deployments"
+	}
+	return s.Table
+}
+
+// Schema returns the CREATE TABLE statement of the table
+func (s SQL) Schema() string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	run_id VARCHAR(64) NOT NULL,
+	train VARCHAR(255) NOT NULL,
+	branch VARCHAR(255) NOT NULL,
+	targets TEXT NOT NULL,
+	images TEXT NOT NULL,
+	pr_url VARCHAR(1024) NOT NULL,
+	release_branch VARCHAR(255) NOT NULL,
+	source_branch VARCHAR(255) NOT NULL,
+	source_commit VARCHAR(64) NOT NULL,
+	started_at TIMESTAMP NOT NULL,
+	recorded_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (run_id, branch)
+)`, s.table())
+}
+
+// CreateTable creates the table if it does not exist
+func (s SQL) CreateTable(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx, s.Schema())
+	return err
+}
+
+func (s SQL) insert() string {
+	placeholder := s.Placeholder
+	if placeholder == nil {
+		placeholder = func(int) string { return "?" }
+	}
+	params := make([]string, len(columns))
+	for i := range columns {
+		params[i] = placeholder(i + 1)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", s.table(), strings.Join(columns, ", "), strings.Join(params, ", "))
+}
+
+// Put inserts records in a single transaction
+func (s SQL) Put(ctx context.Context, records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	stmt, err := tx.PrepareContext(ctx, s.insert())
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, r := range records {
+		_, err := stmt.ExecContext(ctx,
+			r.RunID,
+			r.Train,
+			r.Branch,
+			strings.Join(r.Targets, "\n"),
+			strings.Join(r.Images, "\n"),
+			r.PRURL,
+			r.ReleaseBranch,
+			r.SourceBranch,
+			r.SourceCommit,
+			r.StartedAt.UTC(),
+			r.RecordedAt.UTC(),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to record branch %s: %w", r.Branch, err)
+		}
+	}
+	return tx.Commit()
+}
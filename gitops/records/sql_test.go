@@ -0,0 +1,99 @@
+package records
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// execLog is a database/sql driver recording executed statements
+type execLog struct {
+	stmts     []string
+	args      [][]driver.Value
+	committed bool
+}
+
+func (l *execLog) Open(string) (driver.Conn, error) { return l, nil }
+func (l *execLog) Prepare(query string) (driver.Stmt, error) {
+	return &logStmt{log: l, query: query}, nil
+}
+func (l *execLog) Close() error              { return nil }
+func (l *execLog) Begin() (driver.Tx, error) { return l, nil }
+func (l *execLog) Commit() error             { l.committed = true; return nil }
+func (l *execLog) Rollback() error           { return nil }
+
+type logStmt struct {
+	log   *execLog
+	query string
+}
+
+func (s *logStmt) Close() error  { return nil }
+func (s *logStmt) NumInput() int { return -1 }
+func (s *logStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.log.stmts = append(s.log.stmts, s.query)
+	s.log.args = append(s.log.args, args)
+	return driver.RowsAffected(1), nil
+}
+func (s *logStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+var testRecord = Record{
+	RunID:         "r1",
+	Train:         "prod",
+	Branch:        "deploy/prod",
+	Targets:       []string{"//app:prod.gitops", "//app:worker.gitops"},
+	Images:        []string{"registry/app@sha256:0123"},
+	PRURL:         "https://github.com/org/repo/pull/7",
+	ReleaseBranch: "master",
+	SourceBranch:  "master",
+	SourceCommit:  "abc",
+	StartedAt:     time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+	RecordedAt:    time.Date(2024, 3, 1, 10, 5, 0, 0, time.UTC),
+}
+
+func TestSQL(t *testing.T) {
+	log := &execLog{}
+	sql.Register("records_test", log)
+	db, err := sql.Open("records_test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	s := SQL{DB: db, Placeholder: Dollar}
+	if err := s.CreateTable(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(log.stmts[0], "CREATE TABLE IF NOT EXISTS gitops_deployments (") {
+		t.Errorf("unexpected schema %s", log.stmts[0])
+	}
+	// per-target branches of a train are recorded in the same run
+	if !strings.Contains(log.stmts[0], "PRIMARY KEY (run_id, branch)") {
+		t.Errorf("unexpected primary key in %s", log.stmts[0])
+	}
+	if err := s.Put(context.Background(), []Record{testRecord}); err != nil {
+		t.Fatal(err)
+	}
+	if !log.committed {
+		t.Error("records are not committed")
+	}
+	expected := "INSERT INTO gitops_deployments (run_id, train, branch, targets, images, pr_url, release_branch, source_branch, source_commit, started_at, recorded_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)"
+	if log.stmts[1] != expected {
+		t.Errorf("unexpected insert %s", log.stmts[1])
+	}
+	args := []driver.Value{"r1", "prod", "deploy/prod", "//app:prod.gitops\n//app:worker.gitops", "registry/app@sha256:0123",
+		"https://github.com/org/repo/pull/7", "master", "master", "abc", testRecord.StartedAt, testRecord.RecordedAt}
+	if !reflect.DeepEqual(log.args[1], args) {
+		t.Errorf("unexpected args %v", log.args[1])
+	}
+
+	if q := (SQL{Table: "audit"}).insert(); !strings.HasPrefix(q, "INSERT INTO audit (") || !strings.HasSuffix(q, "VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)") {
+		t.Errorf("unexpected default insert %s", q)
+	}
+}
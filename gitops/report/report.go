@@ -0,0 +1,105 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Severity of a report entry
+type Severity string
+
+const (
+	// Warning is a problem that did not change the outcome of the run
+	Warning Severity = "warning"
+	// Error is a problem that made a part of the run fail
+	Error Severity = "error"
+)
+
+// Entry is a single non-fatal problem collected during the run
+type Entry struct {
+	Severity Severity `json:"severity"`
+	// Phase is the part of the run the entry belongs to, like "discovery", "push" or "pr"
+	Phase string `json:"phase"`
+	// Subject is the train, target or branch the entry is about
+	Subject string `json:"subject,omitempty"`
+	Message string `json:"message"`
+}
+
+// Report collects non-fatal problems so they can be presented together at the end of the run.
+// It is safe for concurrent use.
+type Report struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+func (r *Report) add(e Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, e)
+}
+
+// Warnf records a warning for subject in phase
+func (r *Report) Warnf(phase, subject, format string, args ...interface{}) {
+	r.add(Entry{Severity: Warning, Phase: phase, Subject: subject, Message: fmt.Sprintf(format, args...)})
+}
+
+// Error records a failure for subject in phase
+func (r *Report) Error(phase, subject string, err error) {
+	r.add(Entry{Severity: Error, Phase: phase, Subject: subject, Message: err.Error()})
+}
+
+// Entries returns a copy of collected entries in the order they were recorded
+func (r *Report) Entries() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Entry(nil), r.entries...)
+}
+
+// HasErrors returns true if at least one entry has Error severity
+func (r *Report) HasErrors() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.entries {
+		if e.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// Print writes entries grouped by phase and severity. Nothing is written for an empty report.
+func (r *Report) Print(w io.Writer) {
+	entries := r.Entries()
+	if len(entries) == 0 {
+		return
+	}
+	// errors first, then phases in the order of first appearance
+	order := make(map[string]int)
+	for _, e := range entries {
+		if _, ok := order[e.Phase]; !ok {
+			order[e.Phase] = len(order)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Severity != entries[j].Severity {
+			return entries[i].Severity == Error
+		}
+		return order[entries[i].Phase] < order[entries[j].Phase]
+	})
+	fmt.Fprintf(w, "=== %d problem(s) reported during the run ===\n", len(entries))
+	var sev Severity
+	phase := ""
+	for i, e := range entries {
+		if i == 0 || e.Severity != sev || e.Phase != phase {
+			sev, phase = e.Severity, e.Phase
+			fmt.Fprintf(w, "%s [%s]:\n", sev, phase)
+		}
+		if e.Subject != "" {
+			fmt.Fprintf(w, "  %s: %s\n", e.Subject, e.Message)
+		} else {
+			fmt.Fprintf(w, "  %s\n", e.Message)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPrintGroupsByPhase(t *testing.T) {
+	var r Report
+	r.Warnf("push", "//a:push", "not a file, running as a command")
+	r.Error("pr", "deploy/prod", errors.New("boom"))
+	r.Warnf("discovery", "", "no targets for train %s", "dev")
+	r.Warnf("push", "//b:push", "not a file, running as a command")
+
+	var sb strings.Builder
+	r.Print(&sb)
+	expected := `=== 4 problem(s) reported during the run ===
+error [pr]:
+  deploy/prod: boom
+warning [push]:
+  //a:push: not a file, running as a command
+  //b:push: not a file, running as a command
+warning [discovery]:
+  no targets for train dev
+`
+	if sb.String() != expected {
+		t.Errorf("unexpected report:\n%s", sb.String())
+	}
+	if !r.HasErrors() {
+		t.Error("expected HasErrors to be true")
+	}
+}
+
+func TestEmptyReport(t *testing.T) {
+	var r Report
+	var sb strings.Builder
+	r.Print(&sb)
+	if sb.Len() != 0 {
+		t.Errorf("expected no output, got %q", sb.String())
+	}
+	if r.HasErrors() {
+		t.Error("expected HasErrors to be false")
+	}
+}
@@ -0,0 +1,152 @@
+// Package sarif writes findings of manifest checks in the SARIF 2.1.0 format read by code scanning dashboards
+package sarif
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+)
+
+// Levels of results
+const (
+	Error   = "error"
+	Warning = "warning"
+)
+
+// Result is a finding of a check in a file
+type Result struct {
+	// RuleID identifies the check, like image-policy
+	RuleID  string
+	Level   string
+	Message string
+	// File is the slash separated path of the file relative to the repository root
+	File string
+	// Line is the 1-based line of the finding, 0 if unknown
+	Line int
+	// Targets are the bazel targets the file is generated from, recorded as logical locations
+	Targets []string
+	// Properties are additional details, like the release train
+	Properties map[string]string
+}
+
+// Log collects results of a tool run
+type Log struct {
+	Tool    string
+	Version string
+	// URI is the web page of the tool
+	URI string
+	// Rules describe rule ids of results
+	Rules   map[string]string
+	Results []Result
+}
+
+// Add appends a result
+func (l *Log) Add(r Result) {
+	l.Results = append(l.Results, r)
+}
+
+type message struct {
+	Text string `json:"text"`
+}
+
+type rule struct {
+	ID               string  `json:"id"`
+	ShortDescription message `json:"shortDescription"`
+}
+
+type driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []rule `json:"rules"`
+}
+
+type region struct {
+	StartLine int `json:"startLine"`
+}
+
+type artifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type physicalLocation struct {
+	ArtifactLocation artifactLocation `json:"artifactLocation"`
+	Region           *region          `json:"region,omitempty"`
+}
+
+type logicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type location struct {
+	PhysicalLocation physicalLocation  `json:"physicalLocation"`
+	LogicalLocations []logicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type result struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    message           `json:"message"`
+	Locations  []location        `json:"locations"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type run struct {
+	Tool struct {
+		Driver driver `json:"driver"`
+	} `json:"tool"`
+	Results []result `json:"results"`
+}
+
+type sarifLog struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []run  `json:"runs"`
+}
+
+// MarshalJSON returns the log as a SARIF document with a single run
+func (l *Log) MarshalJSON() ([]byte, error) {
+	var r run
+	r.Tool.Driver = driver{Name: l.Tool, Version: l.Version, InformationURI: l.URI, Rules: []rule{}}
+	ids := make([]string, 0, len(l.Rules))
+	for id := range l.Rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		r.Tool.Driver.Rules = append(r.Tool.Driver.Rules, rule{ID: id, ShortDescription: message{l.Rules[id]}})
+	}
+	r.Results = []result{}
+	for _, res := range l.Results {
+		loc := location{PhysicalLocation: physicalLocation{ArtifactLocation: artifactLocation{URI: res.File, URIBaseID: "%SRCROOT%"}}}
+		if res.Line > 0 {
+			loc.PhysicalLocation.Region = &region{StartLine: res.Line}
+		}
+		for _, t := range res.Targets {
+			loc.LogicalLocations = append(loc.LogicalLocations, logicalLocation{FullyQualifiedName: t, Kind: "module"})
+		}
+		r.Results = append(r.Results, result{
+			RuleID:     res.RuleID,
+			Level:      res.Level,
+			Message:    message{res.Message},
+			Locations:  []location{loc},
+			Properties: res.Properties,
+		})
+	}
+	return json.Marshal(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []run{r},
+	})
+}
+
+// WriteFile writes the SARIF document to path
+func (l *Log) WriteFile(path string) error {
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
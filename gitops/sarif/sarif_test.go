@@ -0,0 +1,67 @@
+package sarif
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	l := &Log{
+		Tool:    "create_gitops_prs",
+		Version: "v1.0.0",
+		Rules:   map[string]string{"image-policy": "images must satisfy the image policy", "secret-scan/private-key": "private key"},
+	}
+	l.Add(Result{
+		RuleID:     "image-policy",
+		Level:      Error,
+		Message:    "image registry/app:latest: tag latest is denied",
+		File:       "cloud/prod/app.yaml",
+		Line:       12,
+		Targets:    []string{"//app:prod.gitops"},
+		Properties: map[string]string{"train": "prod"},
+	})
+	l.Add(Result{RuleID: "secret-scan/private-key", Level: Warning, Message: "possible secret", File: "cloud/dev/secret.yaml"})
+	path := filepath.Join(t.TempDir(), "report.sarif")
+	if err := l.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["version"] != "2.1.0" {
+		t.Errorf("unexpected version %v", doc["version"])
+	}
+	run := doc["runs"].([]interface{})[0].(map[string]interface{})
+	rules := run["tool"].(map[string]interface{})["driver"].(map[string]interface{})["rules"].([]interface{})
+	if len(rules) != 2 || rules[0].(map[string]interface{})["id"] != "image-policy" {
+		t.Errorf("unexpected rules %v", rules)
+	}
+	results := run["results"].([]interface{})
+	expected := map[string]interface{}{
+		"ruleId":  "image-policy",
+		"level":   "error",
+		"message": map[string]interface{}{"text": "image registry/app:latest: tag latest is denied"},
+		"locations": []interface{}{map[string]interface{}{
+			"physicalLocation": map[string]interface{}{
+				"artifactLocation": map[string]interface{}{"uri": "cloud/prod/app.yaml", "uriBaseId": "%SRCROOT%"},
+				"region":           map[string]interface{}{"startLine": float64(12)},
+			},
+			"logicalLocations": []interface{}{map[string]interface{}{"fullyQualifiedName": "//app:prod.gitops", "kind": "module"}},
+		}},
+		"properties": map[string]interface{}{"train": "prod"},
+	}
+	if !reflect.DeepEqual(results[0], expected) {
+		t.Errorf("unexpected result %v", results[0])
+	}
+	if _, ok := results[1].(map[string]interface{})["locations"].([]interface{})[0].(map[string]interface{})["physicalLocation"].(map[string]interface{})["region"]; ok {
+		t.Error("expected no region without a line")
+	}
+}
@@ -0,0 +1,114 @@
+package secretscan
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Rule is a named pattern of a secret
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// DefaultRules are well known credential formats
+var DefaultRules = []Rule{
+	{"aws-access-key-id", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"aws-secret-access-key", regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}`)},
+	{"private-key", regexp.MustCompile(`-----BEGIN ([A-Z]+ )?PRIVATE KEY( BLOCK)?-----`)},
+	{"github-token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"slack-token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`)},
+	{"gcp-service-account-key", regexp.MustCompile(`"type":\s*"service_account"`)},
+}
+
+// Finding is a secret found in a file
+type Finding struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Rule string `json:"rule"`
+	// Match is the redacted matching text
+	Match string `json:"match"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: %s (%s)", f.File, f.Line, f.Rule, f.Match)
+}
+
+// Scanner looks for secrets in text files
+type Scanner struct {
+	Rules []Rule
+	// EntropyThreshold enables reporting of high entropy strings if greater than zero.
+	// Random base64 encoded keys have entropy around 5-6 bits per character, sha256 hex digests have at most 4.
+	EntropyThreshold float64
+	// EntropyMinLength is the minimal length of a string checked for entropy
+	EntropyMinLength int
+}
+
+// candidate tokens for entropy check: base64 and hex alphabets
+var tokenRe = regexp.MustCompile(`[A-Za-z0-9+/=_-]+`)
+
+// ScanFile scans a file
+func (s *Scanner) ScanFile(path string) ([]Finding, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return s.Scan(path, f)
+}
+
+// Scan scans content of r, file is used to report findings
+func (s *Scanner) Scan(file string, r io.Reader) ([]Finding, error) {
+	var findings []Finding
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	lineno := 0
+	for scanner.Scan() {
+		lineno++
+		line := scanner.Text()
+		for _, rule := range s.Rules {
+			if m := rule.Pattern.FindString(line); m != "" {
+				findings = append(findings, Finding{File: file, Line: lineno, Rule: rule.Name, Match: redact(m)})
+			}
+		}
+		if s.EntropyThreshold <= 0 {
+			continue
+		}
+		for _, token := range tokenRe.FindAllString(line, -1) {
+			if len(token) >= s.EntropyMinLength && entropy(token) >= s.EntropyThreshold {
+				findings = append(findings, Finding{File: file, Line: lineno, Rule: "high-entropy-string", Match: redact(token)})
+			}
+		}
+	}
+	return findings, scanner.Err()
+}
+
+// entropy returns Shannon entropy of s in bits per character
+func entropy(s string) float64 {
+	freq := make(map[rune]float64)
+	n := 0.0
+	for _, r := range s {
+		freq[r]++
+		n++
+	}
+	var e float64
+	for _, c := range freq {
+		p := c / n
+		e -= p * math.Log2(p)
+	}
+	return e
+}
+
+// redact keeps only a short prefix of a secret so reports don't leak it
+func redact(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= 8 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:4] + strings.Repeat("*", 8)
+}
@@ -0,0 +1,140 @@
+package trains
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// TargetBranchSeparator separates the release train from the target path in per-target branch names.
+// It is not /, so per-target branches never nest under the release train branch, which git does not allow:
+// deploy/prod/services/api/prod could not exist next to deploy/prod. Train names must not contain it.
+const TargetBranchSeparator = "--"
+
+// Branches names deployment branches of release trains
+type Branches struct {
+	// Prefix and Suffix surround release train names, like deploy/ and an empty suffix
+	Prefix string
+	Suffix string
+	// PerTarget commits every gitops target of a train to its own branch <prefix><train>--<target path><suffix>
+	PerTarget bool
+}
+
+// Unit is a set of gitops targets of a release train committed to one deployment branch
+type Unit struct {
+	Train   string
+	Targets []string
+	Branch  string
+	// Target is the gitops target of a per-target branch, empty for release train branches
+	Target string
+}
+
+// Units returns deployment branches of release trains in order: one per train, or one per gitops target with
+// PerTarget. It fails if targets of a train would get the same branch or branches git can not have at the same time,
+// and with PerTarget for trains with the separator in their name, whose branches would be mistaken for per-target
+// branches of another train.
+func (b Branches) Units(order []string, releaseTrains map[string][]string) ([]Unit, error) {
+	var units []Unit
+	for _, train := range order {
+		targets := releaseTrains[train]
+		if !b.PerTarget {
+			units = append(units, Unit{Train: train, Targets: targets, Branch: b.Prefix + train + b.Suffix})
+			continue
+		}
+		if strings.Contains(train, TargetBranchSeparator) {
+			return nil, fmt.Errorf("release train %s can not have per-target deployment branches: %s separates the train from the target path in their names", train, TargetBranchSeparator)
+		}
+		seen := make(map[string]string)
+		var paths []string
+		for _, target := range targets {
+			p := TargetPath(target)
+			if prev, ok := seen[p]; ok {
+				return nil, fmt.Errorf("gitops targets %s and %s of train %s have the same deployment branch path %s", prev, target, train, p)
+			}
+			seen[p] = target
+			paths = append(paths, p)
+			units = append(units, Unit{
+				Train:   train,
+				Targets: []string{target},
+				Branch:  b.Prefix + train + TargetBranchSeparator + p + b.Suffix,
+				Target:  target,
+			})
+		}
+		if parent, child, ok := PathConflict(paths); ok {
+			return nil, fmt.Errorf("gitops targets %s and %s of train %s can not have deployment branches at the same time: git does not allow branch %s under branch %s",
+				seen[parent], seen[child], train, child, parent)
+		}
+	}
+	return units, nil
+}
+
+// IsTrainBranch reports whether branch is the deployment branch of train or, with PerTarget,
+// one of the per-target branches of the train
+func (b Branches) IsTrainBranch(branch, train string) bool {
+	rest, ok := strings.CutPrefix(branch, b.Prefix+train)
+	if !ok {
+		return false
+	}
+	rest, ok = strings.CutSuffix(rest, b.Suffix)
+	if !ok {
+		return false
+	}
+	return rest == "" || (b.PerTarget && strings.HasPrefix(rest, TargetBranchSeparator))
+}
+
+// TargetPath returns the path of a gitops target used in per-target deployment branch names,
+// like services/api/prod for //services/api:prod.gitops. Characters git does not allow in branch names are
+// replaced with -. Resolved gitops binaries are named by their path in bazel-bin, binaries outside of bazel-bin
+// given by an absolute path by their file name, so the location of the checkout never ends up in branch names.
+func TargetPath(target string) string {
+	t := strings.ReplaceAll(target, `\`, "/")
+	if !strings.HasPrefix(t, "//") && !strings.HasPrefix(t, "@") {
+		if i := strings.LastIndex("/"+t, "/bazel-bin/"); i >= 0 {
+			t = t[i+len("bazel-bin/"):]
+		} else if strings.HasPrefix(t, "/") || (len(t) > 1 && t[1] == ':') {
+			t = path.Base(t)
+		}
+	}
+	t = strings.TrimPrefix(t, "@")
+	t = strings.ReplaceAll(t, ":", "/")
+	t = strings.TrimLeft(t, "/")
+	t = strings.TrimSuffix(t, ".exe")
+	t = strings.TrimSuffix(t, ".gitops")
+	var parts []string
+	for _, p := range strings.Split(t, "/") {
+		p = strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+				return r
+			case strings.ContainsRune("._-+=", r):
+				return r
+			}
+			return '-'
+		}, p)
+		for strings.Contains(p, "..") {
+			p = strings.ReplaceAll(p, "..", ".")
+		}
+		p = strings.TrimSuffix(strings.TrimLeft(p, "."), ".lock")
+		p = strings.TrimRight(p, ".")
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// PathConflict returns two of paths git can not have branches for at the same time, because one is a prefix
+// directory of the other like services/api and services/api/prod, and ok true if there are any
+func PathConflict(paths []string) (parent, child string, ok bool) {
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+	for i := 0; i < len(sorted); i++ {
+		for j := i + 1; j < len(sorted) && strings.HasPrefix(sorted[j], sorted[i]); j++ {
+			if strings.HasPrefix(sorted[j], sorted[i]+"/") {
+				return sorted[i], sorted[j], true
+			}
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,83 @@
+package trains
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTargetPath(t *testing.T) {
+	tests := map[string]string{
+		"//services/api:prod.gitops":          "services/api/prod",
+		"//services/api:api":                  "services/api/api",
+		"@deps//services/api:prod.gitops":     "deps/services/api/prod",
+		"//:root.gitops":                      "root",
+		"bazel-bin/services/api/prod.gitops":  "services/api/prod",
+		"/abs/path/render.sh":                 "render.sh",
+		"/ws/bazel-bin/svc/prod.gitops":       "svc/prod",
+		"../bazel-bin/svc/prod.gitops":        "svc/prod",
+		`C:\ws\bazel-bin\svc\prod.gitops.exe`: "svc/prod",
+		`C:\tools\render.exe`:                 "render",
+		"tools/render.sh":                     "tools/render.sh",
+		"//app:weird name~1^..lock":           "app/weird-name-1-",
+		"//app/.hidden:x.lock":                "app/hidden/x",
+	}
+	for target, expected := range tests {
+		if p := TargetPath(target); p != expected {
+			t.Errorf("TargetPath(%q) = %q, want %q", target, p, expected)
+		}
+	}
+}
+
+func TestPathConflict(t *testing.T) {
+	if p, c, ok := PathConflict([]string{"services/api/prod", "services/api-gw", "services/api"}); !ok || p != "services/api" || c != "services/api/prod" {
+		t.Errorf("PathConflict() = %q, %q, %v", p, c, ok)
+	}
+	if p, c, ok := PathConflict([]string{"services/api", "services/api-gw/prod", "services/apis"}); ok {
+		t.Errorf("unexpected conflict %q, %q", p, c)
+	}
+}
+
+func TestUnits(t *testing.T) {
+	releaseTrains := map[string][]string{
+		"prod": {"//svc/api:prod.gitops", "//svc/web:prod.gitops"},
+		"dev":  {"//svc/api:dev.gitops"},
+	}
+	b := Branches{Prefix: "deploy/", Suffix: "-v2"}
+	units, err := b.Units([]string{"dev", "prod"}, releaseTrains)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Unit{
+		{Train: "dev", Targets: []string{"//svc/api:dev.gitops"}, Branch: "deploy/dev-v2"},
+		{Train: "prod", Targets: []string{"//svc/api:prod.gitops", "//svc/web:prod.gitops"}, Branch: "deploy/prod-v2"},
+	}
+	if !reflect.DeepEqual(units, expected) {
+		t.Errorf("unexpected units %+v", units)
+	}
+
+	b.PerTarget = true
+	units, err = b.Units([]string{"prod"}, releaseTrains)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []Unit{
+		{Train: "prod", Targets: []string{"//svc/api:prod.gitops"}, Branch: "deploy/prod--svc/api/prod-v2", Target: "//svc/api:prod.gitops"},
+		{Train: "prod", Targets: []string{"//svc/web:prod.gitops"}, Branch: "deploy/prod--svc/web/prod-v2", Target: "//svc/web:prod.gitops"},
+	}
+	if !reflect.DeepEqual(units, expected) {
+		t.Errorf("unexpected per-target units %+v", units)
+	}
+	if !b.IsTrainBranch("deploy/prod--svc/api/prod-v2", "prod") || !b.IsTrainBranch("deploy/prod-v2", "prod") || b.IsTrainBranch("deploy/prod-canary-v2", "prod") {
+		t.Error("unexpected IsTrainBranch result")
+	}
+
+	if _, err := b.Units([]string{"prod"}, map[string][]string{"prod": {"//svc:api.gitops", "//svc/api:prod.gitops"}}); err == nil {
+		t.Error("expected an error for nested per-target branches")
+	}
+	if _, err := b.Units([]string{"prod"}, map[string][]string{"prod": {"//svc:api.gitops", "//svc:api.lock"}}); err == nil {
+		t.Error("expected an error for the same per-target branch")
+	}
+	if _, err := b.Units([]string{"prod--eu"}, map[string][]string{"prod--eu": {"//svc/api:prod.gitops"}}); err == nil {
+		t.Error("expected an error for a train name with the per-target separator")
+	}
+}
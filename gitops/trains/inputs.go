@@ -0,0 +1,46 @@
+package trains
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Settings are the tool options the rendered manifests of a release train depend on besides the gitops binaries
+type Settings struct {
+	GitopsPath           string
+	StandardLabels       bool
+	ResourceLabels       []string
+	ResourceAnnotations  []string
+	NamespaceBootstrap   bool
+	NamespaceDir         string
+	NamespaceLabels      []string
+	NamespaceAnnotations []string
+	KustomizationIndex   bool
+	IgnoreServerFields   bool
+	// ToolVersion identifies the build of the tool, as its post-processing of rendered manifests can change
+	ToolVersion string
+}
+
+// InputsHash returns a hash of everything rendering the train depends on: the digest of every target, as returned by
+// digest, and the settings. Targets are hashed in sorted order.
+func InputsHash(targets []string, settings Settings, digest func(target string) (string, error)) (string, error) {
+	sorted := append([]string(nil), targets...)
+	sort.Strings(sorted)
+	h := sha256.New()
+	for _, target := range sorted {
+		d, err := digest(target)
+		if err != nil {
+			return "", fmt.Errorf("unable to compute inputs digest of %s: %w", target, err)
+		}
+		fmt.Fprintf(h, "%s %s\n", target, d)
+	}
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return "", err
+	}
+	h.Write(b)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
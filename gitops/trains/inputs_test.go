@@ -0,0 +1,57 @@
+package trains
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInputsHash(t *testing.T) {
+	digests := map[string]string{"//app:prod": "d1", "//db:prod": "d2"}
+	digest := func(target string) (string, error) {
+		if d, ok := digests[target]; ok {
+			return d, nil
+		}
+		return "", errors.New("no runfiles manifest")
+	}
+	base := Settings{GitopsPath: "cloud", NamespaceDir: "namespaces", IgnoreServerFields: true, ToolVersion: "v1.0.0 abc123"}
+	hash := func(targets []string, s Settings) string {
+		h, err := InputsHash(targets, s, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	h := hash([]string{"//app:prod", "//db:prod"}, base)
+	if other := hash([]string{"//db:prod", "//app:prod"}, base); other != h {
+		t.Errorf("hash depends on the order of targets")
+	}
+
+	changed := map[string]func(s *Settings){
+		"gitops_path":          func(s *Settings) { s.GitopsPath = "k8s" },
+		"standard_labels":      func(s *Settings) { s.StandardLabels = true },
+		"resource_label":       func(s *Settings) { s.ResourceLabels = []string{"team=payments"} },
+		"resource_annotation":  func(s *Settings) { s.ResourceAnnotations = []string{"owner=ops"} },
+		"namespace_bootstrap":  func(s *Settings) { s.NamespaceBootstrap = true },
+		"namespace_dir":        func(s *Settings) { s.NamespaceDir = "ns" },
+		"namespace_label":      func(s *Settings) { s.NamespaceLabels = []string{"istio-injection=enabled"} },
+		"namespace_annotation": func(s *Settings) { s.NamespaceAnnotations = []string{"owner=ops"} },
+		"kustomization_index":  func(s *Settings) { s.KustomizationIndex = true },
+		"ignore_server_fields": func(s *Settings) { s.IgnoreServerFields = false },
+		"tool version":         func(s *Settings) { s.ToolVersion = "v1.1.0 def456" },
+	}
+	for name, change := range changed {
+		s := base
+		change(&s)
+		if hash([]string{"//app:prod", "//db:prod"}, s) == h {
+			t.Errorf("changing %s does not change the hash", name)
+		}
+	}
+
+	digests["//db:prod"] = "d3"
+	if hash([]string{"//app:prod", "//db:prod"}, base) == h {
+		t.Errorf("changing a runfiles digest does not change the hash")
+	}
+	if _, err := InputsHash([]string{"//missing:prod"}, base, digest); err == nil {
+		t.Errorf("expected an error for a target without digest")
+	}
+}
@@ -0,0 +1,77 @@
+// Package trains orders release trains by their declared dependencies, names per-target deployment branches
+// and renders release trains
+package trains
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Dependencies maps a release train to the trains that have to be deployed before it
+type Dependencies map[string][]string
+
+// ParseDependencies parses declarations in train=dependency[,dependency...] format
+func ParseDependencies(decls []string) (Dependencies, error) {
+	deps := make(Dependencies)
+	for _, d := range decls {
+		train, list, ok := strings.Cut(d, "=")
+		train = strings.TrimSpace(train)
+		if !ok || train == "" {
+			return nil, fmt.Errorf("invalid train dependency %q, expected train=dependency[,dependency...]", d)
+		}
+		for _, dep := range strings.Split(list, ",") {
+			if dep = strings.TrimSpace(dep); dep != "" {
+				deps[train] = append(deps[train], dep)
+			}
+		}
+	}
+	return deps, nil
+}
+
+// Order returns trains sorted so every train comes after its dependencies.
+// Trains without an ordering constraint are sorted by name. Dependencies on trains not in the list are ignored.
+func Order(trains []string, deps Dependencies) ([]string, error) {
+	present := make(map[string]bool)
+	for _, t := range trains {
+		present[t] = true
+	}
+	sorted := append([]string(nil), trains...)
+	sort.Strings(sorted)
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var ordered []string
+	var visit func(t string, path []string) error
+	visit = func(t string, path []string) error {
+		switch state[t] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("release train dependency cycle: %s", strings.Join(append(path, t), " -> "))
+		}
+		state[t] = visiting
+		d := append([]string(nil), deps[t]...)
+		sort.Strings(d)
+		for _, dep := range d {
+			if !present[dep] {
+				continue
+			}
+			if err := visit(dep, append(path, t)); err != nil {
+				return err
+			}
+		}
+		state[t] = done
+		ordered = append(ordered, t)
+		return nil
+	}
+	for _, t := range sorted {
+		if err := visit(t, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
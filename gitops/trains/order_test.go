@@ -0,0 +1,45 @@
+package trains
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDependencies(t *testing.T) {
+	deps, err := ParseDependencies([]string{"services=infra", "frontend=services, infra", "infra="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Dependencies{
+		"services": {"infra"},
+		"frontend": {"services", "infra"},
+	}
+	if !reflect.DeepEqual(deps, expected) {
+		t.Errorf("unexpected dependencies %v", deps)
+	}
+	if _, err := ParseDependencies([]string{"infra"}); err == nil {
+		t.Error("expected an error for a declaration without =")
+	}
+}
+
+func TestOrder(t *testing.T) {
+	deps := Dependencies{
+		"services": {"infra"},
+		"frontend": {"services", "missing"},
+	}
+	order, err := Order([]string{"frontend", "batch", "services", "infra"}, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"batch", "infra", "services", "frontend"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Order() = %v, expected %v", order, expected)
+	}
+}
+
+func TestOrderCycle(t *testing.T) {
+	_, err := Order([]string{"a", "b", "c"}, Dependencies{"a": {"b"}, "b": {"c"}, "c": {"a"}})
+	if err == nil || err.Error() != "release train dependency cycle: a -> b -> c -> a" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package trains
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/fasterci/rules_gitops/gitops/exec"
+)
+
+// Renderer runs gitops binaries of release trains
+type Renderer struct {
+	// Sandbox executes the binaries
+	Sandbox *exec.Sandbox
+	// Parallelism is the maximum number of binaries running at once, at least 1
+	Parallelism int
+	// Executable returns the binary of a gitops target
+	Executable func(target string) string
+}
+
+// Render runs gitops binaries for all targets of the train with --nopush --deployment_root deploymentRoot.
+// Targets of the same train write into disjoint paths of the deployment root, so up to Parallelism of them run
+// concurrently. Output of every binary is captured and logged as a whole once the binary exits.
+// All targets are rendered even if some fail; their errors are returned joined in the order of targets.
+func (r Renderer) Render(train string, targets []string, deploymentRoot string) error {
+	parallelism := r.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	errs := make([]error, len(targets))
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		i, target := i, target
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			log.Println("train", train, "target", target)
+			if _, err := r.Sandbox.Ex("", r.Executable(target), "--nopush", "--deployment_root", deploymentRoot); err != nil {
+				errs[i] = fmt.Errorf("gitops target %s failed: %w", target, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package trains
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fasterci/rules_gitops/gitops/exec"
+)
+
+func TestRender(t *testing.T) {
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	var ran []string
+	runner := exec.RunnerFunc(func(dir string, env []string, name string, arg ...string) (string, error) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		ran = append(ran, name)
+		mu.Unlock()
+		if want := []string{"--nopush", "--deployment_root", "/gitops"}; strings.Join(arg, " ") != strings.Join(want, " ") {
+			t.Errorf("unexpected arguments of %s: %v", name, arg)
+		}
+		// the first target finishes last
+		if name == "bin/a" {
+			time.Sleep(50 * time.Millisecond)
+		} else {
+			time.Sleep(10 * time.Millisecond)
+		}
+		mu.Lock()
+		running--
+		mu.Unlock()
+		if strings.HasSuffix(name, "-fail") {
+			return "", errors.New("exit status 1")
+		}
+		return "", nil
+	})
+	r := Renderer{
+		Sandbox:     &exec.Sandbox{Runner: runner},
+		Parallelism: 2,
+		Executable:  func(target string) string { return "bin/" + target },
+	}
+	targets := []string{"a", "b-fail", "c", "d-fail", "e"}
+	err := r.Render("prod", targets, "/gitops")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	expected := "gitops target b-fail failed: exit status 1\ngitops target d-fail failed: exit status 1"
+	if err.Error() != expected {
+		t.Errorf("unexpected error %q", err)
+	}
+	if len(ran) != len(targets) {
+		t.Errorf("expected all targets to run after failures, ran %v", ran)
+	}
+	if maxRunning != 2 {
+		t.Errorf("expected 2 binaries running at once, got %d", maxRunning)
+	}
+
+	ran, maxRunning = nil, 0
+	r.Parallelism = 0
+	if err := r.Render("prod", []string{"a", "c"}, "/gitops"); err != nil {
+		t.Fatal(err)
+	}
+	if maxRunning != 1 || strings.Join(ran, " ") != "bin/a bin/c" {
+		t.Errorf("expected sequential rendering, ran %v with %d at once", ran, maxRunning)
+	}
+}
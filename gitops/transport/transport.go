@@ -0,0 +1,159 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+// Config describes TLS and proxy settings shared by git operations and git server API clients
+type Config struct {
+	// CAFile is a PEM bundle of additional trusted certificate authorities
+	CAFile string
+	// CertFile and KeyFile are a PEM client certificate and its private key
+	CertFile string
+	KeyFile  string
+	// Proxy is a http, https or socks5 proxy url. Proxy environment variables are used if empty
+	Proxy string
+
+	// Connection tuning of the API client transport. Zero values keep the http.DefaultTransport settings.
+	DialTimeout           time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	IdleConnTimeout       time.Duration
+	// KeepAlive is the TCP keep-alive period, negative disables TCP keep-alives
+	KeepAlive           time.Duration
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	// DisableKeepAlives uses a new connection for every request
+	DisableKeepAlives bool
+	// DisableHTTP2 restricts API clients to HTTP/1.1
+	DisableHTTP2 bool
+}
+
+// Enabled returns true if any setting differs from the defaults
+func (c Config) Enabled() bool {
+	return c.CAFile != "" || c.CertFile != "" || c.KeyFile != "" || c.Proxy != "" || c.tuned()
+}
+
+func (c Config) tuned() bool {
+	return c.DialTimeout != 0 || c.TLSHandshakeTimeout != 0 || c.ResponseHeaderTimeout != 0 || c.IdleConnTimeout != 0 ||
+		c.KeepAlive != 0 || c.MaxIdleConns != 0 || c.MaxIdleConnsPerHost != 0 || c.DisableKeepAlives || c.DisableHTTP2
+}
+
+// NewTransport returns a copy of http.DefaultTransport using the configuration.
+// Certificate authorities from CAFile are trusted in addition to the system ones.
+func (c Config) NewTransport() (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if c.CAFile != "" || c.CertFile != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if c.CAFile != "" {
+			pem, err := os.ReadFile(c.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read CA bundle: %w", err)
+			}
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in CA bundle %s", c.CAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		if c.CertFile != "" {
+			cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to load client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		t.TLSClientConfig = tlsConfig
+	}
+	if c.Proxy != "" {
+		u, err := url.Parse(c.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme %q, expected http, https or socks5", u.Scheme)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+	if c.DialTimeout != 0 || c.KeepAlive != 0 {
+		// the defaults of http.DefaultTransport
+		d := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		if c.DialTimeout != 0 {
+			d.Timeout = c.DialTimeout
+		}
+		if c.KeepAlive != 0 {
+			d.KeepAlive = c.KeepAlive
+		}
+		t.DialContext = d.DialContext
+	}
+	if c.TLSHandshakeTimeout != 0 {
+		t.TLSHandshakeTimeout = c.TLSHandshakeTimeout
+	}
+	if c.ResponseHeaderTimeout != 0 {
+		t.ResponseHeaderTimeout = c.ResponseHeaderTimeout
+	}
+	if c.IdleConnTimeout != 0 {
+		t.IdleConnTimeout = c.IdleConnTimeout
+	}
+	if c.MaxIdleConns != 0 {
+		t.MaxIdleConns = c.MaxIdleConns
+	}
+	if c.MaxIdleConnsPerHost != 0 {
+		t.MaxIdleConnsPerHost = c.MaxIdleConnsPerHost
+	}
+	t.DisableKeepAlives = c.DisableKeepAlives
+	if c.DisableHTTP2 {
+		// a non-nil empty map disables the automatic HTTP/2 upgrade
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return t, nil
+}
+
+// NewClient returns an API client with a transport of its own created by NewTransport and the request timeout.
+// Other HTTP clients of the process are not affected.
+func (c Config) NewClient(timeout time.Duration) (*http.Client, error) {
+	t, err := c.NewTransport()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: t, Timeout: timeout}, nil
+}
+
+// GitEnv returns environment variables making git use the certificates
+func (c Config) GitEnv() []string {
+	var env []string
+	if c.CAFile != "" {
+		env = append(env, "GIT_SSL_CAINFO="+c.CAFile)
+	}
+	if c.CertFile != "" {
+		env = append(env, "GIT_SSL_CERT="+c.CertFile)
+	}
+	if c.KeyFile != "" {
+		env = append(env, "GIT_SSL_KEY="+c.KeyFile)
+	}
+	return env
+}
+
+// GitConfig returns git configuration making git use the proxy, see git.ConfigEnv
+func (c Config) GitConfig() []git.ConfigEntry {
+	if c.Proxy == "" {
+		return nil
+	}
+	// git http.proxy accepts socks5:// urls too
+	return []git.ConfigEntry{{Key: "http.proxy", Value: c.Proxy}}
+}
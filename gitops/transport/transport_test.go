@@ -0,0 +1,116 @@
+package transport
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fasterci/rules_gitops/gitops/git"
+)
+
+func TestCAFile(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	b := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(ca, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := http.Get(srv.URL); err == nil {
+		t.Fatal("expected certificate verification error with the default transport")
+	}
+	tr, err := Config{CAFile: ca}.NewTransport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: tr}).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+func TestProxy(t *testing.T) {
+	tr, err := Config{Proxy: "socks5://proxy.corp:1080"}.NewTransport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest("GET", "https://gitlab.corp/api/v4", nil)
+	u, err := tr.Proxy(req)
+	if err != nil || u.String() != "socks5://proxy.corp:1080" {
+		t.Errorf("unexpected proxy %v %v", u, err)
+	}
+	if _, err := (Config{Proxy: "ftp://proxy"}).NewTransport(); err == nil {
+		t.Error("expected unsupported scheme error")
+	}
+}
+
+func TestGitEnv(t *testing.T) {
+	c := Config{CAFile: "/etc/ca.pem", Proxy: "http://proxy:3128"}
+	if env := c.GitEnv(); !reflect.DeepEqual(env, []string{"GIT_SSL_CAINFO=/etc/ca.pem"}) {
+		t.Error("unexpected env", env)
+	}
+	if cfg := c.GitConfig(); !reflect.DeepEqual(cfg, []git.ConfigEntry{{Key: "http.proxy", Value: "http://proxy:3128"}}) {
+		t.Error("unexpected config", cfg)
+	}
+}
+
+func TestTuning(t *testing.T) {
+	c := Config{ResponseHeaderTimeout: 50 * time.Millisecond, MaxIdleConnsPerHost: 4, DisableHTTP2: true}
+	if !c.Enabled() {
+		t.Fatal("expected tuning to enable the transport")
+	}
+	tr, err := c.NewTransport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.MaxIdleConnsPerHost != 4 || tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+		t.Errorf("unexpected transport %+v", tr)
+	}
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+	tr.TLSClientConfig = srv.Client().Transport.(*http.Transport).TLSClientConfig
+	client := &http.Client{Transport: tr}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.Proto != "HTTP/1.1" {
+		t.Errorf("expected HTTP/1.1, got %s", resp.Proto)
+	}
+	if _, err := client.Get(srv.URL + "/slow"); err == nil {
+		t.Error("expected a response header timeout")
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	c, err := Config{Proxy: "http://proxy:3128"}.NewClient(time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c == http.DefaultClient || c.Transport == http.DefaultTransport || c.Timeout != time.Minute {
+		t.Errorf("expected a client of its own with the timeout, got %+v", c)
+	}
+	// the process wide defaults are left alone
+	if http.DefaultClient.Timeout != 0 || http.DefaultTransport.(*http.Transport).Proxy == nil {
+		t.Error("unexpected change of the default client")
+	}
+	req, _ := http.NewRequest("GET", "https://gitlab.corp/api/v4", nil)
+	if u, err := c.Transport.(*http.Transport).Proxy(req); err != nil || u.String() != "http://proxy:3128" {
+		t.Errorf("unexpected proxy %v %v", u, err)
+	}
+}
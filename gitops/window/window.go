@@ -0,0 +1,175 @@
+// Package window parses deployment windows like "Mon-Fri 09:00-17:00 Europe/Berlin"
+package window
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Window is a daily time range on selected weekdays.
+// A range ending before it starts continues past midnight into the next day.
+type Window struct {
+	days       [7]bool
+	start, end int // minutes since midnight
+	loc        *time.Location
+}
+
+// Schedule is a set of windows. A time is inside the schedule if it is inside any of the windows.
+type Schedule struct {
+	spec    string
+	windows []Window
+}
+
+// Parse parses windows separated by ";". Every window has the format "DAYS HH:MM-HH:MM [TIMEZONE]" where DAYS is
+// "*", a weekday range like Mon-Fri or a list like Mon,Wed,Fri. Times are in UTC unless TIMEZONE is set.
+func Parse(spec string) (*Schedule, error) {
+	s := &Schedule{spec: spec}
+	for _, w := range strings.Split(spec, ";") {
+		if strings.TrimSpace(w) == "" {
+			continue
+		}
+		parsed, err := parseWindow(w)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deployment window %q: %w", w, err)
+		}
+		s.windows = append(s.windows, parsed)
+	}
+	if len(s.windows) == 0 {
+		return nil, fmt.Errorf("empty deployment window %q", spec)
+	}
+	return s, nil
+}
+
+func parseWindow(w string) (Window, error) {
+	var win Window
+	fields := strings.Fields(w)
+	if len(fields) < 2 || len(fields) > 3 {
+		return win, fmt.Errorf("expected DAYS HH:MM-HH:MM [TIMEZONE]")
+	}
+	if err := win.parseDays(strings.ToLower(fields[0])); err != nil {
+		return win, err
+	}
+	from, to, ok := strings.Cut(fields[1], "-")
+	if !ok {
+		return win, fmt.Errorf("invalid time range %s", fields[1])
+	}
+	var err error
+	if win.start, err = parseClock(from); err != nil {
+		return win, err
+	}
+	if win.end, err = parseClock(to); err != nil {
+		return win, err
+	}
+	win.loc = time.UTC
+	if len(fields) == 3 {
+		if win.loc, err = time.LoadLocation(fields[2]); err != nil {
+			return win, err
+		}
+	}
+	return win, nil
+}
+
+func (w *Window) parseDays(days string) error {
+	if days == "*" {
+		for i := range w.days {
+			w.days[i] = true
+		}
+		return nil
+	}
+	for _, part := range strings.Split(days, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		first, ok := weekdays[from]
+		if !ok {
+			return fmt.Errorf("unknown weekday %s", from)
+		}
+		last := first
+		if isRange {
+			if last, ok = weekdays[to]; !ok {
+				return fmt.Errorf("unknown weekday %s", to)
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			w.days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %s, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// opening returns the start of the window on the day of t in the window time zone
+func (w Window) opening(t time.Time) time.Time {
+	y, m, d := t.In(w.loc).Date()
+	return time.Date(y, m, d, 0, w.start, 0, 0, w.loc)
+}
+
+func (w Window) length() time.Duration {
+	end := w.end
+	if end <= w.start {
+		end += 24 * 60
+	}
+	return time.Duration(end-w.start) * time.Minute
+}
+
+// Contains reports whether t is inside the window
+func (w Window) Contains(t time.Time) bool {
+	// the window may have opened today or, if it continues past midnight, yesterday
+	for _, back := range []int{0, -1} {
+		open := w.opening(t.AddDate(0, 0, back))
+		if w.days[open.Weekday()] && !t.Before(open) && t.Before(open.Add(w.length())) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether t is inside any window of the schedule
+func (s *Schedule) Contains(t time.Time) bool {
+	for _, w := range s.windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the next time a window of the schedule opens after t
+func (s *Schedule) Next(t time.Time) time.Time {
+	var next time.Time
+	for _, w := range s.windows {
+		for day := 0; day <= 7; day++ {
+			open := w.opening(t.AddDate(0, 0, day))
+			if w.days[open.Weekday()] && open.After(t) {
+				if next.IsZero() || open.Before(next) {
+					next = open
+				}
+				break
+			}
+		}
+	}
+	return next
+}
+
+func (s *Schedule) String() string {
+	return s.spec
+}
@@ -0,0 +1,61 @@
+package window
+
+import (
+	"testing"
+	"time"
+)
+
+func at(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestContains(t *testing.T) {
+	s, err := Parse("Mon-Fri 09:00-17:00; Sat 22:00-02:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		time   string
+		inside bool
+	}{
+		{"2024-01-08T09:00:00Z", true},  // Monday opening
+		{"2024-01-08T16:59:59Z", true},  // Monday
+		{"2024-01-08T17:00:00Z", false}, // Monday closing
+		{"2024-01-07T12:00:00Z", false}, // Sunday
+		{"2024-01-13T23:00:00Z", true},  // Saturday night
+		{"2024-01-14T01:30:00Z", true},  // after Saturday midnight
+		{"2024-01-14T02:00:00Z", false}, // Sunday
+		{"2024-01-12T23:00:00Z", false}, // Friday night
+	} {
+		if got := s.Contains(at(tc.time)); got != tc.inside {
+			t.Errorf("Contains(%s) = %v", tc.time, got)
+		}
+	}
+}
+
+func TestTimezoneAndNext(t *testing.T) {
+	s, err := Parse("Mon,Wed 10:00-12:00 America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 15:00 UTC is 10:00 in New York in January
+	if !s.Contains(at("2024-01-08T15:00:00Z")) {
+		t.Error("expected Monday 10:00 New York time to be inside the window")
+	}
+	next := s.Next(at("2024-01-08T18:00:00Z"))
+	if !next.Equal(at("2024-01-10T15:00:00Z")) {
+		t.Errorf("Next() = %v", next)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{"", "Mon-Fri", "Funday 09:00-17:00", "Mon 9-17", "Mon 09:00-17:00 Mars/Base", "* 09:00-25:00"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) expected an error", spec)
+		}
+	}
+}
@@ -6,10 +6,24 @@ bindir=$(cd `dirname "$0"` && pwd)
 repo_path=$bindir/..
 cd $repo_path
 
-bazel build //gitops/blaze_query/... //gitops/analysis/...
-GEN_REPB_PATH="gitops/blaze_query/build.pb.go"
-cp -f "$(find $(bazel info bazel-bin) -path "*/$GEN_REPB_PATH")" "$GEN_REPB_PATH"
+# build.proto and analysis_v2.proto are vendored unmodified from this Bazel release, only the import of build.proto
+# is adjusted to its vendored location. Bump it to pick up new query and cquery output fields.
+BAZEL_VERSION=7.4.1
+proto_url="https://raw.githubusercontent.com/bazelbuild/bazel/${BAZEL_VERSION}/src/main/protobuf"
+curl -fsSL "$proto_url/build.proto" -o gitops/blaze_query/build.proto
+curl -fsSL "$proto_url/analysis_v2.proto" |
+    sed 's|^import "src/main/protobuf/build.proto";|import "gitops/blaze_query/build.proto";|' \
+    > gitops/analysis/analysis.proto
+grep -q '^import "gitops/blaze_query/build.proto";' gitops/analysis/analysis.proto
 
-GEN_REPB_PATH="gitops/analysis/analysis.pb.go"
-cp -f "$(find $(bazel info bazel-bin) -path "*/$GEN_REPB_PATH")" "$GEN_REPB_PATH"
+# protoc-gen-go version should match google.golang.org/protobuf in go.mod
+PROTOC_GEN_GO_VERSION=$(awk '$1 == "google.golang.org/protobuf" {print $2}' go.mod)
+GOBIN="$repo_path/.bin" go install "google.golang.org/protobuf/cmd/protoc-gen-go@${PROTOC_GEN_GO_VERSION}"
 
+# Go packages are mapped here rather than with go_package options, the vendored protos are not edited
+protoc --plugin=protoc-gen-go="$repo_path/.bin/protoc-gen-go" \
+    --go_out=. --go_opt=paths=source_relative \
+    --go_opt=Mgitops/blaze_query/build.proto=github.com/fasterci/rules_gitops/gitops/blaze_query \
+    --go_opt=Mgitops/analysis/analysis.proto=github.com/fasterci/rules_gitops/gitops/analysis \
+    gitops/blaze_query/build.proto \
+    gitops/analysis/analysis.proto
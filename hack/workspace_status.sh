@@ -0,0 +1,8 @@
+#!/usr/bin/env bash
+# Prints stable status keys used to stamp the version of create_gitops_prs.
+# Use with: bazel build --stamp --workspace_status_command=hack/workspace_status.sh //gitops/prer:create_gitops_prs
+
+set -euo pipefail
+
+echo "STABLE_GITOPS_VERSION $(git describe --tags --always --dirty 2>/dev/null || echo unknown)"
+echo "STABLE_GITOPS_COMMIT $(git rev-parse HEAD 2>/dev/null || echo unknown)"
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/fasterci/rules_gitops/push_oci/pkg/helmchart"
+	"github.com/google/go-containerregistry/pkg/logs"
+)
+
+var (
+	Timeout    time.Duration
+	Chart      string
+	Provenance string
+	Repository string
+	DigestFile string
+)
+
+func init() {
+	logs.Warn.SetOutput(os.Stderr)
+	logs.Progress.SetOutput(os.Stderr)
+	flag.DurationVar(&Timeout, "timeout", time.Second*30, "Timeout for the push operation")
+	flag.StringVar(&Chart, "chart", "", "The packaged chart archive, like app-0.1.0.tgz, required")
+	flag.StringVar(&Provenance, "prov", "", "The chart provenance file to push alongside the chart")
+	flag.StringVar(&Repository, "repository", "", "The repository to push the chart to, must end with the chart name. The chart is only packaged if not set")
+	flag.StringVar(&DigestFile, "digest_file", "", "Write the digest of the chart artifact to the file")
+}
+
+func main() {
+	flag.Parse()
+	if Chart == "" || (Repository == "" && DigestFile == "") {
+		flag.Usage()
+		os.Exit(1)
+	}
+	chart, err := os.ReadFile(Chart)
+	if err != nil {
+		logs.Warn.Fatalf("Unable to read chart: %v", err)
+	}
+	var prov []byte
+	if Provenance != "" {
+		if prov, err = os.ReadFile(Provenance); err != nil {
+			logs.Warn.Fatalf("Unable to read provenance file: %v", err)
+		}
+	}
+	img, m, err := helmchart.Image(chart, prov)
+	if err != nil {
+		logs.Warn.Fatalf("Unable to package chart %s: %v", Chart, err)
+	}
+	if DigestFile != "" {
+		d, err := img.Digest()
+		if err != nil {
+			logs.Warn.Fatalf("Unable to compute chart digest: %v", err)
+		}
+		if err := os.WriteFile(DigestFile, []byte(d.String()), 0644); err != nil {
+			logs.Warn.Fatalf("Unable to write digest file: %v", err)
+		}
+	}
+	if Repository == "" {
+		return
+	}
+
+	ctx, cancelT := context.WithTimeout(context.Background(), Timeout)
+	defer cancelT()
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	defer cancel()
+	ref, err := helmchart.Push(ctx, Repository, img, m)
+	if err != nil {
+		logs.Warn.Printf("Failed to push chart %s: %v", Chart, err)
+		os.Exit(1)
+	}
+	logs.Progress.Printf("Pushed %s:%s as %s", m.Name, m.Version, ref)
+}
@@ -0,0 +1,113 @@
+"""
+Implementation of the `push_helm_chart` rule publishing a packaged Helm chart as an OCI artifact
+"""
+
+load("//gitops:provider.bzl", "GitopsPushInfo")
+load("//skylib:runfile.bzl", "get_runfile_path")
+
+def _impl(ctx):
+    pusher = ctx.executable.helm_push_tool
+    inputs = [ctx.file.chart]
+    args = ["-chart", ctx.file.chart.path]
+    push_args = "-chart {} -repository {} -timeout {}".format(
+        get_runfile_path(ctx, ctx.file.chart),
+        ctx.attr.repository,
+        ctx.attr.push_timeout,
+    )
+    if ctx.file.prov:
+        inputs.append(ctx.file.prov)
+        args += ["-prov", ctx.file.prov.path]
+        push_args += " -prov {}".format(get_runfile_path(ctx, ctx.file.prov))
+
+    digest = ctx.actions.declare_file(ctx.attr.name + ".digest")
+    ctx.actions.run(
+        inputs = inputs,
+        outputs = [digest],
+        executable = pusher,
+        arguments = args + ["-digest_file", digest.path],
+        mnemonic = "HelmChartDigest",
+        progress_message = "Computing digest of %s" % ctx.file.chart.short_path,
+    )
+
+    ctx.actions.expand_template(
+        template = ctx.file._tag_tpl,
+        substitutions = {
+            "%{args}": push_args,
+            "%{container_pusher}": get_runfile_path(ctx, pusher),
+        },
+        output = ctx.outputs.executable,
+        is_executable = True,
+    )
+
+    runfiles = ctx.runfiles(files = inputs + [digest]).merge(ctx.attr.helm_push_tool[DefaultInfo].default_runfiles)
+
+    return [
+        DefaultInfo(
+            executable = ctx.outputs.executable,
+            runfiles = runfiles,
+        ),
+        GitopsPushInfo(
+            image_label = ctx.label,
+            repository = ctx.attr.repository,
+            digestfile = digest,
+        ),
+    ]
+
+push_helm_chart_rule = rule(
+    implementation = _impl,
+    attrs = {
+        "chart": attr.label(
+            mandatory = True,
+            allow_single_file = [".tgz"],
+            doc = "The packaged chart archive, like the output of helm package",
+        ),
+        "prov": attr.label(
+            allow_single_file = [".prov"],
+            doc = "The optional provenance file pushed alongside the chart",
+        ),
+        "repository": attr.string(
+            mandatory = True,
+            doc = "The repository to push the chart to including the registry. Must end with the chart name.",
+        ),
+        "push_timeout": attr.string(
+            doc = "The allowed wait time for the chart push",
+            default = "30s",
+        ),
+        "helm_push_tool": attr.label(
+            default = Label("//push_oci/cmd/helm_push"),
+            executable = True,
+            cfg = "exec",
+        ),
+        "_tag_tpl": attr.label(
+            default = Label("//push_oci:tag.sh.tpl"),
+            allow_single_file = True,
+        ),
+    },
+    executable = True,
+    doc = """Push a packaged Helm chart to an OCI registry the way helm push does.
+Implements GitopsPushInfo so the chart reference can be injected into manifests like an image:
+{{//label:target}} renders as repository@sha256:..., and .digest and .short-digest variables are available as well.
+""",
+)
+
+def push_helm_chart(name, chart, repository = None, registry = None, chart_name = None, **kwargs):
+    """Push a packaged Helm chart as an OCI artifact.
+
+    Args:
+      name: name of the push target. The target is picked up by create_gitops_prs as a push_helm_chart kind.
+      chart: the packaged chart archive.
+      repository: the repository to push the chart to. Defaults to {package}/{chart_name}.
+      registry: the registry prepended to the repository.
+      chart_name: the name of the chart from Chart.yaml, used for the default repository. Defaults to name.
+      **kwargs: passed to the rule, like prov, push_timeout, tags and visibility.
+    """
+    if not repository:
+        repository = "{}/{}".format(native.package_name(), chart_name or name)
+    if registry:
+        repository = "{}/{}".format(registry, repository)
+    push_helm_chart_rule(
+        name = name,
+        chart = chart,
+        repository = repository,
+        **kwargs
+    )
@@ -0,0 +1,186 @@
+// Package helmchart packages Helm chart archives as OCI artifacts compatible with helm push and helm pull
+package helmchart
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Media types defined by Helm for charts stored in OCI registries
+const (
+	ConfigMediaType     types.MediaType = "application/vnd.cncf.helm.config.v1+json"
+	ChartMediaType      types.MediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	ProvenanceMediaType types.MediaType = "application/vnd.cncf.helm.chart.provenance.v1.prov"
+)
+
+// Metadata is the part of Chart.yaml identifying the chart
+type Metadata struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Tag returns the OCI tag helm uses for the chart version. OCI tags can't contain +.
+func (m Metadata) Tag() string {
+	return strings.ReplaceAll(m.Version, "+", "_")
+}
+
+// blob is a layer or config stored as is
+type blob struct {
+	content   []byte
+	mediaType types.MediaType
+}
+
+func (b blob) Digest() (v1.Hash, error) {
+	h, _, err := v1.SHA256(bytes.NewReader(b.content))
+	return h, err
+}
+
+func (b blob) Compressed() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(b.content)), nil
+}
+
+func (b blob) Size() (int64, error) {
+	return int64(len(b.content)), nil
+}
+
+func (b blob) MediaType() (types.MediaType, error) {
+	return b.mediaType, nil
+}
+
+func (b blob) descriptor() (v1.Descriptor, error) {
+	d, err := b.Digest()
+	return v1.Descriptor{MediaType: b.mediaType, Size: int64(len(b.content)), Digest: d}, err
+}
+
+// artifact implements partial.CompressedImageCore for a chart
+type artifact struct {
+	config blob
+	layers []blob
+}
+
+func (a *artifact) RawConfigFile() ([]byte, error) {
+	return a.config.content, nil
+}
+
+func (a *artifact) MediaType() (types.MediaType, error) {
+	return types.OCIManifestSchema1, nil
+}
+
+func (a *artifact) RawManifest() ([]byte, error) {
+	m := v1.Manifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIManifestSchema1,
+	}
+	var err error
+	if m.Config, err = a.config.descriptor(); err != nil {
+		return nil, err
+	}
+	for _, l := range a.layers {
+		d, err := l.descriptor()
+		if err != nil {
+			return nil, err
+		}
+		m.Layers = append(m.Layers, d)
+	}
+	return json.Marshal(m)
+}
+
+func (a *artifact) LayerByDigest(h v1.Hash) (partial.CompressedLayer, error) {
+	for _, b := range append([]blob{a.config}, a.layers...) {
+		if d, _ := b.Digest(); d == h {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("blob %s not found", h)
+}
+
+// ReadMetadata returns Chart.yaml of the chart archive as JSON, as helm stores it in the artifact config
+func ReadMetadata(chart []byte) (Metadata, []byte, error) {
+	var m Metadata
+	gz, err := gzip.NewReader(bytes.NewReader(chart))
+	if err != nil {
+		return m, nil, fmt.Errorf("chart is not a gzip archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return m, nil, errors.New("Chart.yaml not found in the chart archive")
+		}
+		if err != nil {
+			return m, nil, err
+		}
+		// Chart.yaml is in the top level directory of the archive named after the chart
+		if path.Base(h.Name) != "Chart.yaml" || strings.Count(strings.Trim(h.Name, "/"), "/") != 1 {
+			continue
+		}
+		y, err := io.ReadAll(tr)
+		if err != nil {
+			return m, nil, err
+		}
+		j, err := yaml.YAMLToJSON(y)
+		if err != nil {
+			return m, nil, fmt.Errorf("invalid Chart.yaml: %w", err)
+		}
+		if err := json.Unmarshal(j, &m); err != nil {
+			return m, nil, fmt.Errorf("invalid Chart.yaml: %w", err)
+		}
+		if m.Name == "" || m.Version == "" {
+			return m, nil, errors.New("Chart.yaml must set name and version")
+		}
+		return m, j, nil
+	}
+}
+
+// Image returns the OCI artifact of the chart archive with an optional provenance file
+func Image(chart, prov []byte) (v1.Image, Metadata, error) {
+	m, config, err := ReadMetadata(chart)
+	if err != nil {
+		return nil, m, err
+	}
+	a := &artifact{
+		config: blob{content: config, mediaType: ConfigMediaType},
+		layers: []blob{{content: chart, mediaType: ChartMediaType}},
+	}
+	if prov != nil {
+		a.layers = append(a.layers, blob{content: prov, mediaType: ProvenanceMediaType})
+	}
+	img, err := partial.CompressedToImage(a)
+	return img, m, err
+}
+
+// Push uploads the chart artifact to repository tagged with the chart version and returns its digest reference,
+// like registry/charts/app@sha256:1234. Like helm push, the repository has to end with the chart name.
+func Push(ctx context.Context, repository string, img v1.Image, m Metadata) (string, error) {
+	repo, err := name.NewRepository(repository)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository %s: %w", repository, err)
+	}
+	if path.Base(repo.RepositoryStr()) != m.Name {
+		return "", fmt.Errorf("repository %s must end with the chart name %s", repository, m.Name)
+	}
+	if err := remote.Write(repo.Tag(m.Tag()), img, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain)); err != nil {
+		return "", fmt.Errorf("unable to push chart %s:%s: %w", repo, m.Tag(), err)
+	}
+	d, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+	return repo.Digest(d.String()).String(), nil
+}
@@ -0,0 +1,126 @@
+package helmchart_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/fasterci/rules_gitops/mirror/pkg/testing/testregistry"
+	"github.com/fasterci/rules_gitops/push_oci/pkg/helmchart"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+func chartArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for n, c := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: n, Mode: 0644, Size: int64(len(c))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(c)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadMetadata(t *testing.T) {
+	chart := chartArchive(t, map[string]string{
+		"app/Chart.yaml":            "apiVersion: v2\nname: app\nversion: 1.2.3+build.4\n",
+		"app/charts/dep/Chart.yaml": "apiVersion: v2\nname: dep\nversion: 0.1.0\n",
+		"app/values.yaml":           "replicas: 1\n",
+	})
+	m, config, err := helmchart.ReadMetadata(chart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Name != "app" || m.Version != "1.2.3+build.4" {
+		t.Errorf("unexpected metadata %+v", m)
+	}
+	if m.Tag() != "1.2.3_build.4" {
+		t.Errorf("unexpected tag %s", m.Tag())
+	}
+	if string(config) != `{"apiVersion":"v2","name":"app","version":"1.2.3+build.4"}` {
+		t.Errorf("unexpected config %s", config)
+	}
+
+	if _, _, err := helmchart.ReadMetadata(chartArchive(t, map[string]string{"app/values.yaml": ""})); err == nil {
+		t.Error("expected an error for a chart without Chart.yaml")
+	}
+	if _, _, err := helmchart.ReadMetadata([]byte("not a chart")); err == nil {
+		t.Error("expected an error for a non gzip file")
+	}
+}
+
+func TestPush(t *testing.T) {
+	reg, cleanup := testregistry.SetupRegistry(t)
+	defer cleanup()
+
+	chart := chartArchive(t, map[string]string{"app/Chart.yaml": "apiVersion: v2\nname: app\nversion: 0.1.0\n"})
+	prov := []byte("-----BEGIN PGP SIGNED MESSAGE-----\n")
+	img, m, err := helmchart.Image(chart, prov)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := helmchart.Push(context.Background(), reg.Name()+"/charts", img, m); err == nil {
+		t.Error("expected an error for a repository not ending with the chart name")
+	}
+	ref, err := helmchart.Push(context.Background(), reg.Name()+"/charts/app", img, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := reg.Name() + "/charts/app@" + d.String(); ref != expected {
+		t.Errorf("expected reference %s, got %s", expected, ref)
+	}
+
+	tag, err := name.ParseReference(reg.Name() + "/charts/app:0.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pulled, err := remote.Image(tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := pulled.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Config.MediaType != helmchart.ConfigMediaType {
+		t.Errorf("unexpected config media type %s", manifest.Config.MediaType)
+	}
+	if len(manifest.Layers) != 2 || manifest.Layers[0].MediaType != helmchart.ChartMediaType || manifest.Layers[1].MediaType != helmchart.ProvenanceMediaType {
+		t.Fatalf("unexpected layers %+v", manifest.Layers)
+	}
+	l, err := pulled.LayerByDigest(manifest.Layers[0].Digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := l.Compressed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, chart) {
+		t.Error("pulled chart differs from the pushed one")
+	}
+}
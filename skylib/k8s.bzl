@@ -534,6 +534,15 @@ def _k8s_test_setup_impl(ctx):
         sidecar_args.append("--portforward=%s" % service)
     for app in ctx.attr.wait_for_apps:
         sidecar_args.append("--waitforapp=%s" % app)
+    for ingress in ctx.attr.wait_for_ingresses:
+        sidecar_args.append("--wait_for_ingress=%s" % ingress)
+    for service in ctx.attr.wait_for_loadbalancers:
+        sidecar_args.append("--wait_for_loadbalancer=%s" % service)
+    if ctx.attr.wait_for_ingresses or ctx.attr.wait_for_loadbalancers:
+        # TEST_UNDECLARED_OUTPUTS_DIR is not set outside of bazel test, e.g. with bazel run
+        sidecar_args.append("${TEST_UNDECLARED_OUTPUTS_DIR:+--endpoints_file=${TEST_UNDECLARED_OUTPUTS_DIR}/endpoints.json}")
+    for condition in ctx.attr.wait_for_conditions:
+        sidecar_args.append("--wait_for_condition=%s" % condition)
     if ctx.attr.allow_errors:
         sidecar_args.append("--allow_errors")
     if ctx.attr.disable_pod_logs:
@@ -585,6 +594,15 @@ k8s_test_setup = rule(
         "portforward_services": attr.string_list(),
         "setup_timeout": attr.string(default = "10m"),
         "wait_for_apps": attr.string_list(),
+        "wait_for_ingresses": attr.string_list(
+            doc = "Ingress names to wait for until an address is assigned. Endpoints are reported to the test.",
+        ),
+        "wait_for_loadbalancers": attr.string_list(
+            doc = "Service type=LoadBalancer names to wait for until an address is assigned. Endpoints are reported to the test.",
+        ),
+        "wait_for_conditions": attr.string_list(
+            doc = "Resources status conditions to wait for, in form of group/version/kind/name=ConditionType (version/kind/name=ConditionType for core resources). The setup waits until every condition status is True.",
+        ),
         "allow_errors": attr.bool(
             default = False,
             doc = "If true, the test will ignore any kuberntetes errors. Use only in situations when error is a part of the normal workflow, like crashlooping to wait for dependencies.",
@@ -460,6 +460,7 @@ fi
         GitopsArtifactsInfo(
             image_pushes = depset(transitive = [obj[GitopsArtifactsInfo].image_pushes for obj in ctx.attr.srcs]),
             deployment_branch = ctx.attr.deployment_branch,
+            release_branch_prefix = ctx.attr.release_branch_prefix,
         ),
     ]
 
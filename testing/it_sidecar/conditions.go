@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+// conditionWait is a resource that should have a status condition with status True
+type conditionWait struct {
+	group, version, kind, name string
+	conditionType              string
+}
+
+func (c conditionWait) String() string {
+	gv := c.version
+	if c.group != "" {
+		gv = c.group + "/" + c.version
+	}
+	return fmt.Sprintf("%s/%s/%s=%s", gv, c.kind, c.name, c.conditionType)
+}
+
+type conditionWaitFlags []conditionWait
+
+func (i *conditionWaitFlags) String() string {
+	return fmt.Sprintf("%v", *i)
+}
+
+// Set parses group/version/kind/name=ConditionType. Core group resources use version/kind/name=ConditionType.
+func (i *conditionWaitFlags) Set(value string) error {
+	res, cond, found := strings.Cut(value, "=")
+	if !found || cond == "" {
+		return fmt.Errorf("incorrect wait_for_condition '%s': must be in form of group/version/kind/name=ConditionType", value)
+	}
+	v := strings.Split(res, "/")
+	var c conditionWait
+	switch len(v) {
+	case 3:
+		c = conditionWait{version: v[0], kind: v[1], name: v[2]}
+	case 4:
+		c = conditionWait{group: v[0], version: v[1], kind: v[2], name: v[3]}
+	default:
+		return fmt.Errorf("incorrect wait_for_condition '%s': must be in form of group/version/kind/name=ConditionType", value)
+	}
+	if c.version == "" || c.kind == "" || c.name == "" {
+		return fmt.Errorf("incorrect wait_for_condition '%s': version, kind and name are required", value)
+	}
+	c.conditionType = cond
+	*i = append(*i, c)
+	return nil
+}
+
+// resourcePath returns the REST API path of the resource, resolving kind to resource name with discovery
+func resourcePath(clientset *kubernetes.Clientset, c conditionWait) (string, error) {
+	gv := c.version
+	prefix := "/api/" + c.version
+	if c.group != "" {
+		gv = c.group + "/" + c.version
+		prefix = "/apis/" + gv
+	}
+	resources, err := clientset.Discovery().ServerResourcesForGroupVersion(gv)
+	if err != nil {
+		return "", fmt.Errorf("unable to discover resources of %s: %w", gv, err)
+	}
+	for _, r := range resources.APIResources {
+		if r.Kind != c.kind || strings.Contains(r.Name, "/") {
+			continue
+		}
+		if r.Namespaced {
+			return fmt.Sprintf("%s/namespaces/%s/%s/%s", prefix, namespace, r.Name, c.name), nil
+		}
+		return fmt.Sprintf("%s/%s/%s", prefix, r.Name, c.name), nil
+	}
+	return "", fmt.Errorf("kind %s is not served by %s", c.kind, gv)
+}
+
+type conditionsStatus struct {
+	Status struct {
+		Conditions []struct {
+			Type    string `json:"type"`
+			Status  string `json:"status"`
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+// conditionStatus fetches the resource and returns the status and message of the condition.
+// Empty status is returned if the resource or the condition does not exist yet, other errors fail the wait.
+func conditionStatus(ctx context.Context, clientset *kubernetes.Clientset, path, conditionType string) (status, message string, err error) {
+	raw, err := clientset.Discovery().RESTClient().Get().AbsPath(path).Do(ctx).Raw()
+	if apierrors.IsNotFound(err) {
+		return "", "resource does not exist yet", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("unable to get %s: %w", path, err)
+	}
+	var obj conditionsStatus
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return "", "", fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	for _, c := range obj.Status.Conditions {
+		if c.Type == conditionType {
+			return c.Status, c.Message, nil
+		}
+	}
+	return "", "condition is not reported yet", nil
+}
+
+// waitForConditions polls resources until all requested conditions are True
+func waitForConditions(ctx context.Context, clientset *kubernetes.Clientset, waits []conditionWait) error {
+	paths := make([]string, len(waits))
+	for i, c := range waits {
+		p, err := resourcePath(clientset, c)
+		if err != nil {
+			return err
+		}
+		paths[i] = p
+	}
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	ready := make([]bool, len(waits))
+	for {
+		var notReady []string
+		for i, c := range waits {
+			if ready[i] {
+				continue
+			}
+			status, message, err := conditionStatus(ctx, clientset, paths[i], c.conditionType)
+			if err != nil {
+				return err
+			}
+			if status == "True" {
+				ready[i] = true
+				log.Print("CONDITION_READY ", c)
+				continue
+			}
+			notReady = append(notReady, fmt.Sprintf("%s (%s)", c, message))
+		}
+		if len(notReady) == 0 {
+			log.Println("all conditions are met")
+			return nil
+		}
+		log.Print("waiting for conditions:", notReady)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for conditions %v", notReady)
+		}
+	}
+}
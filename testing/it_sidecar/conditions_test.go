@@ -0,0 +1,47 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConditionWaitFlags(t *testing.T) {
+	var f conditionWaitFlags
+	for _, v := range []string{
+		"cert-manager.io/v1/Certificate/tls=Ready",
+		"v1/PersistentVolumeClaim/data=Resized",
+	} {
+		if err := f.Set(v); err != nil {
+			t.Fatalf("Set(%q) failed: %v", v, err)
+		}
+	}
+	expected := conditionWaitFlags{
+		{group: "cert-manager.io", version: "v1", kind: "Certificate", name: "tls", conditionType: "Ready"},
+		{version: "v1", kind: "PersistentVolumeClaim", name: "data", conditionType: "Resized"},
+	}
+	if !reflect.DeepEqual(f, expected) {
+		t.Errorf("unexpected conditions %+v", f)
+	}
+	if s := f[0].String(); s != "cert-manager.io/v1/Certificate/tls=Ready" {
+		t.Errorf("unexpected string %q", s)
+	}
+	if s := f[1].String(); s != "v1/PersistentVolumeClaim/data=Resized" {
+		t.Errorf("unexpected string %q", s)
+	}
+
+	for _, v := range []string{
+		"cert-manager.io/v1/Certificate/tls",
+		"cert-manager.io/v1/Certificate/tls=",
+		"Certificate/tls=Ready",
+		"a/b/v1/Certificate/tls=Ready",
+		"cert-manager.io//Certificate/tls=Ready",
+		"v1/Service/=Ready",
+	} {
+		if err := f.Set(v); err == nil {
+			t.Errorf("Set(%q) expected to fail", v)
+		}
+	}
+	if len(f) != 2 {
+		t.Errorf("invalid values were added: %+v", f)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// loadBalancerAddresses returns host names or ips assigned to a load balancer
+func loadBalancerAddresses(ingresses []v1.LoadBalancerIngress) []string {
+	var addrs []string
+	for _, lb := range ingresses {
+		if lb.Hostname != "" {
+			addrs = append(addrs, lb.Hostname)
+		} else if lb.IP != "" {
+			addrs = append(addrs, lb.IP)
+		}
+	}
+	return addrs
+}
+
+func ingressLoadBalancerAddresses(ingresses []networkingv1.IngressLoadBalancerIngress) []string {
+	var addrs []string
+	for _, lb := range ingresses {
+		if lb.Hostname != "" {
+			addrs = append(addrs, lb.Hostname)
+		} else if lb.IP != "" {
+			addrs = append(addrs, lb.IP)
+		}
+	}
+	return addrs
+}
+
+func joinHostPorts(addrs []string, ports []int32) []string {
+	var endpoints []string
+	for _, a := range addrs {
+		for _, p := range ports {
+			endpoints = append(endpoints, net.JoinHostPort(a, strconv.Itoa(int(p))))
+		}
+	}
+	return endpoints
+}
+
+// ingressEndpoints returns host:port endpoints of an Ingress, empty until an address is assigned
+func ingressEndpoints(ctx context.Context, clientset *kubernetes.Clientset, name string) ([]string, error) {
+	ing, err := clientset.NetworkingV1().Ingresses(namespace).Get(ctx, name, meta_v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ports := []int32{80}
+	if len(ing.Spec.TLS) > 0 {
+		ports = append(ports, 443)
+	}
+	return joinHostPorts(ingressLoadBalancerAddresses(ing.Status.LoadBalancer.Ingress), ports), nil
+}
+
+// loadBalancerEndpoints returns host:port endpoints of a Service type=LoadBalancer, empty until an address is assigned
+func loadBalancerEndpoints(ctx context.Context, clientset *kubernetes.Clientset, name string) ([]string, error) {
+	svc, err := clientset.CoreV1().Services(namespace).Get(ctx, name, meta_v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if svc.Spec.Type != v1.ServiceTypeLoadBalancer {
+		return nil, fmt.Errorf("service %s is of type %s, not LoadBalancer", name, svc.Spec.Type)
+	}
+	var ports []int32
+	for _, p := range svc.Spec.Ports {
+		ports = append(ports, p.Port)
+	}
+	return joinHostPorts(loadBalancerAddresses(svc.Status.LoadBalancer.Ingress), ports), nil
+}
+
+// waitForExternalEndpoints polls Ingresses and LoadBalancer Services until all of them have an address.
+// Every endpoint is printed as "ENDPOINT kind/name host:port" and all endpoints are written to endpointsFile if set.
+func waitForExternalEndpoints(ctx context.Context, clientset *kubernetes.Clientset) error {
+	type pending struct {
+		key  string
+		name string
+		get  func(context.Context, *kubernetes.Clientset, string) ([]string, error)
+	}
+	var waits []pending
+	for _, name := range waitForIngresses {
+		waits = append(waits, pending{"ingress/" + name, name, ingressEndpoints})
+	}
+	for _, name := range waitForLoadBalancers {
+		waits = append(waits, pending{"service/" + name, name, loadBalancerEndpoints})
+	}
+	endpoints := make(map[string][]string)
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	for {
+		var notReady []string
+		for _, w := range waits {
+			if _, ok := endpoints[w.key]; ok {
+				continue
+			}
+			eps, err := w.get(ctx, clientset, w.name)
+			if err != nil {
+				log.Printf("%s: %v", w.key, err)
+			}
+			if len(eps) == 0 {
+				notReady = append(notReady, w.key)
+				continue
+			}
+			endpoints[w.key] = eps
+			for _, ep := range eps {
+				fmt.Printf("ENDPOINT %s %s\n", w.key, ep)
+			}
+		}
+		if len(notReady) == 0 {
+			log.Println("all external endpoints are ready")
+			break
+		}
+		log.Print("waiting for external addresses:", notReady)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for external addresses %v", notReady)
+		}
+	}
+	if endpointsFile == "" {
+		return nil
+	}
+	for _, eps := range endpoints {
+		sort.Strings(eps)
+	}
+	b, err := json.MarshalIndent(endpoints, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(endpointsFile, b, 0644)
+}
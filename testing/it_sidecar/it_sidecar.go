@@ -73,6 +73,11 @@ var (
 	waitForApps    arrayFlags
 	allowErrors    bool
 	disablePodLogs bool
+	waitConditions conditionWaitFlags
+	// external endpoints
+	waitForIngresses     arrayFlags
+	waitForLoadBalancers arrayFlags
+	endpointsFile        string
 )
 
 func init() {
@@ -83,6 +88,10 @@ func init() {
 	flag.Var(&waitForApps, "waitforapp", "wait for pods with label app=<this parameter>")
 	flag.BoolVar(&allowErrors, "allow_errors", false, "do not treat Failed in events as error. Use only if crashloop is expected")
 	flag.BoolVar(&disablePodLogs, "disable_pod_logs", false, "do not forward pod logs")
+	flag.Var(&waitForIngresses, "wait_for_ingress", "wait for the Ingress with this name to get an address and print its endpoints")
+	flag.Var(&waitForLoadBalancers, "wait_for_loadbalancer", "wait for the Service type=LoadBalancer with this name to get an address and print its endpoints")
+	flag.StringVar(&endpointsFile, "endpoints_file", "", "write external endpoints of ingresses and load balancers to this JSON file")
+	flag.Var(&waitConditions, "wait_for_condition", "wait for status condition of any resource to become True, in form of group/version/kind/name=ConditionType (version/kind/name=ConditionType for core resources)")
 }
 
 // contains returns true if slice v contains an item
@@ -401,6 +410,21 @@ func main() {
 		}
 	}
 
+	if len(waitForIngresses) > 0 || len(waitForLoadBalancers) > 0 {
+		err = waitForExternalEndpoints(ctx, clientset)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+	}
+	if len(waitConditions) > 0 {
+		err = waitForConditions(ctx, clientset, waitConditions)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+	}
+
 	fmt.Println("READY")
 	<-ctx.Done()
 	if cause := context.Cause(ctx); cause != nil {